@@ -31,6 +31,7 @@ import (
 	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/kubernetes"
 	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/internal/pki"
 	"github.com/crunchydata/postgres-operator/internal/tracing"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
@@ -89,6 +90,30 @@ func initLogging() {
 	klog.SetLoggerWithOptions(global, klog.ContextualLogger(true))
 }
 
+// initPKI reads the settings for generated keys and certificates from the
+// environment.
+func initPKI() (pki.Options, error) {
+	var err error
+	options := pki.Options{
+		KeyAlgorithm: pki.KeyAlgorithm(strings.TrimSpace(os.Getenv("PGO_PKI_KEY_ALGORITHM"))),
+	}
+
+	if s := strings.TrimSpace(os.Getenv("PGO_PKI_LEAF_LIFETIME")); s != "" {
+		options.LeafLifetime, err = time.ParseDuration(s)
+		if err != nil {
+			err = fmt.Errorf("value for PGO_PKI_LEAF_LIFETIME is invalid: %w", err)
+		}
+	}
+	if s := strings.TrimSpace(os.Getenv("PGO_PKI_ROOT_LIFETIME")); s != "" && err == nil {
+		options.RootLifetime, err = time.ParseDuration(s)
+		if err != nil {
+			err = fmt.Errorf("value for PGO_PKI_ROOT_LIFETIME is invalid: %w", err)
+		}
+	}
+
+	return options, err
+}
+
 //+kubebuilder:rbac:groups="coordination.k8s.io",resources="leases",verbs={get,create,update,watch}
 //+kubebuilder:rbac:groups="authentication.k8s.io",resources="tokenreviews",verbs={create}
 //+kubebuilder:rbac:groups="authorization.k8s.io",resources="subjectaccessreviews",verbs={create}
@@ -212,6 +237,8 @@ func main() {
 		// These are enabled, including features that are on by default
 		"enabled", feature.ShowEnabled(running))
 
+	must(pki.SetOptions(need(initPKI())))
+
 	// Initialize OpenTelemetry and flush data when there is a panic.
 	otelFinish := need(initOpenTelemetry(running))
 	defer func(ctx context.Context) { _ = otelFinish(ctx) }(running)
@@ -12,6 +12,8 @@ import (
 
 	"gotest.tools/v3/assert"
 	"gotest.tools/v3/assert/cmp"
+
+	"github.com/crunchydata/postgres-operator/internal/pki"
 )
 
 func TestInitManager(t *testing.T) {
@@ -128,3 +130,32 @@ func TestInitManager(t *testing.T) {
 		})
 	})
 }
+
+func TestInitPKI(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		options, err := initPKI()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, options, pki.Options{})
+	})
+
+	t.Run("Settings", func(t *testing.T) {
+		t.Setenv("PGO_PKI_KEY_ALGORITHM", "RSA-4096")
+		t.Setenv("PGO_PKI_LEAF_LIFETIME", "720h")
+		t.Setenv("PGO_PKI_ROOT_LIFETIME", "87600h")
+
+		options, err := initPKI()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, options, pki.Options{
+			KeyAlgorithm: pki.RSA4096,
+			LeafLifetime: 720 * time.Hour,
+			RootLifetime: 87600 * time.Hour,
+		})
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Setenv("PGO_PKI_LEAF_LIFETIME", "1 year")
+
+		_, err := initPKI()
+		assert.ErrorContains(t, err, "PGO_PKI_LEAF_LIFETIME")
+	})
+}
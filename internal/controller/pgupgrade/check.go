@@ -0,0 +1,306 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgupgrade
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// checkTimeout is how long "pg_upgrade --check" can take, including the time to
+// provision any clones of volumes, before its Job fails.
+const checkTimeout = 30 * time.Minute
+
+// pgUpgradeCheckJob returns the ObjectMeta for the Job that runs
+// "pg_upgrade --check" before a PostgreSQL major version upgrade.
+func pgUpgradeCheckJob(upgrade *v1beta1.PGUpgrade) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: upgrade.Namespace,
+		Name:      upgrade.Name + "-check",
+	}
+}
+
+// checkInput returns a value that identifies the command of a check of upgrade
+// and the data it reads: the volumes of source or clones of them. The result
+// of a check applies only while this value is the same.
+func checkInput(upgrade *v1beta1.PGUpgrade, source *appsv1.StatefulSet, clones bool) string {
+	hash := fnv.New32a()
+	_, _ = fmt.Fprint(hash, checkCommand(&upgrade.Spec.PGUpgradeSettings), source.Name, clones)
+	return fmt.Sprintf("%x", hash.Sum32())
+}
+
+// generateCheckVolume returns a PersistentVolumeClaim that is a clone of source.
+// - https://docs.k8s.io/concepts/storage/volume-pvc-datasource/
+func (r *PGUpgradeReconciler) generateCheckVolume(
+	upgrade *v1beta1.PGUpgrade, source *corev1.PersistentVolumeClaim,
+) *corev1.PersistentVolumeClaim {
+	volume := &corev1.PersistentVolumeClaim{}
+	volume.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"))
+
+	volume.Namespace = upgrade.Namespace
+	volume.Name = pgUpgradeCheckJob(upgrade).Name + "-" + source.Name
+
+	volume.Annotations = upgrade.Spec.Metadata.GetAnnotationsOrNil()
+	volume.Labels = Merge(upgrade.Spec.Metadata.GetLabelsOrNil(),
+		commonLabels(pgUpgradeCheck, upgrade))
+
+	// A clone must be in the same storage class and at least as large as its source.
+	volume.Spec.AccessModes = source.Spec.AccessModes
+	volume.Spec.Resources.Requests = corev1.ResourceList{
+		corev1.ResourceStorage: source.Spec.Resources.Requests[corev1.ResourceStorage],
+	}
+	volume.Spec.StorageClassName = source.Spec.StorageClassName
+	volume.Spec.VolumeMode = source.Spec.VolumeMode
+	volume.Spec.DataSource = &corev1.TypedLocalObjectReference{
+		Kind: "PersistentVolumeClaim",
+		Name: source.Name,
+	}
+
+	r.setControllerReference(upgrade, volume)
+	return volume
+}
+
+// generateCheckJob returns a Job that runs "pg_upgrade --check" against the
+// data of the source instance. Volumes of source named in clones are replaced
+// by the claims they map to. The Job fails when it does not finish within
+// [checkTimeout], such as when its clones cannot be provisioned.
+func (r *PGUpgradeReconciler) generateCheckJob(
+	ctx context.Context, upgrade *v1beta1.PGUpgrade,
+	source *appsv1.StatefulSet, clones map[string]string,
+) *batchv1.Job {
+	// Start with the upgrade Job which has the same image, tools, and volumes.
+	job := r.generateUpgradeJob(ctx, upgrade, source)
+	job.Name = pgUpgradeCheckJob(upgrade).Name
+	job.Annotations = Merge(job.Annotations, map[string]string{
+		annotationCheckInput: checkInput(upgrade, source, len(clones) > 0),
+	})
+
+	job.Labels = Merge(upgrade.Spec.Metadata.GetLabelsOrNil(),
+		commonLabels(pgUpgradeCheck, upgrade),
+		map[string]string{
+			LabelVersion: fmt.Sprint(upgrade.Spec.ToPostgresVersion),
+		})
+	job.Spec.Template.Labels = job.Labels
+	job.Spec.ActiveDeadlineSeconds = initialize.Int64(int64(checkTimeout.Seconds()))
+
+	// The check command writes its report to the termination message. Fallback
+	// to the end of the logs when it fails before it can do so.
+	container := &job.Spec.Template.Spec.Containers[0]
+	container.Command = checkCommand(&upgrade.Spec.PGUpgradeSettings)
+	container.TerminationMessagePolicy = corev1.TerminationMessageFallbackToLogsOnError
+
+	for i := range job.Spec.Template.Spec.Volumes {
+		if claim := job.Spec.Template.Spec.Volumes[i].PersistentVolumeClaim; claim != nil {
+			if clone, ok := clones[claim.ClaimName]; ok {
+				claim.ClaimName = clone
+			}
+		}
+	}
+
+	return job
+}
+
+// checkResult matches a line of pg_upgrade output that reports the result of
+// one of its checks, e.g. "Checking cluster versions    ok".
+var checkResult = regexp.MustCompile(`^(Checking\s.*\S)\s+(\S+)$`)
+
+// parseCheckReport returns the checks in the output of "pg_upgrade --check"
+// that did not pass along with the explanation printed after each.
+func parseCheckReport(report string) []v1beta1.PGUpgradeCheckFailure {
+	var failures []v1beta1.PGUpgradeCheckFailure
+	var details []string
+
+	finish := func() {
+		if len(failures) > 0 && len(details) > 0 {
+			failures[len(failures)-1].Details = strings.TrimSpace(strings.Join(details, "\n"))
+		}
+		details = nil
+	}
+
+	var failing bool
+	for line := range strings.Lines(report) {
+		line = strings.TrimRight(line, " \r\n")
+
+		if match := checkResult.FindStringSubmatch(line); match != nil {
+			finish()
+			failing = match[2] != "ok"
+			if failing {
+				failures = append(failures, v1beta1.PGUpgradeCheckFailure{
+					Check: match[1], Result: match[2],
+				})
+			}
+		} else if line == "Failure, exiting" {
+			finish()
+			failing = false
+		} else if failing {
+			details = append(details, line)
+		}
+	}
+	finish()
+
+	return failures
+}
+
+//+kubebuilder:rbac:groups="",resources="persistentvolumeclaims",verbs={create,patch}
+//+kubebuilder:rbac:groups="",resources="persistentvolumeclaims",verbs={delete}
+//+kubebuilder:rbac:groups="batch",resources="jobs",verbs={create,patch}
+//+kubebuilder:rbac:groups="batch",resources="jobs",verbs={delete}
+
+// reconcileUpgradeCheck runs "pg_upgrade --check" and records its result in
+// the status of upgrade. While the cluster is running, the check reads clones
+// of the leader's volumes; this happens only when checkOnly is set. After the
+// cluster is shut down, it reads the volumes of the startup instance. Deleting
+// the check Job runs it again, and so does any change to what it reads or runs,
+// such as when the cluster shuts down after a check of clones.
+func (r *PGUpgradeReconciler) reconcileUpgradeCheck(
+	ctx context.Context, upgrade *v1beta1.PGUpgrade, world *World,
+) error {
+	var err error
+	job := world.Jobs[pgUpgradeCheckJob(upgrade).Name]
+
+	source := world.ClusterLeader
+	if world.ClusterShutdown {
+		source = world.ClusterPrimary
+	}
+
+	setChecked := func(status metav1.ConditionStatus, reason, message string) {
+		meta.SetStatusCondition(&upgrade.Status.Conditions, metav1.Condition{
+			ObservedGeneration: upgrade.GetGeneration(),
+			Type:               ConditionPGUpgradeChecked,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+
+	// The result of a check that read or ran something else does not apply.
+	// Delete its Job so the check runs again.
+	if job != nil && source != nil &&
+		job.Annotations[annotationCheckInput] != checkInput(upgrade, source, !world.ClusterShutdown) {
+		uid := job.GetUID()
+		version := job.GetResourceVersion()
+		exactly := client.Preconditions{UID: &uid, ResourceVersion: &version}
+		propagate := client.PropagationPolicy(metav1.DeletePropagationBackground)
+		err = client.IgnoreNotFound(r.Writer.Delete(ctx, job, exactly, propagate))
+
+		if err == nil {
+			upgrade.Status.Check = nil
+			setChecked(metav1.ConditionUnknown, "PGUpgradeCheckWaiting", fmt.Sprintf(
+				"Checking PostgresCluster %s again because its data or the upgrade changed",
+				upgrade.Spec.PostgresClusterName))
+		}
+		return errors.WithStack(err)
+	}
+
+	if job == nil {
+		if source == nil {
+			setChecked(metav1.ConditionUnknown, "PGUpgradeCheckWaiting",
+				"PostgresCluster primary instance not identified")
+			return nil
+		}
+
+		// Clone the volumes of a running instance rather than share them.
+		clones := make(map[string]string)
+		if !world.ClusterShutdown {
+			for _, volume := range source.Spec.Template.Spec.Volumes {
+				if claim := volume.PersistentVolumeClaim; err == nil && claim != nil &&
+					world.Volumes[claim.ClaimName] != nil {
+					clone := r.generateCheckVolume(upgrade, world.Volumes[claim.ClaimName])
+					clones[claim.ClaimName] = clone.Name
+					err = errors.WithStack(runtime.Apply(ctx, r.Writer, clone))
+				}
+			}
+		}
+		if err == nil {
+			err = errors.WithStack(runtime.Apply(ctx, r.Writer,
+				r.generateCheckJob(ctx, upgrade, source, clones)))
+		}
+		if err == nil {
+			upgrade.Status.Check = nil
+			setChecked(metav1.ConditionUnknown, "PGUpgradeCheckRunning", fmt.Sprintf(
+				"Checking PostgresCluster %s for upgrade to version %d",
+				upgrade.Spec.PostgresClusterName, upgrade.Spec.ToPostgresVersion))
+		}
+		return err
+	}
+
+	if !jobCompleted(job) && !jobFailed(job) {
+		setChecked(metav1.ConditionUnknown, "PGUpgradeCheckRunning", fmt.Sprintf(
+			"Checking PostgresCluster %s for upgrade to version %d",
+			upgrade.Spec.PostgresClusterName, upgrade.Spec.ToPostgresVersion))
+		return nil
+	}
+
+	// The check is done; remove any clones. Pods that have stopped do not
+	// prevent the deletion of their volumes.
+	for _, volume := range world.Volumes {
+		if err == nil &&
+			volume.Labels[LabelPGUpgrade] == upgrade.Name &&
+			volume.Labels[LabelRole] == pgUpgradeCheck {
+			uid := volume.GetUID()
+			version := volume.GetResourceVersion()
+			exactly := client.Preconditions{UID: &uid, ResourceVersion: &version}
+			err = client.IgnoreNotFound(r.Writer.Delete(ctx, volume, exactly))
+		}
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var report string
+	for _, pod := range world.CheckPods {
+		if owner := metav1.GetControllerOf(pod); owner != nil && owner.UID == job.UID {
+			for _, status := range pod.Status.ContainerStatuses {
+				if status.State.Terminated != nil {
+					report = status.State.Terminated.Message
+				}
+			}
+		}
+	}
+
+	upgrade.Status.Check = &v1beta1.PGUpgradeCheckStatus{
+		Failures: parseCheckReport(report),
+	}
+
+	if jobCompleted(job) {
+		setChecked(metav1.ConditionTrue, "PGUpgradeCheckPassed", fmt.Sprintf(
+			"PostgresCluster %s can be upgraded to version %d",
+			upgrade.Spec.PostgresClusterName, upgrade.Spec.ToPostgresVersion))
+	} else if jobTimedOut(job) {
+		setChecked(metav1.ConditionFalse, "PGUpgradeCheckTimedOut", fmt.Sprintf(
+			"Upgrade check did not finish within %v; the storage class may not support cloning volumes."+
+				" Delete Job %s to check again", checkTimeout, job.Name))
+	} else {
+		message := "please check pod logs"
+		if failures := upgrade.Status.Check.Failures; len(failures) > 0 {
+			checks := make([]string, len(failures))
+			for i := range failures {
+				checks[i] = failures[i].Check + ": " + failures[i].Result
+			}
+			message = strings.Join(checks, "; ")
+		}
+		setChecked(metav1.ConditionFalse, "PGUpgradeCheckFailed", fmt.Sprintf(
+			"Upgrade check failed, %s. Delete Job %s to check again",
+			message, job.Name))
+	}
+
+	return nil
+}
@@ -0,0 +1,459 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgupgrade
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestCheckCommand(t *testing.T) {
+	spec := &v1beta1.PGUpgradeSettings{FromPostgresVersion: 16, ToPostgresVersion: 17}
+	command := checkCommand(spec)
+	assert.Assert(t, len(command) > 3)
+	assert.DeepEqual(t, []string{"bash", "-c", "--"}, command[:3])
+	assert.DeepEqual(t, []string{"check", "16", "17"}, command[4:])
+
+	script := command[3]
+	assert.Assert(t, cmp.Contains(script, `Step 6 of 6: Checking for potential issues...`))
+	assert.Assert(t, cmp.Contains(script, `new_data="${data_volume}/pg${new_version}_check"`))
+	assert.Assert(t, cmp.Contains(script, `"${new_bin}/pg_upgrade" --check --link --jobs=1`))
+	assert.Assert(t, cmp.Contains(script, `> /dev/termination-log`))
+
+	assert.Assert(t, !strings.Contains(script, `Performing upgrade`),
+		"expected no upgrade, got:\n%s", script)
+}
+
+func TestParseCheckReport(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Assert(t, parseCheckReport("") == nil)
+	})
+
+	t.Run("Compatible", func(t *testing.T) {
+		assert.Assert(t, parseCheckReport(strings.Join([]string{
+			`Performing Consistency Checks`,
+			`-----------------------------`,
+			`Checking cluster versions                                     ok`,
+			`Checking database user is the install user                    ok`,
+			``,
+			`*Clusters are compatible*`,
+		}, "\n")) == nil)
+	})
+
+	t.Run("Fatal", func(t *testing.T) {
+		failures := parseCheckReport(strings.Join([]string{
+			`Checking cluster versions                                     ok`,
+			`Checking for reg* data types in user tables                   fatal`,
+			``,
+			`Your installation contains one of the reg* data types in user tables.`,
+			`A list of the problem columns is in the file:`,
+			`    /pgdata/pg17_check/pg_upgrade_output.d/tables_using_reg.txt`,
+			``,
+			`Failure, exiting`,
+		}, "\n"))
+
+		assert.DeepEqual(t, failures, []v1beta1.PGUpgradeCheckFailure{{
+			Check:  `Checking for reg* data types in user tables`,
+			Result: `fatal`,
+			Details: strings.Join([]string{
+				`Your installation contains one of the reg* data types in user tables.`,
+				`A list of the problem columns is in the file:`,
+				`    /pgdata/pg17_check/pg_upgrade_output.d/tables_using_reg.txt`,
+			}, "\n"),
+		}})
+	})
+}
+
+func TestGenerateCheckVolume(t *testing.T) {
+	reconciler := &PGUpgradeReconciler{}
+
+	upgrade := &v1beta1.PGUpgrade{}
+	upgrade.Namespace = "ns1"
+	upgrade.Name = "pgu2"
+	upgrade.UID = "uid3"
+	upgrade.Spec.PostgresClusterName = "pg5"
+
+	source := &corev1.PersistentVolumeClaim{}
+	source.Name = "pg5-instance1-abcd-pgdata"
+	source.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	source.Spec.StorageClassName = initialize.String("fast")
+	source.Spec.Resources.Requests = corev1.ResourceList{
+		corev1.ResourceStorage: resource.MustParse("5Gi"),
+	}
+
+	volume := reconciler.generateCheckVolume(upgrade, source)
+	assert.Assert(t, cmp.MarshalMatches(volume, `
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  labels:
+    postgres-operator.crunchydata.com/cluster: pg5
+    postgres-operator.crunchydata.com/pgupgrade: pgu2
+    postgres-operator.crunchydata.com/role: pgupgrade-check
+  name: pgu2-check-pg5-instance1-abcd-pgdata
+  namespace: ns1
+  ownerReferences:
+  - apiVersion: postgres-operator.crunchydata.com/v1beta1
+    blockOwnerDeletion: true
+    controller: true
+    kind: PGUpgrade
+    name: pgu2
+    uid: uid3
+spec:
+  accessModes:
+  - ReadWriteOnce
+  dataSource:
+    apiGroup: null
+    kind: PersistentVolumeClaim
+    name: pg5-instance1-abcd-pgdata
+  resources:
+    requests:
+      storage: 5Gi
+  storageClassName: fast
+status: {}
+	`))
+}
+
+func TestGenerateCheckJob(t *testing.T) {
+	ctx := context.Background()
+	reconciler := &PGUpgradeReconciler{}
+
+	upgrade := &v1beta1.PGUpgrade{}
+	upgrade.Namespace = "ns1"
+	upgrade.Name = "pgu2"
+	upgrade.UID = "uid3"
+	upgrade.Spec.Image = initialize.Pointer("img4")
+	upgrade.Spec.PostgresClusterName = "pg5"
+	upgrade.Spec.FromPostgresVersion = 19
+	upgrade.Spec.ToPostgresVersion = 25
+
+	source := &appsv1.StatefulSet{}
+	source.Spec.Template.Spec = corev1.PodSpec{
+		Containers: []corev1.Container{{Name: ContainerDatabase}},
+		Volumes: []corev1.Volume{
+			{
+				Name: "postgres-data",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "some-pgdata",
+					},
+				},
+			},
+			{
+				Name: "other",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "other-claim",
+					},
+				},
+			},
+		},
+	}
+
+	job := reconciler.generateCheckJob(ctx, upgrade, source, map[string]string{
+		"some-pgdata": "pgu2-check-some-pgdata",
+	})
+
+	assert.Equal(t, job.Name, "pgu2-check")
+	assert.Equal(t, job.Labels[LabelRole], "pgupgrade-check")
+	assert.DeepEqual(t, job.Labels, job.Spec.Template.Labels)
+	assert.Equal(t, *job.Spec.BackoffLimit, int32(0))
+
+	container := job.Spec.Template.Spec.Containers[0]
+	assert.DeepEqual(t, container.Command, checkCommand(&upgrade.Spec.PGUpgradeSettings))
+	assert.Equal(t, container.TerminationMessagePolicy,
+		corev1.TerminationMessageFallbackToLogsOnError)
+
+	assert.Assert(t, cmp.MarshalMatches(job.Spec.Template.Spec.Volumes, `
+- name: postgres-data
+  persistentVolumeClaim:
+    claimName: pgu2-check-some-pgdata
+- name: other
+  persistentVolumeClaim:
+    claimName: other-claim
+	`))
+
+	// The StatefulSet is unchanged.
+	assert.Equal(t, source.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName, "some-pgdata")
+}
+
+// recordingWriter records the objects passed to its methods.
+type recordingWriter struct {
+	deleted []client.Object
+	patched []client.Object
+}
+
+func (w *recordingWriter) Delete(_ context.Context, object client.Object, _ ...client.DeleteOption) error {
+	w.deleted = append(w.deleted, object)
+	return nil
+}
+
+func (w *recordingWriter) Patch(_ context.Context, object client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	w.patched = append(w.patched, object)
+	return nil
+}
+
+func TestReconcileUpgradeCheck(t *testing.T) {
+	ctx := context.Background()
+
+	newUpgrade := func() *v1beta1.PGUpgrade {
+		upgrade := &v1beta1.PGUpgrade{}
+		upgrade.Namespace = "ns1"
+		upgrade.Name = "pgu2"
+		upgrade.UID = "uid3"
+		upgrade.Spec.Image = initialize.Pointer("img4")
+		upgrade.Spec.PostgresClusterName = "pg5"
+		upgrade.Spec.FromPostgresVersion = 16
+		upgrade.Spec.ToPostgresVersion = 17
+		return upgrade
+	}
+
+	instance := &appsv1.StatefulSet{}
+	instance.Name = "pg5-instance1-abcd"
+	instance.Spec.Template.Spec = corev1.PodSpec{
+		Containers: []corev1.Container{{Name: ContainerDatabase}},
+		Volumes: []corev1.Volume{{
+			Name: "postgres-data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "pg5-instance1-abcd-pgdata",
+				},
+			},
+		}},
+	}
+
+	data := &corev1.PersistentVolumeClaim{}
+	data.Name = "pg5-instance1-abcd-pgdata"
+
+	t.Run("NoPrimary", func(t *testing.T) {
+		writer := new(recordingWriter)
+		reconciler := &PGUpgradeReconciler{Writer: writer}
+		upgrade := newUpgrade()
+
+		assert.NilError(t, reconciler.reconcileUpgradeCheck(ctx, upgrade, NewWorld()))
+		assert.Assert(t, len(writer.patched) == 0)
+
+		checked := meta.FindStatusCondition(upgrade.Status.Conditions, ConditionPGUpgradeChecked)
+		assert.Assert(t, checked != nil)
+		assert.Equal(t, checked.Status, metav1.ConditionUnknown)
+		assert.Equal(t, checked.Reason, "PGUpgradeCheckWaiting")
+	})
+
+	t.Run("Running", func(t *testing.T) {
+		writer := new(recordingWriter)
+		reconciler := &PGUpgradeReconciler{Writer: writer}
+		upgrade := newUpgrade()
+
+		world := NewWorld()
+		world.ClusterLeader = instance
+		world.Volumes[data.Name] = data
+
+		assert.NilError(t, reconciler.reconcileUpgradeCheck(ctx, upgrade, world))
+		assert.Equal(t, len(writer.patched), 2)
+		assert.Equal(t, writer.patched[0].GetName(), "pgu2-check-pg5-instance1-abcd-pgdata")
+		assert.Equal(t, writer.patched[1].GetName(), "pgu2-check")
+
+		job := writer.patched[1].(*batchv1.Job)
+		assert.Equal(t,
+			job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName,
+			"pgu2-check-pg5-instance1-abcd-pgdata")
+		assert.DeepEqual(t, job.Spec.ActiveDeadlineSeconds, initialize.Int64(1800))
+
+		checked := meta.FindStatusCondition(upgrade.Status.Conditions, ConditionPGUpgradeChecked)
+		assert.Assert(t, checked != nil)
+		assert.Equal(t, checked.Status, metav1.ConditionUnknown)
+		assert.Equal(t, checked.Reason, "PGUpgradeCheckRunning")
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		writer := new(recordingWriter)
+		reconciler := &PGUpgradeReconciler{Writer: writer}
+		upgrade := newUpgrade()
+
+		world := NewWorld()
+		world.ClusterPrimary = instance
+		world.ClusterShutdown = true
+		world.Volumes[data.Name] = data
+
+		assert.NilError(t, reconciler.reconcileUpgradeCheck(ctx, upgrade, world))
+		assert.Equal(t, len(writer.patched), 1, "expected no clones")
+
+		job := writer.patched[0].(*batchv1.Job)
+		assert.Equal(t,
+			job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName,
+			"pg5-instance1-abcd-pgdata")
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		writer := new(recordingWriter)
+		reconciler := &PGUpgradeReconciler{Writer: writer}
+		upgrade := newUpgrade()
+
+		job := &batchv1.Job{}
+		job.Name = "pgu2-check"
+		job.UID = "job-uid"
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type: batchv1.JobFailed, Status: corev1.ConditionTrue,
+		}}
+
+		clone := &corev1.PersistentVolumeClaim{}
+		clone.Name = "pgu2-check-pg5-instance1-abcd-pgdata"
+		clone.Labels = commonLabels(pgUpgradeCheck, upgrade)
+
+		pod := &corev1.Pod{}
+		pod.OwnerReferences = []metav1.OwnerReference{{
+			Controller: initialize.Bool(true), UID: "job-uid",
+		}}
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					Message: strings.Join([]string{
+						`Checking for reg* data types in user tables                   fatal`,
+						`Your installation contains one of the reg* data types in user tables.`,
+						`Failure, exiting`,
+					}, "\n"),
+				},
+			},
+		}}
+
+		world := NewWorld()
+		world.Jobs[job.Name] = job
+		world.Volumes[data.Name] = data
+		world.Volumes[clone.Name] = clone
+		world.CheckPods = []*corev1.Pod{pod}
+
+		assert.NilError(t, reconciler.reconcileUpgradeCheck(ctx, upgrade, world))
+		assert.Equal(t, len(writer.deleted), 1, "expected only the clone to be deleted")
+		assert.Equal(t, writer.deleted[0].GetName(), clone.Name)
+
+		assert.Assert(t, upgrade.Status.Check != nil)
+		assert.DeepEqual(t, upgrade.Status.Check.Failures, []v1beta1.PGUpgradeCheckFailure{{
+			Check:   `Checking for reg* data types in user tables`,
+			Result:  `fatal`,
+			Details: `Your installation contains one of the reg* data types in user tables.`,
+		}})
+
+		checked := meta.FindStatusCondition(upgrade.Status.Conditions, ConditionPGUpgradeChecked)
+		assert.Assert(t, checked != nil)
+		assert.Equal(t, checked.Status, metav1.ConditionFalse)
+		assert.Equal(t, checked.Reason, "PGUpgradeCheckFailed")
+		assert.Assert(t, cmp.Contains(checked.Message,
+			"Checking for reg* data types in user tables: fatal"))
+		assert.Assert(t, cmp.Contains(checked.Message, "Delete Job pgu2-check"))
+	})
+
+	t.Run("TimedOut", func(t *testing.T) {
+		writer := new(recordingWriter)
+		reconciler := &PGUpgradeReconciler{Writer: writer}
+		upgrade := newUpgrade()
+
+		job := &batchv1.Job{}
+		job.Name = "pgu2-check"
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type: batchv1.JobFailed, Status: corev1.ConditionTrue,
+			Reason: batchv1.JobReasonDeadlineExceeded,
+		}}
+
+		clone := &corev1.PersistentVolumeClaim{}
+		clone.Name = "pgu2-check-pg5-instance1-abcd-pgdata"
+		clone.Labels = commonLabels(pgUpgradeCheck, upgrade)
+
+		world := NewWorld()
+		world.Jobs[job.Name] = job
+		world.Volumes[clone.Name] = clone
+
+		assert.NilError(t, reconciler.reconcileUpgradeCheck(ctx, upgrade, world))
+		assert.Equal(t, len(writer.deleted), 1, "expected the clone to be deleted")
+
+		checked := meta.FindStatusCondition(upgrade.Status.Conditions, ConditionPGUpgradeChecked)
+		assert.Assert(t, checked != nil)
+		assert.Equal(t, checked.Status, metav1.ConditionFalse)
+		assert.Equal(t, checked.Reason, "PGUpgradeCheckTimedOut")
+		assert.Assert(t, cmp.Contains(checked.Message, "did not finish within 30m0s"))
+		assert.Assert(t, cmp.Contains(checked.Message, "Delete Job pgu2-check"))
+	})
+
+	t.Run("Passed", func(t *testing.T) {
+		writer := new(recordingWriter)
+		reconciler := &PGUpgradeReconciler{Writer: writer}
+		upgrade := newUpgrade()
+
+		job := &batchv1.Job{}
+		job.Name = "pgu2-check"
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type: batchv1.JobComplete, Status: corev1.ConditionTrue,
+		}}
+
+		world := NewWorld()
+		world.Jobs[job.Name] = job
+
+		assert.NilError(t, reconciler.reconcileUpgradeCheck(ctx, upgrade, world))
+		assert.Assert(t, upgrade.Status.Check != nil)
+		assert.Assert(t, upgrade.Status.Check.Failures == nil)
+
+		checked := meta.FindStatusCondition(upgrade.Status.Conditions, ConditionPGUpgradeChecked)
+		assert.Assert(t, checked != nil)
+		assert.Equal(t, checked.Status, metav1.ConditionTrue)
+		assert.Equal(t, checked.Reason, "PGUpgradeCheckPassed")
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		writer := new(recordingWriter)
+		reconciler := &PGUpgradeReconciler{Writer: writer}
+		upgrade := newUpgrade()
+
+		// This check passed while reading clones of a running cluster.
+		running := NewWorld()
+		running.ClusterLeader = instance
+		running.Volumes[data.Name] = data
+
+		assert.NilError(t, reconciler.reconcileUpgradeCheck(ctx, upgrade, running))
+		job := writer.patched[len(writer.patched)-1].(*batchv1.Job)
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type: batchv1.JobComplete, Status: corev1.ConditionTrue,
+		}}
+		running.Jobs[job.Name] = job
+
+		assert.NilError(t, reconciler.reconcileUpgradeCheck(ctx, upgrade, running))
+		assert.Equal(t, meta.FindStatusCondition(upgrade.Status.Conditions,
+			ConditionPGUpgradeChecked).Reason, "PGUpgradeCheckPassed")
+
+		// That result does not apply once the cluster is shut down.
+		stopped := NewWorld()
+		stopped.ClusterPrimary = instance
+		stopped.ClusterShutdown = true
+		stopped.Jobs[job.Name] = job
+
+		writer.deleted = nil
+		assert.NilError(t, reconciler.reconcileUpgradeCheck(ctx, upgrade, stopped))
+		assert.Equal(t, len(writer.deleted), 1)
+		assert.Equal(t, writer.deleted[0].GetName(), "pgu2-check")
+		assert.Assert(t, upgrade.Status.Check == nil)
+
+		checked := meta.FindStatusCondition(upgrade.Status.Conditions, ConditionPGUpgradeChecked)
+		assert.Equal(t, checked.Status, metav1.ConditionUnknown)
+		assert.Equal(t, checked.Reason, "PGUpgradeCheckWaiting")
+
+		// Neither does a result for other settings.
+		writer.deleted = nil
+		upgrade.Spec.TransferMethod = "Copy"
+		assert.NilError(t, reconciler.reconcileUpgradeCheck(ctx, upgrade, running))
+		assert.Equal(t, len(writer.deleted), 1)
+	})
+}
@@ -37,6 +37,19 @@ func pgUpgradeJob(upgrade *v1beta1.PGUpgrade) metav1.ObjectMeta {
 // upgradeCommand returns an entrypoint that prepares the filesystem for
 // and performs a PostgreSQL major version upgrade using pg_upgrade.
 func upgradeCommand(spec *v1beta1.PGUpgradeSettings) []string {
+	return pgUpgradeCommand(spec, false)
+}
+
+// checkCommand returns an entrypoint that prepares a temporary data directory
+// and runs "pg_upgrade --check" without changing the old data directory.
+// The output of pg_upgrade is written to the container termination message.
+func checkCommand(spec *v1beta1.PGUpgradeSettings) []string {
+	return pgUpgradeCommand(spec, true)
+}
+
+// pgUpgradeCommand returns the entrypoint of [upgradeCommand] or, when check
+// is true, of [checkCommand].
+func pgUpgradeCommand(spec *v1beta1.PGUpgradeSettings, check bool) []string {
 	argJobs := fmt.Sprintf(` --jobs=%d`, max(1, spec.Jobs))
 	argMethod := cmp.Or(map[string]string{
 		"Clone":         ` --clone`,
@@ -47,19 +60,29 @@ func upgradeCommand(spec *v1beta1.PGUpgradeSettings) []string {
 	oldVersion := spec.FromPostgresVersion
 	newVersion := spec.ToPostgresVersion
 
+	// The check uses a separate new data directory that is removed when it
+	// is done. It must be on the same filesystem for "--link" to be checked.
+	steps, action, newData := 7, `Performing`, `"${data_volume}/pg${new_version}"`
+	if check {
+		steps, action, newData = 6, `Checking`, `"${data_volume}/pg${new_version}_check" && rm -rf -- "${new_data}"`
+	}
+	step := func(n int, title string) string {
+		return fmt.Sprintf(`section 'Step %d of %d: %s...'`, n, steps, title)
+	}
+
 	args := []string{fmt.Sprint(oldVersion), fmt.Sprint(newVersion)}
-	script := strings.Join([]string{
+	script := []string{
 		// Exit immediately when a pipeline or subshell exits non-zero or when expanding an unset variable.
 		`shopt -so errexit nounset`,
 
 		`declare -r data_volume='/pgdata' old_version="$1" new_version="$2"`,
-		`printf 'Performing PostgreSQL upgrade from version "%s" to "%s" ...\n' "$@"`,
+		`printf '` + action + ` PostgreSQL upgrade from version "%s" to "%s" ...\n' "$@"`,
 		`section() { printf '\n\n%s\n' "$@"; }`,
 
 		// NOTE: Rather than import the nss_wrapper init container, as we do in
 		// the PostgresCluster controller, this job does the required nss_wrapper
 		// settings here.
-		`section 'Step 1 of 7: Ensuring username is postgres...'`,
+		step(1, `Ensuring username is postgres`),
 
 		// Create a copy of the system group definitions, but remove the "postgres"
 		// group or any group with the current GID. Replace them with our own that
@@ -80,9 +103,9 @@ func upgradeCommand(spec *v1beta1.PGUpgradeSettings) []string {
 		`export LD_PRELOAD='libnss_wrapper.so' NSS_WRAPPER_GROUP NSS_WRAPPER_PASSWD`,
 		`id; [[ "$(id -nu)" == 'postgres' && "$(id -ng)" == 'postgres' ]]`,
 
-		`section 'Step 2 of 7: Finding data and tools...'`,
+		step(2, `Finding data and tools`),
 		`old_data="${data_volume}/pg${old_version}" && [[ -d "${old_data}" ]]`,
-		`new_data="${data_volume}/pg${new_version}"`,
+		`new_data=` + newData,
 
 		// Search for Postgres executables matching the old and new versions.
 		// Use `command -v` to look through all of PATH, then trim the executable name from the absolute path.
@@ -131,38 +154,68 @@ func upgradeCommand(spec *v1beta1.PGUpgradeSettings) []string {
 		// https://git.postgresql.org/gitweb/?p=postgresql.git;hb=refs/tags/REL_18_0;f=src/bin/pg_checksums/pg_checksums.c#l571
 		`checksums=$(if [[ "${checksums}" -gt 0 ]]; then echo '--data-checksums'; elif [[ "${new_version}" -ge 18 ]]; then echo '--no-data-checksums'; fi)`,
 
-		`section 'Step 3 of 7: Initializing new data directory...'`,
+		step(3, `Initializing new data directory`),
 		`PGDATA="${new_data}" "${new_bin}/initdb" --allow-group-access ${checksums}`,
 
 		// Read the configured value then quote it; every single-quote U+0027 is replaced by two.
 		//
 		// https://www.postgresql.org/docs/current/config-setting.html
 		// https://www.gnu.org/software/bash/manual/bash.html#ANSI_002dC-Quoting
-		`section 'Step 4 of 7: Copying shared_preload_libraries parameter...'`,
+		step(4, `Copying shared_preload_libraries parameter`),
 		`value=$(LC_ALL=C PGDATA="${old_data}" "${old_bin}/postgres" -C shared_preload_libraries)`,
 		`echo >> "${new_data}/postgresql.conf" "shared_preload_libraries = '${value//$'\''/$'\'\''}'"`,
+	}
+
+	if check {
+		script = append(script,
+			// A clone of a running primary was not shut down cleanly. Start and
+			// stop the old server so pg_upgrade finds a consistent data directory.
+			// Only local connections are allowed, and WAL is not archived.
+			step(5, `Recovering old data directory`),
+			`if [[ -f "${old_data}/postmaster.pid" ]]; then`,
+			`(set -x && "${old_bin}/pg_ctl" start --wait --timeout=86400 --pgdata="${old_data}" \`,
+			`--options="-c archive_mode=off -c listen_addresses='' -c unix_socket_directories='${data_volume}'")`,
+			`(set -x && "${old_bin}/pg_ctl" stop --wait --timeout=86400 --pgdata="${old_data}" --mode=fast)`,
+			`fi`,
+
+			// Keep the output of pg_upgrade and write its end to the termination
+			// message, where the controller reads it. Kubernetes keeps at most 4096 bytes.
+			// - https://docs.k8s.io/tasks/debug/debug-application/determine-reason-pod-failure/
+			step(6, `Checking for potential issues`),
+			`report=$(mktemp) status=0`,
+			`"${new_bin}/pg_upgrade" --check`+argMethod+argJobs+` \`,
+			`--old-bindir="${old_bin}" --old-datadir="${old_data}" \`,
+			`--new-bindir="${new_bin}" --new-datadir="${new_data}" > "${report}" 2>&1 || status=$?`,
+			`cat "${report}"; tail --bytes=4096 "${report}" > /dev/termination-log`,
+			`rm -rf -- "${new_data}"; [[ "${status}" -eq 0 ]] || exit "${status}"`,
+
+			`section 'Success!'`,
+		)
+		return append([]string{"bash", "-c", "--", strings.Join(script, "\n"), "check"}, args...)
+	}
 
+	script = append(script,
 		// NOTE: The default for --new-bindir is the directory of pg_upgrade since PostgreSQL v13.
 		//
 		// https://www.postgresql.org/docs/release/13#id-1.11.6.28.5.11
-		`section 'Step 5 of 7: Checking for potential issues...'`,
-		`"${new_bin}/pg_upgrade" --check` + argMethod + argJobs + ` \`,
+		step(5, `Checking for potential issues`),
+		`"${new_bin}/pg_upgrade" --check`+argMethod+argJobs+` \`,
 		`--old-bindir="${old_bin}" --old-datadir="${old_data}" \`,
 		`--new-bindir="${new_bin}" --new-datadir="${new_data}"`,
 
-		`section 'Step 6 of 7: Performing upgrade...'`,
-		`(set -x && time "${new_bin}/pg_upgrade"` + argMethod + argJobs + ` \`,
+		step(6, `Performing upgrade`),
+		`(set -x && time "${new_bin}/pg_upgrade"`+argMethod+argJobs+` \`,
 		`--old-bindir="${old_bin}" --old-datadir="${old_data}" \`,
 		`--new-bindir="${new_bin}" --new-datadir="${new_data}")`,
 
 		// https://patroni.readthedocs.io/en/latest/existing_data.html#major-upgrade-of-postgresql-version
-		`section 'Step 7 of 7: Copying Patroni settings...'`,
+		step(7, `Copying Patroni settings`),
 		`(set -x && cp "${old_data}/patroni.dynamic.json" "${new_data}")`,
 
 		`section 'Success!'`,
-	}, "\n")
+	)
 
-	return append([]string{"bash", "-c", "--", script, "upgrade"}, args...)
+	return append([]string{"bash", "-c", "--", strings.Join(script, "\n"), "upgrade"}, args...)
 }
 
 // largestWholeCPU returns the maximum CPU request or limit as a non-negative
@@ -416,3 +469,16 @@ func jobCompleted(job *batchv1.Job) bool {
 	}
 	return false
 }
+
+// jobTimedOut returns "true" if the Job provided failed because it ran longer
+// than its active deadline. Otherwise it returns "false".
+func jobTimedOut(job *batchv1.Job) bool {
+	conditions := job.Status.Conditions
+	for i := range conditions {
+		if conditions[i].Type == batchv1.JobFailed {
+			return conditions[i].Status == corev1.ConditionTrue &&
+				conditions[i].Reason == batchv1.JobReasonDeadlineExceeded
+		}
+	}
+	return false
+}
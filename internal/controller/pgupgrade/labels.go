@@ -17,6 +17,10 @@ const (
 	// status of a Postgres major upgrade.
 	ConditionPGUpgradeSucceeded = "Succeeded"
 
+	// ConditionPGUpgradeChecked is the type used in a condition to indicate the
+	// result of "pg_upgrade --check" before a Postgres major upgrade.
+	ConditionPGUpgradeChecked = "Checked"
+
 	labelPrefix           = "postgres-operator.crunchydata.com/"
 	LabelPGUpgrade        = labelPrefix + "pgupgrade"
 	LabelCluster          = labelPrefix + "cluster"
@@ -26,11 +30,15 @@ const (
 	LabelPGBackRestBackup = labelPrefix + "pgbackrest-backup"
 	LabelInstance         = labelPrefix + "instance"
 
+	// annotationCheckInput identifies what a check Job runs and reads.
+	annotationCheckInput = labelPrefix + "pgupgrade-check-input"
+
 	ReplicaCreate     = "replica-create"
 	ContainerDatabase = "database"
 
-	pgUpgrade  = "pgupgrade"
-	removeData = "removedata"
+	pgUpgrade      = "pgupgrade"
+	pgUpgradeCheck = "pgupgrade-check"
+	removeData     = "removedata"
 )
 
 func commonLabels(role string, upgrade *v1beta1.PGUpgrade) map[string]string {
@@ -7,6 +7,7 @@ package pgupgrade
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	batchv1 "k8s.io/api/batch/v1"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
 	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/internal/maintenance"
 	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/tracing"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
@@ -294,6 +296,62 @@ func (r *PGUpgradeReconciler) Reconcile(ctx context.Context, upgrade *v1beta1.PG
 		return ctrl.Result{}, nil
 	}
 
+	// When asked, check that the cluster can be upgraded before anything is
+	// changed. A running cluster is checked using clones of its volumes.
+	if upgradeJob == nil && upgrade.Spec.CheckOnly {
+		err = r.reconcileUpgradeCheck(ctx, upgrade, world)
+
+		if checked := setStatusFromUpgradeCheck(upgrade); checked != nil &&
+			checked.Status == metav1.ConditionTrue {
+			meta.SetStatusCondition(&upgrade.Status.Conditions, metav1.Condition{
+				ObservedGeneration: upgrade.Generation,
+				Type:               ConditionPGUpgradeProgressing,
+				Status:             metav1.ConditionFalse,
+				Reason:             "PGUpgradeCheckOnly",
+				Message: fmt.Sprintf(
+					"PostgresCluster %s can be upgraded; unset checkOnly to continue",
+					upgrade.Spec.PostgresClusterName),
+			})
+		}
+		return ctrl.Result{}, err
+	}
+
+	setStatusToProgressingIfReasonWas("PGUpgradeCheckOnly", upgrade)
+
+	// Start the upgrade during a maintenance window of the cluster. This is
+	// checked before shutdown so the condition says when to shut it down.
+	if upgradeJob == nil {
+		now := time.Now()
+		allowed, next, invalid := maintenance.Allowed(world.Cluster, now)
+
+		if !allowed {
+			message := fmt.Sprintf(
+				"PostgresCluster %s is outside its maintenance windows",
+				upgrade.Spec.PostgresClusterName)
+			if !next.IsZero() {
+				message += fmt.Sprintf("; the next opens at %s", next.UTC().Format(time.RFC3339))
+			}
+			if invalid != nil {
+				message += fmt.Sprintf("; %v", invalid)
+			}
+
+			meta.SetStatusCondition(&upgrade.Status.Conditions, metav1.Condition{
+				ObservedGeneration: upgrade.Generation,
+				Type:               ConditionPGUpgradeProgressing,
+				Status:             metav1.ConditionFalse,
+				Reason:             "PGClusterOutsideMaintenanceWindow",
+				Message:            message,
+			})
+
+			if next.IsZero() {
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+		}
+	}
+
+	setStatusToProgressingIfReasonWas("PGClusterOutsideMaintenanceWindow", upgrade)
+
 	// The upgrade needs to manipulate the data directory of the primary while
 	// Postgres is stopped. Wait until all instances are gone and the primary
 	// is identified.
@@ -371,6 +429,19 @@ func (r *PGUpgradeReconciler) Reconcile(ctx context.Context, upgrade *v1beta1.PG
 
 	setStatusToProgressingIfReasonWas("PGClusterMissingRequiredAnnotation", upgrade)
 
+	// Check that the stopped cluster can be upgraded before anything is
+	// changed. The upgrade does not start until this check passes.
+	if upgradeJob == nil {
+		err = r.reconcileUpgradeCheck(ctx, upgrade, world)
+
+		if checked := setStatusFromUpgradeCheck(upgrade); err != nil ||
+			checked == nil || checked.Status != metav1.ConditionTrue {
+			return ctrl.Result{}, err
+		}
+	}
+
+	setStatusToProgressingIfReasonWas("PGUpgradeCheckFailed", upgrade)
+
 	// Currently our jobs are set to only run once, so if any job has failed, the
 	// upgrade has failed.
 	if upgradeJobFailed || removeDataJobsFailed {
@@ -489,3 +560,20 @@ func setStatusToProgressingIfReasonWas(reason string, upgrade *v1beta1.PGUpgrade
 		})
 	}
 }
+
+// setStatusFromUpgradeCheck marks upgrade as not progressing when its check
+// failed. It returns the condition that reports the result of the check.
+func setStatusFromUpgradeCheck(upgrade *v1beta1.PGUpgrade) *metav1.Condition {
+	checked := meta.FindStatusCondition(upgrade.Status.Conditions, ConditionPGUpgradeChecked)
+
+	if checked != nil && checked.Status == metav1.ConditionFalse {
+		meta.SetStatusCondition(&upgrade.Status.Conditions, metav1.Condition{
+			ObservedGeneration: upgrade.Generation,
+			Type:               ConditionPGUpgradeProgressing,
+			Status:             metav1.ConditionFalse,
+			Reason:             "PGUpgradeCheckFailed",
+			Message:            checked.Message,
+		})
+	}
+	return checked
+}
@@ -26,6 +26,8 @@ import (
 //+kubebuilder:rbac:groups="",resources="endpoints",verbs={list,watch}
 //+kubebuilder:rbac:groups="batch",resources="jobs",verbs={list,watch}
 //+kubebuilder:rbac:groups="apps",resources="statefulsets",verbs={list,watch}
+//+kubebuilder:rbac:groups="",resources="persistentvolumeclaims",verbs={list,watch}
+//+kubebuilder:rbac:groups="",resources="pods",verbs={list,watch}
 
 func (r *PGUpgradeReconciler) observeWorld(
 	ctx context.Context, upgrade *v1beta1.PGUpgrade,
@@ -75,6 +77,31 @@ func (r *PGUpgradeReconciler) observeWorld(
 				client.MatchingLabelsSelector{Selector: selectCluster},
 			))
 		world.populateStatefulSets(statefulsets.Items)
+		world.populateLeader(statefulsets.Items)
+	}
+
+	if err == nil {
+		var volumes corev1.PersistentVolumeClaimList
+		err = errors.WithStack(
+			r.Reader.List(ctx, &volumes,
+				client.InNamespace(upgrade.Namespace),
+				client.MatchingLabelsSelector{Selector: selectCluster},
+			))
+		for i := range volumes.Items {
+			world.Volumes[volumes.Items[i].Name] = &volumes.Items[i]
+		}
+	}
+
+	if err == nil {
+		var pods corev1.PodList
+		err = errors.WithStack(
+			r.Reader.List(ctx, &pods,
+				client.InNamespace(upgrade.Namespace),
+				client.MatchingLabels(commonLabels(pgUpgradeCheck, upgrade)),
+			))
+		for i := range pods.Items {
+			world.CheckPods = append(world.CheckPods, &pods.Items[i])
+		}
 	}
 
 	if err == nil {
@@ -130,6 +157,22 @@ func (w *World) populateStatefulSets(statefulSets []appsv1.StatefulSet) {
 	}
 }
 
+// populateLeader assigns the StatefulSet of the instance that Patroni reports
+// as its leader. Patroni stores the name of the leader pod in an annotation of
+// its leader Endpoints.
+// - https://patroni.readthedocs.io/en/latest/kubernetes.html
+func (w *World) populateLeader(statefulSets []appsv1.StatefulSet) {
+	for _, endpoint := range w.PatroniEndpoints {
+		if leader := endpoint.Annotations["leader"]; leader != "" {
+			for index, sts := range statefulSets {
+				if sts.Labels[LabelInstance] != "" && leader == sts.Name+"-0" {
+					w.ClusterLeader = &statefulSets[index]
+				}
+			}
+		}
+	}
+}
+
 func (w *World) populateShutdown() {
 	if w.Cluster != nil {
 		status := w.Cluster.Status
@@ -159,6 +202,7 @@ type World struct {
 	Upgrade *v1beta1.PGUpgrade
 
 	ClusterNotFound  error
+	ClusterLeader    *appsv1.StatefulSet
 	ClusterPrimary   *appsv1.StatefulSet
 	ClusterReplicas  []*appsv1.StatefulSet
 	ClusterShutdown  bool
@@ -166,10 +210,13 @@ type World struct {
 
 	PatroniEndpoints []*corev1.Endpoints
 	Jobs             map[string]*batchv1.Job
+	Volumes          map[string]*corev1.PersistentVolumeClaim
+	CheckPods        []*corev1.Pod
 }
 
 func NewWorld() *World {
 	return &World{
-		Jobs: make(map[string]*batchv1.Job),
+		Jobs:    make(map[string]*batchv1.Job),
+		Volumes: make(map[string]*corev1.PersistentVolumeClaim),
 	}
 }
@@ -228,3 +228,39 @@ func TestPopulateStatefulSets(t *testing.T) {
 		assert.Assert(t, world.ReplicasExpected == 1)
 	})
 }
+
+func TestPopulateLeader(t *testing.T) {
+	instance := appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "pg5-instance1-abcd",
+			Labels: map[string]string{LabelInstance: "whatever"},
+		},
+	}
+	other := appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "pg5-instance1-wxyz",
+			Labels: map[string]string{LabelInstance: "whatever"},
+		},
+	}
+
+	t.Run("NoLeader", func(t *testing.T) {
+		world := NewWorld()
+		world.PatroniEndpoints = []*corev1.Endpoints{{}}
+		world.populateLeader([]appsv1.StatefulSet{instance, other})
+
+		assert.Assert(t, world.ClusterLeader == nil)
+	})
+
+	t.Run("Leader", func(t *testing.T) {
+		world := NewWorld()
+		world.PatroniEndpoints = []*corev1.Endpoints{{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{"leader": "pg5-instance1-wxyz-0"},
+			},
+		}}
+		statefulsets := []appsv1.StatefulSet{instance, other}
+		world.populateLeader(statefulsets)
+
+		assert.Equal(t, world.ClusterLeader, &statefulsets[1])
+	})
+}
@@ -70,6 +70,7 @@ func (r *Reconciler) storeDesiredRequest(
 		r.Recorder.Eventf(cluster, eventType, "VolumeAutoGrow",
 			"%s volume expansion to %v requested for %s/%s.",
 			volumeType, current.String(), cluster.Name, host)
+		recordVolumeAutoGrow(cluster, volumeType, host)
 	}
 
 	// If the desired size was not observed, update with previously stored value.
@@ -5,15 +5,20 @@
 package postgrescluster
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"io"
+	"net"
+	"slices"
+	"strconv"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crunchydata/postgres-operator/internal/collector"
 	"github.com/crunchydata/postgres-operator/internal/initialize"
@@ -216,12 +221,75 @@ func (r *Reconciler) generateClusterReplicaService(
 	cluster *v1beta1.PostgresCluster) (*corev1.Service, error,
 ) {
 	service := &corev1.Service{ObjectMeta: naming.ClusterReplicaService(cluster)}
+
+	// Allocate an IP address and let Kubernetes manage the Endpoints by
+	// selecting Pods with the Patroni replica role.
+	// - https://docs.k8s.io/concepts/services-networking/service/#defining-a-service
+	selector := map[string]string{
+		naming.LabelCluster: cluster.Name,
+		naming.LabelRole:    naming.RolePatroniReplica,
+	}
+
+	// Exclude replicas that should not be load balanced, such as those that
+	// are delayed.
+	if excludesReplicas(cluster) {
+		selector[naming.LabelLoadBalance] = "true"
+	}
+
+	// Exclude replicas that are too far behind the primary. Pods are labeled
+	// once their lag is known; see [Reconciler.reconcileReplicaLag].
+	if limitsReplicaLag(cluster) {
+		selector[naming.LabelLagging] = "false"
+	}
+
+	err := r.generateReplicaService(cluster, cluster.Spec.ReplicaService, service,
+		map[string]string{
+			naming.LabelCluster: cluster.Name,
+			naming.LabelRole:    naming.RoleReplica,
+		}, selector)
+	if err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// generateInstanceSetReplicaService returns a v1.Service that exposes the
+// PostgreSQL replica instances of set.
+func (r *Reconciler) generateInstanceSetReplicaService(
+	cluster *v1beta1.PostgresCluster, set *v1beta1.PostgresInstanceSetSpec,
+) (*corev1.Service, error) {
+	service := &corev1.Service{ObjectMeta: naming.InstanceSetReplicaService(cluster, set)}
+
+	err := r.generateReplicaService(cluster, set.Service, service,
+		map[string]string{
+			naming.LabelCluster:     cluster.Name,
+			naming.LabelInstanceSet: set.Name,
+			naming.LabelRole:        naming.RoleReplica,
+		},
+		map[string]string{
+			naming.LabelCluster:     cluster.Name,
+			naming.LabelInstanceSet: set.Name,
+			naming.LabelRole:        naming.RolePatroniReplica,
+		})
+	if err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// generateReplicaService populates service according to spec so that it
+// exposes the PostgreSQL replicas matching selector. The labels are applied
+// after any in spec.
+func (r *Reconciler) generateReplicaService(
+	cluster *v1beta1.PostgresCluster, spec *v1beta1.ServiceSpec,
+	service *corev1.Service, labels, selector map[string]string,
+) error {
 	service.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Service"))
 
 	service.Annotations = cluster.Spec.Metadata.GetAnnotationsOrNil()
 	service.Labels = cluster.Spec.Metadata.GetLabelsOrNil()
 
-	if spec := cluster.Spec.ReplicaService; spec != nil {
+	if spec != nil {
 		service.Annotations = naming.Merge(service.Annotations,
 			spec.Metadata.GetAnnotationsOrNil())
 		service.Labels = naming.Merge(service.Labels,
@@ -229,12 +297,7 @@ func (r *Reconciler) generateClusterReplicaService(
 	}
 
 	// add our labels last so they aren't overwritten
-	service.Labels = naming.Merge(
-		service.Labels,
-		map[string]string{
-			naming.LabelCluster: cluster.Name,
-			naming.LabelRole:    naming.RoleReplica,
-		})
+	service.Labels = naming.Merge(service.Labels, labels)
 
 	// The TargetPort must be the name (not the number) of the PostgreSQL
 	// ContainerPort. This name allows the port number to differ between Pods,
@@ -250,7 +313,7 @@ func (r *Reconciler) generateClusterReplicaService(
 	service.Spec.Type = corev1.ServiceTypeClusterIP
 
 	// Check user provided spec for a specified type
-	if spec := cluster.Spec.ReplicaService; spec != nil {
+	if spec != nil {
 		service.Spec.Type = corev1.ServiceType(spec.Type)
 		if spec.NodePort != nil {
 			if service.Spec.Type == corev1.ServiceTypeClusterIP {
@@ -262,7 +325,7 @@ func (r *Reconciler) generateClusterReplicaService(
 				// and event could potentially be removed in favor of that validation
 				r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "MisconfiguredClusterIP",
 					"NodePort cannot be set with type ClusterIP on Service %q", service.Name)
-				return nil, fmt.Errorf("NodePort cannot be set with type ClusterIP on Service %q", service.Name)
+				return fmt.Errorf("NodePort cannot be set with type ClusterIP on Service %q", service.Name)
 			}
 			servicePort.NodePort = *spec.NodePort
 		}
@@ -279,18 +342,23 @@ func (r *Reconciler) generateClusterReplicaService(
 
 	}
 	service.Spec.Ports = []corev1.ServicePort{servicePort}
+	service.Spec.Selector = selector
 
-	// Allocate an IP address and let Kubernetes manage the Endpoints by
-	// selecting Pods with the Patroni replica role.
-	// - https://docs.k8s.io/concepts/services-networking/service/#defining-a-service
-	service.Spec.Selector = map[string]string{
-		naming.LabelCluster: cluster.Name,
-		naming.LabelRole:    naming.RolePatroniReplica,
-	}
+	return errors.WithStack(r.setControllerReference(cluster, service))
+}
 
-	err := errors.WithStack(r.setControllerReference(cluster, service))
+// excludesReplicas returns true when the instances of any instance set of
+// cluster should not receive traffic from the replica Service.
+func excludesReplicas(cluster *v1beta1.PostgresCluster) bool {
+	return slices.ContainsFunc(cluster.Spec.InstanceSets,
+		func(set v1beta1.PostgresInstanceSetSpec) bool { return !patroni.LoadBalanced(&set) })
+}
 
-	return service, err
+// limitsReplicaLag returns true when replicas of cluster that are too far
+// behind the primary should not receive traffic from the replica Service.
+func limitsReplicaLag(cluster *v1beta1.PostgresCluster) bool {
+	return cluster.Spec.ReplicaRouting != nil &&
+		(cluster.Spec.ReplicaRouting.MaxLag != nil || cluster.Spec.ReplicaRouting.MaxLagTime != nil)
 }
 
 // +kubebuilder:rbac:groups="",resources="services",verbs={create,patch}
@@ -308,6 +376,87 @@ func (r *Reconciler) reconcileClusterReplicaService(
 	return service, err
 }
 
+// +kubebuilder:rbac:groups="",resources="services",verbs={get,list}
+// +kubebuilder:rbac:groups="",resources="services",verbs={create,delete,patch}
+
+// reconcileInstanceSetReplicaServices writes the Services that expose the
+// PostgreSQL replica instances of each instance set that specifies one and
+// deletes those of instance sets that no longer do. It reports and skips any
+// Service whose name is already taken by something else. The addresses of each
+// Service are stored in the status of its instance set.
+func (r *Reconciler) reconcileInstanceSetReplicaServices(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) error {
+	existing := &corev1.ServiceList{}
+	err := errors.WithStack(r.Reader.List(ctx, existing,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{
+			naming.LabelCluster: cluster.Name,
+			naming.LabelRole:    naming.RoleReplica,
+		},
+		client.HasLabels{naming.LabelInstanceSet},
+	))
+
+	services := make(map[string]*corev1.Service)
+	for i := range cluster.Spec.InstanceSets {
+		if set := &cluster.Spec.InstanceSets[i]; err == nil && set.Service != nil {
+			var service *corev1.Service
+			service, err = r.generateInstanceSetReplicaService(cluster, set)
+
+			// Names with dashes can collide. The Service of cluster "a" and set
+			// "b-c" has the same name as that of cluster "a-b" and set "c".
+			// Leave alone any Service that belongs to something else.
+			var taken bool
+			if err == nil {
+				current := &corev1.Service{}
+				err = errors.WithStack(r.Reader.Get(ctx, client.ObjectKeyFromObject(service), current))
+				taken = err == nil && !metav1.IsControlledBy(current, cluster)
+				err = client.IgnoreNotFound(err)
+			}
+			if taken {
+				r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "ServiceNameConflict",
+					"Service %q for the replicas of instance set %q already exists and belongs to something else",
+					service.Name, set.Name)
+				continue
+			}
+
+			if err == nil {
+				err = errors.WithStack(r.apply(ctx, service))
+			}
+			services[set.Name] = service
+		}
+	}
+
+	for i := range existing.Items {
+		if service := &existing.Items[i]; err == nil &&
+			services[service.Labels[naming.LabelInstanceSet]] == nil {
+			err = errors.WithStack(client.IgnoreNotFound(
+				r.deleteControlled(ctx, cluster, service)))
+		}
+	}
+
+	for i := range cluster.Status.InstanceSets {
+		status := &cluster.Status.InstanceSets[i]
+		status.Service = nil
+
+		if service := services[status.Name]; err == nil && service != nil {
+			port := strconv.Itoa(int(*cluster.Spec.Port))
+			status.Service = &v1beta1.PostgresInstanceSetServiceStatus{
+				Name:      service.Name,
+				Endpoints: []string{net.JoinHostPort(naming.ServiceDNSNames(ctx, service)[0], port)},
+			}
+			for _, ingress := range service.Status.LoadBalancer.Ingress {
+				if host := cmp.Or(ingress.IP, ingress.Hostname); host != "" {
+					status.Service.Endpoints = append(status.Service.Endpoints,
+						net.JoinHostPort(host, port))
+				}
+			}
+		}
+	}
+
+	return err
+}
+
 // reconcileDataSource is responsible for reconciling the data source for a PostgreSQL cluster.
 // This involves ensuring the PostgreSQL data directory for the cluster is properly populated
 // prior to bootstrapping the cluster, specifically according to any data source configured in the
@@ -363,7 +512,7 @@ func (r *Reconciler) reconcileDataSource(ctx context.Context,
 	case postgresDataInitRequested:
 		// there is no restore annotation when initializing a new cluster, so we create a
 		// restore ID for bootstrap
-		restoreID = "~pgo-bootstrap-" + cluster.GetName()
+		restoreID = bootstrapRestoreID(cluster)
 		dataSource = cluster.Spec.DataSource.PostgresCluster
 		if dataSource == nil {
 			cloudDataSource = cluster.Spec.DataSource.PGBackRest
@@ -406,6 +555,9 @@ func (r *Reconciler) reconcileDataSource(ctx context.Context,
 		configs = make([]string, 0, 2+len(dataSource.Options))
 		configs = append(configs, dataSource.ClusterName, dataSource.RepoName)
 		configs = append(configs, dataSource.Options...)
+		if dataSource.BackupName != "" {
+			configs = append(configs, dataSource.BackupName)
+		}
 	case cloudDataSource != nil:
 		configs = make([]string, 0, 2+len(cloudDataSource.Options))
 		configs = append(configs, cloudDataSource.Stanza, cloudDataSource.Repo.Name)
@@ -13,6 +13,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -774,6 +775,198 @@ postgres-operator.crunchydata.com/cluster: pg2
 postgres-operator.crunchydata.com/role: replica
 		`))
 	})
+
+	t.Run("ReplicationDelay", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.InstanceSets = []v1beta1.PostgresInstanceSetSpec{
+			{Name: "one"},
+			{Name: "two", ReplicationDelay: require.Value(v1beta1.NewDuration("1h"))},
+		}
+
+		service, err := reconciler.generateClusterReplicaService(cluster)
+		assert.NilError(t, err)
+
+		// Delayed replicas are not in the selector.
+		assert.Assert(t, cmp.MarshalMatches(service.Spec.Selector, `
+postgres-operator.crunchydata.com/cluster: pg2
+postgres-operator.crunchydata.com/load-balance: "true"
+postgres-operator.crunchydata.com/role: replica
+		`))
+	})
+
+	t.Run("NoLoadBalance", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.InstanceSets = []v1beta1.PostgresInstanceSetSpec{
+			{Name: "one"},
+			{Name: "two", Patroni: &v1beta1.PatroniInstanceSetSpec{
+				Tags: &v1beta1.PatroniTags{NoLoadBalance: true},
+			}},
+		}
+
+		service, err := reconciler.generateClusterReplicaService(cluster)
+		assert.NilError(t, err)
+		assert.Equal(t, service.Spec.Selector[naming.LabelLoadBalance], "true")
+	})
+
+	t.Run("MaxLag", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			replicaRouting: { maxLag: 16Mi },
+		}`)
+
+		service, err := reconciler.generateClusterReplicaService(cluster)
+		assert.NilError(t, err)
+
+		// Lagging replicas are not in the selector.
+		assert.Assert(t, cmp.MarshalMatches(service.Spec.Selector, `
+postgres-operator.crunchydata.com/cluster: pg2
+postgres-operator.crunchydata.com/lagging: "false"
+postgres-operator.crunchydata.com/role: replica
+		`))
+	})
+
+	t.Run("MaxLagTime", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			replicaRouting: { maxLagTime: 30s },
+		}`)
+
+		service, err := reconciler.generateClusterReplicaService(cluster)
+		assert.NilError(t, err)
+		assert.Equal(t, service.Spec.Selector[naming.LabelLagging], "false")
+	})
+}
+
+func TestGenerateInstanceSetReplicaService(t *testing.T) {
+	recorder := events.NewRecorder(t, runtime.Scheme)
+	reconciler := &Reconciler{Recorder: recorder}
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Namespace = "ns1"
+	cluster.Name = "pg3"
+	cluster.Spec.Port = initialize.Int32(9876)
+	cluster.Spec.Metadata = &v1beta1.Metadata{
+		Labels: map[string]string{"happy": "label"},
+	}
+	require.UnmarshalInto(t, &cluster.Spec.InstanceSets, `[
+		{ name: app },
+		{ name: analytics, service: {
+			type: LoadBalancer, nodePort: 32001,
+			metadata: { annotations: { some: note } },
+		} },
+	]`)
+
+	service, err := reconciler.generateInstanceSetReplicaService(cluster, &cluster.Spec.InstanceSets[1])
+	assert.NilError(t, err)
+	assert.Assert(t, cmp.MarshalMatches(service.ObjectMeta, `
+annotations:
+  some: note
+labels:
+  happy: label
+  postgres-operator.crunchydata.com/cluster: pg3
+  postgres-operator.crunchydata.com/instance-set: analytics
+  postgres-operator.crunchydata.com/role: replica
+name: pg3-analytics-replicas
+namespace: ns1
+ownerReferences:
+- apiVersion: postgres-operator.crunchydata.com/v1beta1
+  blockOwnerDeletion: true
+  controller: true
+  kind: PostgresCluster
+  name: pg3
+  uid: ""
+	`))
+	assert.Assert(t, cmp.MarshalMatches(service.Spec, `
+ports:
+- name: postgres
+  nodePort: 32001
+  port: 9876
+  protocol: TCP
+  targetPort: postgres
+selector:
+  postgres-operator.crunchydata.com/cluster: pg3
+  postgres-operator.crunchydata.com/instance-set: analytics
+  postgres-operator.crunchydata.com/role: replica
+type: LoadBalancer
+	`))
+
+	t.Run("NodePortWithClusterIP", func(t *testing.T) {
+		set := cluster.Spec.InstanceSets[1].DeepCopy()
+		set.Service.Type = "ClusterIP"
+
+		_, err := reconciler.generateInstanceSetReplicaService(cluster, set)
+		assert.ErrorContains(t, err, "NodePort cannot be set with type ClusterIP")
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "MisconfiguredClusterIP")
+	})
+}
+
+func TestReconcileInstanceSetReplicaServices(t *testing.T) {
+	ctx := context.Background()
+	_, cc := setupKubernetes(t)
+	require.ParallelCapacity(t, 1)
+
+	recorder := events.NewRecorder(t, runtime.Scheme)
+	reconciler := &Reconciler{
+		Reader:   cc,
+		Recorder: recorder,
+		Writer:   client.WithFieldOwner(cc, t.Name()),
+	}
+
+	cluster := testCluster()
+	cluster.Namespace = setupNamespace(t, cc).Name
+	cluster.Spec.InstanceSets = append(cluster.Spec.InstanceSets,
+		v1beta1.PostgresInstanceSetSpec{
+			Name:                "analytics",
+			DataVolumeClaimSpec: testVolumeClaimSpecWithAutoGrow(),
+			Service:             &v1beta1.ServiceSpec{Type: "ClusterIP"},
+		})
+	assert.NilError(t, cc.Create(ctx, cluster))
+
+	cluster.Status.InstanceSets = []v1beta1.PostgresInstanceSetStatus{
+		{Name: "analytics"}, {Name: "instance1"},
+	}
+	assert.NilError(t, reconciler.reconcileInstanceSetReplicaServices(ctx, cluster))
+
+	service := &corev1.Service{ObjectMeta: naming.InstanceSetReplicaService(cluster, &cluster.Spec.InstanceSets[1])}
+	assert.NilError(t, cc.Get(ctx, client.ObjectKeyFromObject(service), service))
+	assert.Assert(t, service.Spec.ClusterIP != "", "expected to be assigned a ClusterIP")
+
+	status := cluster.Status.InstanceSets
+	assert.Assert(t, status[0].Service != nil)
+	assert.Equal(t, status[0].Service.Name, service.Name)
+	assert.Assert(t, cmp.Len(status[0].Service.Endpoints, 1))
+	assert.Assert(t, cmp.Contains(status[0].Service.Endpoints[0], service.Name+"."+cluster.Namespace+".svc."))
+	assert.Assert(t, status[1].Service == nil)
+
+	// Removing the spec deletes the Service and clears the status.
+	cluster.Spec.InstanceSets[1].Service = nil
+	assert.NilError(t, reconciler.reconcileInstanceSetReplicaServices(ctx, cluster))
+
+	err := cc.Get(ctx, client.ObjectKeyFromObject(service), service)
+	assert.Assert(t, apierrors.IsNotFound(err) || service.DeletionTimestamp != nil, "got %v", err)
+	assert.Assert(t, cluster.Status.InstanceSets[0].Service == nil)
+
+	t.Run("Conflict", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.InstanceSets[1].Name = "conflict"
+		cluster.Spec.InstanceSets[1].Service = &v1beta1.ServiceSpec{Type: "ClusterIP"}
+		cluster.Status.InstanceSets = []v1beta1.PostgresInstanceSetStatus{{Name: "conflict"}}
+
+		// Another cluster, "<cluster>-conflict", has a replica Service by the same name.
+		other := &corev1.Service{ObjectMeta: naming.InstanceSetReplicaService(cluster, &cluster.Spec.InstanceSets[1])}
+		other.Spec.Ports = []corev1.ServicePort{{Port: 5432}}
+		assert.NilError(t, cc.Create(ctx, other))
+
+		assert.NilError(t, reconciler.reconcileInstanceSetReplicaServices(ctx, cluster))
+		assert.Assert(t, cluster.Status.InstanceSets[0].Service == nil)
+
+		assert.NilError(t, cc.Get(ctx, client.ObjectKeyFromObject(other), other))
+		assert.Assert(t, metav1.GetControllerOf(other) == nil, "expected no change")
+
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "ServiceNameConflict")
+	})
 }
 
 func TestPatroniLogSize(t *testing.T) {
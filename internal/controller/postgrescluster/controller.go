@@ -24,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -108,6 +109,9 @@ func (r *Reconciler) Reconcile(
 		return *result, nil
 	}
 
+	// Publish metrics about cluster however this reconcile ends.
+	defer recordClusterMetrics(cluster)
+
 	// Perform initial validation on a cluster
 	// TODO: Move this to a defaulting (mutating admission) webhook
 	// to leverage regular validation.
@@ -154,6 +158,7 @@ func (r *Reconciler) Reconcile(
 		clusterVolumes               []*corev1.PersistentVolumeClaim
 		instanceServiceAccount       *corev1.ServiceAccount
 		instances                    *observedInstances
+		issuedCertificates           issuedCertificates
 		patroniLeaderService         *corev1.Service
 		primaryCertificate           *corev1.SecretProjection
 		primaryService               *corev1.Service
@@ -256,9 +261,19 @@ func (r *Reconciler) Reconcile(
 		if requeue, err = r.reconcilePatroniStatus(ctx, cluster, instances); err == nil && requeue > 0 {
 			result.RequeueAfter = requeue
 		}
+		err = recordReconcileError(cluster, "patroni", err)
 	}
 	if err == nil {
-		err = r.reconcilePatroniSwitchover(ctx, cluster, instances)
+		var requeue time.Duration
+		if requeue, err = r.reconcileReplicaLag(ctx, cluster, instances); err == nil && requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
+		err = recordReconcileError(cluster, "patroni", err)
+	}
+	if err == nil {
+		err = recordReconcileError(cluster, "patroni",
+			r.reconcilePatroniSwitchover(ctx, cluster, instances))
 	}
 	// reconcile the Pod service before reconciling any data source in case it is necessary
 	// to start Pods during data source reconciliation that require network connections (e.g.
@@ -293,7 +308,11 @@ func (r *Reconciler) Reconcile(
 		clusterConfigMap, err = r.reconcileClusterConfigMap(ctx, cluster, pgHBAs, pgParameters)
 	}
 	if err == nil {
-		clusterReplicationSecret, err = r.reconcileReplicationSecret(ctx, cluster, rootCA)
+		issuedCertificates, err = r.reconcileIssuedCertificates(ctx, cluster)
+	}
+	if err == nil {
+		clusterReplicationSecret, err = r.reconcileReplicationSecret(ctx, cluster, rootCA,
+			issuedCertificates.replication)
 	}
 	if err == nil {
 		patroniLeaderService, err = r.reconcilePatroniLeaderLease(ctx, cluster)
@@ -305,13 +324,19 @@ func (r *Reconciler) Reconcile(
 		replicaService, err = r.reconcileClusterReplicaService(ctx, cluster)
 	}
 	if err == nil {
-		primaryCertificate, err = r.reconcileClusterCertificate(ctx, rootCA, cluster, primaryService, replicaService)
+		err = r.reconcileInstanceSetReplicaServices(ctx, cluster)
+	}
+	if err == nil {
+		primaryCertificate, err = r.reconcileClusterCertificate(ctx, rootCA, cluster,
+			primaryService, replicaService, issuedCertificates.postgres)
 	}
 	if err == nil {
-		err = r.reconcilePatroniDistributedConfiguration(ctx, cluster)
+		err = recordReconcileError(cluster, "patroni",
+			r.reconcilePatroniDistributedConfiguration(ctx, cluster))
 	}
 	if err == nil {
-		err = r.reconcilePatroniDynamicConfiguration(ctx, cluster, instances, pgHBAs, pgParameters)
+		err = recordReconcileError(cluster, "patroni",
+			r.reconcilePatroniDynamicConfiguration(ctx, cluster, instances, pgHBAs, pgParameters))
 	}
 	if err == nil {
 		monitoringSecret, err = r.reconcileMonitoringSecret(ctx, cluster)
@@ -323,19 +348,34 @@ func (r *Reconciler) Reconcile(
 		exporterWebConfig, err = r.reconcileExporterWebConfig(ctx, cluster)
 	}
 	if err == nil {
-		err = r.reconcileInstanceSets(
+		var requeue time.Duration
+		if requeue, err = r.reconcileInstanceSets(
 			ctx, cluster, clusterConfigMap, clusterReplicationSecret, rootCA,
 			clusterPodService, instanceServiceAccount, instances, patroniLeaderService,
 			primaryCertificate, clusterVolumes, exporterQueriesConfig, exporterWebConfig,
 			backupsSpecFound, otelConfig, pgParameters,
-		)
+		); err == nil && requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
 	}
 
 	if err == nil {
 		err = r.reconcilePostgresDatabases(ctx, cluster, instances)
 	}
 	if err == nil {
-		err = r.reconcilePostgresUsers(ctx, cluster, instances)
+		var requeue time.Duration
+		if requeue, err = r.reconcilePostgresUsers(ctx, cluster, instances); err == nil && requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
+	}
+	if err == nil {
+		var requeue time.Duration
+		if requeue, err = r.reconcilePostgresDatabaseSpecs(ctx, cluster, instances); err == nil && requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
 	}
 
 	if err == nil {
@@ -343,10 +383,12 @@ func (r *Reconciler) Reconcile(
 		if next, err = r.reconcilePGBackRest(ctx, cluster,
 			instances, rootCA, backupsSpecFound); err == nil && !next.IsZero() {
 			result.Requeue = result.Requeue || next.Requeue
-			if next.RequeueAfter > 0 {
+			if next.RequeueAfter > 0 &&
+				(result.RequeueAfter == 0 || next.RequeueAfter < result.RequeueAfter) {
 				result.RequeueAfter = next.RequeueAfter
 			}
 		}
+		err = recordReconcileError(cluster, "pgbackrest", err)
 	}
 	if err == nil {
 		dedicatedSnapshotPVC, err = r.reconcileDedicatedSnapshotVolume(ctx, cluster, clusterVolumes)
@@ -355,7 +397,9 @@ func (r *Reconciler) Reconcile(
 		err = r.reconcileVolumeSnapshots(ctx, cluster, dedicatedSnapshotPVC)
 	}
 	if err == nil {
-		err = r.reconcilePGBouncer(ctx, cluster, instances, primaryCertificate, rootCA)
+		err = recordReconcileError(cluster, "pgbouncer",
+			r.reconcilePGBouncer(ctx, cluster, instances,
+				primaryCertificate, issuedCertificates.pgbouncer, rootCA))
 	}
 	if err == nil {
 		err = r.reconcilePGMonitorExporter(ctx, cluster, instances, monitoringSecret)
@@ -369,7 +413,15 @@ func (r *Reconciler) Reconcile(
 	if err == nil {
 		// This is after [Reconciler.rolloutInstances] to ensure that recreating
 		// Pods takes precedence.
-		err = r.handlePatroniRestarts(ctx, cluster, instances)
+		err = recordReconcileError(cluster, "patroni",
+			r.handlePatroniRestarts(ctx, cluster, instances))
+	}
+	if err == nil {
+		var requeue time.Duration
+		if requeue, err = r.reconcilePendingMaintenance(ctx, cluster, instances); err == nil && requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
 	}
 
 	// at this point everything reconciled successfully, and we can update the
@@ -464,7 +516,13 @@ func ManagedReconciler(m manager.Manager) error {
 		Owns(&rbacv1.RoleBinding{}).
 		Owns(&batchv1.CronJob{}).
 		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&v1beta1.PGBackRestBackup{}).
 		Watches(&corev1.Pod{}, reconciler.watchPods()).
+		Watches(&v1beta1.PGBackRestJob{}, reconciler.watchPGBackRestJobs()).
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, secret client.Object) []reconcile.Request {
+				return runtime.Requests(reconciler.findPostgresClustersForSecret(ctx, client.ObjectKeyFromObject(secret))...)
+			})).
 		Watches(&appsv1.StatefulSet{},
 			reconciler.controllerRefHandlerFuncs()). // watch all StatefulSets
 		Complete(reconcile.AsReconciler(kubernetes, reconciler)))
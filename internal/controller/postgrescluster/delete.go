@@ -99,6 +99,11 @@ func (r *Reconciler) handleDelete(
 	err := errors.WithStack(r.Writer.Patch(ctx, intent,
 		client.MergeFromWithOptions(before, client.MergeFromWithOptimisticLock{})))
 
+	// The cluster is going away; so are its metrics.
+	if err == nil {
+		forgetClusterMetrics(cluster)
+	}
+
 	// The caller should wait for further events or requeue upon error.
 	return &reconcile.Result{}, err
 }
@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"io"
 	"maps"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -176,27 +178,59 @@ func (s *instanceSorter) Swap(i, j int) {
 // each to keep running. The primary instance, when known, is always the highest
 // priority. Two instances with otherwise-identical priority are ranked by Name.
 func byPriority(instances []*Instance) sort.Interface {
+	return &instanceSorter{instances: instances, less: lowerPriority}
+}
+
+// byRolloutOrder returns a sort.Interface that sorts instances by the position
+// of their instance set in order, then by priority. Instance sets that are not
+// in order come after those that are. The primary instance, when known, is
+// always last.
+func byRolloutOrder(instances []*Instance, order []string) sort.Interface {
+	position := func(i *Instance) int {
+		if i.Spec != nil {
+			if n := slices.Index(order, i.Spec.Name); n >= 0 {
+				return n
+			}
+		}
+		return len(order)
+	}
+
 	return &instanceSorter{instances: instances, less: func(a, b *Instance) bool {
-		// The primary instance is the highest priority.
 		if primary, known := a.IsPrimary(); known && primary {
 			return false
 		}
 		if primary, known := b.IsPrimary(); known && primary {
 			return true
 		}
-
-		// An available instance is a higher priority than not.
-		if available, known := a.IsAvailable(); known && available {
-			return false
-		}
-		if available, known := b.IsAvailable(); known && available {
-			return true
+		if pa, pb := position(a), position(b); pa != pb {
+			return pa < pb
 		}
-
-		return a.Name < b.Name
+		return lowerPriority(a, b)
 	}}
 }
 
+// lowerPriority returns whether or not we want a to keep running less than b.
+// See [byPriority].
+func lowerPriority(a, b *Instance) bool {
+	// The primary instance is the highest priority.
+	if primary, known := a.IsPrimary(); known && primary {
+		return false
+	}
+	if primary, known := b.IsPrimary(); known && primary {
+		return true
+	}
+
+	// An available instance is a higher priority than not.
+	if available, known := a.IsAvailable(); known && available {
+		return false
+	}
+	if available, known := b.IsAvailable(); known && available {
+		return true
+	}
+
+	return a.Name < b.Name
+}
+
 // observedInstances represents all the PostgreSQL instances of a single PostgresCluster.
 type observedInstances struct {
 	byName     map[string]*Instance
@@ -542,6 +576,9 @@ func (r *Reconciler) deleteInstance(
 					err = errors.WithStack(client.IgnoreNotFound(
 						r.deleteControlled(ctx, cluster, &uList.Items[i])))
 				}
+				if err == nil && gvk.Kind == "SecretList" {
+					forgetCertificateExpiry(cluster, uList.Items[i].GetName())
+				}
 			}
 		}
 	}
@@ -550,7 +587,8 @@ func (r *Reconciler) deleteInstance(
 }
 
 // reconcileInstanceSets reconciles instance sets in the environment to match
-// the current spec. This is done by scaling up or down instances where necessary
+// the current spec. This is done by scaling up or down instances where necessary.
+// It returns how long to wait before checking on a rollout again, if at all.
 func (r *Reconciler) reconcileInstanceSets(
 	ctx context.Context,
 	cluster *v1beta1.PostgresCluster,
@@ -567,7 +605,7 @@ func (r *Reconciler) reconcileInstanceSets(
 	backupsSpecFound bool,
 	otelConfig *collector.Config,
 	pgParameters *postgres.ParameterSet,
-) error {
+) (time.Duration, error) {
 
 	// Go through the observed instances and check if a primary has been determined.
 	// If the cluster is being shutdown and this instance is the primary, store
@@ -610,8 +648,11 @@ func (r *Reconciler) reconcileInstanceSets(
 		if err == nil {
 			err = r.reconcileInstanceSetPodDisruptionBudget(ctx, cluster, set)
 		}
+		if err == nil {
+			err = r.reconcileLoadBalanceLabels(ctx, cluster, set, instances.bySet[set.Name])
+		}
 		if err != nil {
-			return err
+			return 0, err
 		}
 	}
 
@@ -620,22 +661,53 @@ func (r *Reconciler) reconcileInstanceSets(
 	// which instance or instance set contains the primary pod.
 	err := r.scaleDownInstances(ctx, cluster, instances)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// Cleanup Instance Set resources that are no longer needed
 	err = r.cleanupPodDisruptionBudgets(ctx, cluster)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// Rollout changes to instances by calling rolloutInstance.
-	err = r.rolloutInstances(ctx, cluster, instances,
+	return r.rolloutInstances(ctx, cluster, instances,
 		func(ctx context.Context, instance *Instance) error {
 			return r.rolloutInstance(ctx, cluster, instances, instance)
 		})
+}
 
-	return err
+// +kubebuilder:rbac:groups="",resources="pods",verbs={patch}
+
+// reconcileLoadBalanceLabels sets the [naming.LabelLoadBalance] label on the
+// running Pods of set. Pods get this label from their StatefulSet only when
+// they are recreated, and the replica Service selects it as soon as any
+// instance set is excluded from that Service.
+func (r *Reconciler) reconcileLoadBalanceLabels(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	set *v1beta1.PostgresInstanceSetSpec, instances []*Instance,
+) error {
+	if !excludesReplicas(cluster) {
+		return nil
+	}
+
+	value := strconv.FormatBool(patroni.LoadBalanced(set))
+	for _, instance := range instances {
+		for _, pod := range instance.Pods {
+			if pod.Labels[naming.LabelLoadBalance] == value {
+				continue
+			}
+
+			patch := client.RawPatch(client.Merge.Type(), []byte(fmt.Sprintf(
+				`{"metadata":{"labels":{%q:%q}}}`, naming.LabelLoadBalance, value)))
+
+			if err := errors.WithStack(client.IgnoreNotFound(
+				r.Writer.Patch(ctx, pod, patch))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // +kubebuilder:rbac:groups="policy",resources="poddisruptionbudgets",verbs={list}
@@ -853,16 +925,23 @@ func (r *Reconciler) rolloutInstance(
 		}))
 }
 
+// replicaLagCheckInterval is how often replication lag is checked again while
+// it holds back a rollout. Changes to what Patroni reports about its members
+// do not trigger a reconcile.
+const replicaLagCheckInterval = 10 * time.Second
+
 // rolloutInstances compares instances to cluster and calls redeploy on those
 // that need their Pod recreated. It considers the overall availability of
-// cluster and minimizes Patroni failovers.
+// cluster and minimizes Patroni failovers. It returns how long to wait before
+// checking again when replication lag holds back a redeploy.
 func (r *Reconciler) rolloutInstances(
 	ctx context.Context,
 	cluster *v1beta1.PostgresCluster,
 	instances *observedInstances,
 	redeploy func(context.Context, *Instance) error,
-) error {
+) (time.Duration, error) {
 	var err error
+	var lagging bool
 	var consider []*Instance
 	var numAvailable int
 	var numSpecified int
@@ -870,10 +949,45 @@ func (r *Reconciler) rolloutInstances(
 	ctx, span := tracing.Start(ctx, "rollout-instances")
 	defer span.End()
 
+	strategy := initialize.FromPointer(cluster.Spec.Rollout)
+
 	for _, set := range cluster.Spec.InstanceSets {
 		numSpecified += int(*set.Replicas)
 	}
 
+	// Replication lag is measured against the primary, when it is known.
+	var primaryWAL *int64
+	for _, instance := range instances.forCluster {
+		if m, ok := patroniMemberStateOf(instance); ok && m.Role == "primary" {
+			primaryWAL = m.XLogLocation
+		}
+	}
+
+	// An instance counts toward availability when it is available, has been
+	// available for the minimum ready seconds of its StatefulSet, and is not
+	// too far behind the primary.
+	counts := func(instance *Instance) bool {
+		if available, known := instance.IsAvailable(); !known || !available {
+			return false
+		}
+		if runner := instance.Runner; runner != nil && runner.Spec.MinReadySeconds > 0 &&
+			(runner.Status.ObservedGeneration != runner.Generation || runner.Status.AvailableReplicas < 1) {
+			return false
+		}
+		if strategy.MaxReplicaLag != nil {
+			// Compare the location replayed by a replica rather than received;
+			// only replayed changes are visible to queries.
+			if m, ok := patroniMemberStateOf(instance); !ok || m.Role != "primary" {
+				if !ok || m.ReplayLSN == nil || primaryWAL == nil ||
+					*primaryWAL-*m.ReplayLSN > strategy.MaxReplicaLag.Value() {
+					lagging = true
+					return false
+				}
+			}
+		}
+		return true
+	}
+
 	for _, instance := range instances.forCluster {
 		// Skip instances that have no set in cluster spec. They should not be
 		// redeployed and should not count toward availability.
@@ -887,7 +1001,7 @@ func (r *Reconciler) rolloutInstances(
 			continue
 		}
 
-		if available, known := instance.IsAvailable(); known && available {
+		if counts(instance) {
 			numAvailable++
 		}
 
@@ -897,13 +1011,19 @@ func (r *Reconciler) rolloutInstances(
 		}
 	}
 
-	const maxUnavailable = 1
+	maxUnavailable := max(1, int(initialize.FromPointer(strategy.MaxUnavailable)))
 	numUnavailable := numSpecified - numAvailable
 
+	// Recreating an available instance interrupts its PostgreSQL connections.
+	// Wait for a maintenance window to do that. Instances that are already
+	// unavailable can be recreated at any time.
+	// See [Reconciler.reconcilePendingMaintenance].
+	allowed := maintenanceAllowed(cluster)
+
 	// When multiple instances need to redeploy, sort them so the lowest
 	// priority instances are first.
 	if len(consider) > 1 {
-		sort.Sort(byPriority(consider))
+		sort.Sort(byRolloutOrder(consider, strategy.InstanceSetOrder))
 	}
 
 	tracing.Int(span, "instances", len(instances.forCluster))
@@ -911,21 +1031,36 @@ func (r *Reconciler) rolloutInstances(
 	tracing.Int(span, "available", numAvailable)
 	tracing.Int(span, "considering", len(consider))
 
+	// Recreate nothing while the rollout is paused.
+	if initialize.FromPointer(strategy.Paused) {
+		return 0, nil
+	}
+
 	// Redeploy instances up to the allowed maximum while "rolling over" any
 	// unavailable instances.
 	// - https://issue.k8s.io/67250
+	var held bool
 	for _, instance := range consider {
 		if err == nil {
 			if available, known := instance.IsAvailable(); known && !available {
 				err = redeploy(ctx, instance)
-			} else if numUnavailable < maxUnavailable {
+			} else if numUnavailable < maxUnavailable && allowed {
 				err = redeploy(ctx, instance)
 				numUnavailable++
+			} else {
+				held = true
 			}
 		}
 	}
 
-	return tracing.Escape(span, err)
+	// Patroni reports replication progress through Pod annotations, which do
+	// not trigger a reconcile. Check again soon when lag is holding back
+	// a redeploy.
+	if held && lagging && allowed {
+		return replicaLagCheckInterval, tracing.Escape(span, err)
+	}
+
+	return 0, tracing.Escape(span, err)
 }
 
 // scaleDownInstances removes extra instances from a cluster until it matches
@@ -1084,10 +1219,12 @@ func (r *Reconciler) scaleUpInstances(
 		instances = append(instances, &appsv1.StatefulSet{ObjectMeta: next})
 	}
 
+	replicateFrom := replicationSource(cluster, observed, set)
+
 	var err error
 	for i := range instances {
 		err = r.reconcileInstance(
-			ctx, cluster, observed.byName[instances[i].Name], set,
+			ctx, cluster, observed.byName[instances[i].Name], set, replicateFrom,
 			clusterConfigMap, clusterReplicationSecret,
 			rootCA, clusterPodService, instanceServiceAccount,
 			patroniLeaderService, primaryCertificate, instances[i],
@@ -1102,6 +1239,32 @@ func (r *Reconciler) scaleUpInstances(
 	return instances, err
 }
 
+// replicationSource returns the name of the Patroni member from which the
+// instances of set should replicate, if any. This is the first Pod, by name,
+// of the instance set named in its "replicateFrom" tag. Instance sets whose
+// tags form a cycle replicate from the primary.
+func replicationSource(
+	cluster *v1beta1.PostgresCluster, observed *observedInstances, set *v1beta1.PostgresInstanceSetSpec,
+) string {
+	if set.Patroni == nil || set.Patroni.Tags == nil ||
+		set.Patroni.Tags.ReplicateFrom == "" || replicationLoops(cluster, set) {
+		return ""
+	}
+
+	var names []string
+	for _, instance := range observed.bySet[set.Patroni.Tags.ReplicateFrom] {
+		for _, pod := range instance.Pods {
+			if pod.DeletionTimestamp == nil {
+				names = append(names, pod.Name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return slices.Min(names)
+}
+
 // +kubebuilder:rbac:groups="apps",resources="statefulsets",verbs={create,patch}
 
 // reconcileInstance writes instance according to spec of cluster.
@@ -1111,6 +1274,7 @@ func (r *Reconciler) reconcileInstance(
 	cluster *v1beta1.PostgresCluster,
 	observed *Instance,
 	spec *v1beta1.PostgresInstanceSetSpec,
+	replicateFrom string,
 	clusterConfigMap *corev1.ConfigMap,
 	clusterReplicationSecret *corev1.Secret,
 	rootCA *pki.RootCertificateAuthority,
@@ -1149,7 +1313,8 @@ func (r *Reconciler) reconcileInstance(
 	)
 
 	if err == nil {
-		instanceConfigMap, err = r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, otelConfig, backupsSpecFound)
+		instanceConfigMap, err = r.reconcileInstanceConfigMap(
+			ctx, cluster, spec, instance, replicateFrom, otelConfig, backupsSpecFound)
 	}
 	if err == nil {
 		instanceCertificates, err = r.reconcileInstanceCertificates(
@@ -1302,6 +1467,13 @@ func generateInstanceStatefulSetIntent(_ context.Context,
 			naming.LabelData:        naming.DataPostgres,
 		})
 
+	// Label every instance when any are excluded from the replica Service so
+	// that it can select those that are not.
+	if excludesReplicas(cluster) {
+		sts.Spec.Template.Labels[naming.LabelLoadBalance] =
+			strconv.FormatBool(patroni.LoadBalanced(spec))
+	}
+
 	// Don't clutter the namespace with extra ControllerRevisions.
 	// The "controller-revision-hash" label still exists on the Pod.
 	sts.Spec.RevisionHistoryLimit = initialize.Int32(0)
@@ -1317,6 +1489,11 @@ func generateInstanceStatefulSetIntent(_ context.Context,
 	// - https://docs.k8s.io/concepts/workloads/controllers/statefulset/#on-delete
 	sts.Spec.UpdateStrategy.Type = appsv1.OnDeleteStatefulSetStrategyType
 
+	// Count the Pod as available after it has been ready for a while. Changes
+	// to its status wake [Reconciler.rolloutInstances].
+	sts.Spec.MinReadySeconds = initialize.FromPointer(
+		initialize.FromPointer(cluster.Spec.Rollout).MinReadySeconds)
+
 	// Use scheduling constraints from the cluster spec.
 	sts.Spec.Template.Spec.Affinity = spec.Affinity
 	sts.Spec.Template.Spec.Tolerations = spec.Tolerations
@@ -1401,10 +1578,12 @@ func addPGBackRestToInstancePodSpec(
 // +kubebuilder:rbac:groups="",resources="configmaps",verbs={create,patch}
 
 // reconcileInstanceConfigMap writes the ConfigMap that contains generated
-// files (etc) that apply to instance of cluster.
+// files (etc) that apply to instance of cluster. When replicateFrom is not
+// empty, instance replicates from that Patroni member.
 func (r *Reconciler) reconcileInstanceConfigMap(
 	ctx context.Context, cluster *v1beta1.PostgresCluster, spec *v1beta1.PostgresInstanceSetSpec,
-	instance *appsv1.StatefulSet, otelConfig *collector.Config, backupsSpecFound bool,
+	instance *appsv1.StatefulSet, replicateFrom string,
+	otelConfig *collector.Config, backupsSpecFound bool,
 ) (*corev1.ConfigMap, error) {
 	instanceConfigMap := &corev1.ConfigMap{ObjectMeta: naming.InstanceConfigMap(instance)}
 	instanceConfigMap.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
@@ -1443,7 +1622,7 @@ func (r *Reconciler) reconcileInstanceConfigMap(
 		}
 	}
 	if err == nil {
-		err = patroni.InstanceConfigMap(ctx, cluster, spec, instanceConfigMap)
+		err = patroni.InstanceConfigMap(ctx, cluster, spec, replicateFrom, instanceConfigMap)
 	}
 	if err == nil {
 		err = errors.WithStack(r.apply(ctx, instanceConfigMap))
@@ -1500,17 +1679,20 @@ func (r *Reconciler) reconcileInstanceCertificates(
 	}
 	if err == nil {
 		err = patroni.InstanceCertificates(ctx,
-			root.Certificate, leafCert.Certificate,
+			root.Bundle(), leafCert.Certificate,
 			leafCert.PrivateKey, instanceCerts)
 	}
 	if err == nil && backupsSpecFound {
 		err = pgbackrest.InstanceCertificates(ctx, cluster,
-			root.Certificate, leafCert.Certificate, leafCert.PrivateKey,
+			root.Bundle(), leafCert.Certificate, leafCert.PrivateKey,
 			instanceCerts)
 	}
 	if err == nil {
 		err = errors.WithStack(r.apply(ctx, instanceCerts))
 	}
+	if err == nil {
+		recordCertificateExpiry(cluster, instanceCerts.Name, leafCert.Certificate)
+	}
 
 	return instanceCerts, err
 }
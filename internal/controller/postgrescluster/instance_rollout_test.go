@@ -9,6 +9,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/trace"
@@ -23,7 +24,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
 	"github.com/crunchydata/postgres-operator/internal/tracing"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
@@ -192,11 +195,12 @@ func TestReconcilerRolloutInstances(t *testing.T) {
 		observed := new(observedInstances)
 
 		ctx := logSpanAttributes(t, ctx)
-		assert.NilError(t, reconciler.rolloutInstances(ctx, cluster, observed,
+		_, err := reconciler.rolloutInstances(ctx, cluster, observed,
 			func(context.Context, *Instance) error {
 				t.Fatal("expected no redeploys")
 				return nil
-			}))
+			})
+		assert.NilError(t, err)
 	})
 
 	// Single healthy instance; nothing to do.
@@ -237,11 +241,12 @@ func TestReconcilerRolloutInstances(t *testing.T) {
 		observed := &observedInstances{forCluster: instances}
 
 		ctx := logSpanAttributes(t, ctx)
-		assert.NilError(t, reconciler.rolloutInstances(ctx, cluster, observed,
+		_, err := reconciler.rolloutInstances(ctx, cluster, observed,
 			func(context.Context, *Instance) error {
 				t.Fatal("expected no redeploys")
 				return nil
-			}))
+			})
+		assert.NilError(t, err)
 	})
 
 	// Single healthy instance, Pod does not match PodTemplate.
@@ -284,11 +289,73 @@ func TestReconcilerRolloutInstances(t *testing.T) {
 		var redeploys []*Instance
 
 		ctx := logSpanAttributes(t, ctx)
-		assert.NilError(t, reconciler.rolloutInstances(ctx, cluster, observed, accumulate(&redeploys)))
+		_, err := reconciler.rolloutInstances(ctx, cluster, observed, accumulate(&redeploys))
+		assert.NilError(t, err)
 		assert.Equal(t, len(redeploys), 1)
 		assert.Equal(t, redeploys[0].Name, "one")
 	})
 
+	// Two instances do not match PodTemplate outside of maintenance windows.
+	t.Run("OutsideMaintenanceWindow", func(t *testing.T) {
+		cluster := new(v1beta1.PostgresCluster)
+		cluster.Spec.InstanceSets = []v1beta1.PostgresInstanceSetSpec{
+			{Name: "00", Replicas: initialize.Int32(2)},
+		}
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			maintenanceWindows: [{ schedule: "0 0 31 2 *", duration: 1h }],
+		}`)
+
+		outdated := func(name string, ready corev1.ConditionStatus) *Instance {
+			return &Instance{
+				Name: name,
+				Spec: &cluster.Spec.InstanceSets[0],
+				Pods: []*corev1.Pod{{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"controller-revision-hash": "beta",
+						},
+					},
+					Status: corev1.PodStatus{
+						Conditions: []corev1.PodCondition{{
+							Type:   corev1.PodReady,
+							Status: ready,
+						}},
+					},
+				}},
+				Runner: &appsv1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Generation: 1,
+					},
+					Status: appsv1.StatefulSetStatus{
+						ObservedGeneration: 1,
+						UpdateRevision:     "gamma",
+					},
+				},
+			}
+		}
+		observed := &observedInstances{forCluster: []*Instance{
+			outdated("one", corev1.ConditionTrue),
+			outdated("two", corev1.ConditionFalse),
+		}}
+
+		var redeploys []*Instance
+
+		ctx := logSpanAttributes(t, ctx)
+		_, err := reconciler.rolloutInstances(ctx, cluster, observed, accumulate(&redeploys))
+		assert.NilError(t, err)
+		assert.Equal(t, len(redeploys), 1, "expected only the unavailable instance")
+		assert.Equal(t, redeploys[0].Name, "two")
+
+		// The annotation allows the rollout.
+		cluster.Annotations = map[string]string{naming.IgnoreMaintenanceWindows: ""}
+		observed.forCluster[1] = outdated("two", corev1.ConditionTrue)
+		redeploys = nil
+
+		_, err = reconciler.rolloutInstances(ctx, cluster, observed, accumulate(&redeploys))
+		assert.NilError(t, err)
+		assert.Equal(t, len(redeploys), 1, "expected one at a time")
+	})
+
 	// Two ready instances do not match PodTemplate, no primary.
 	t.Run("ManyOutdated", func(t *testing.T) {
 		cluster := new(v1beta1.PostgresCluster)
@@ -354,7 +421,8 @@ func TestReconcilerRolloutInstances(t *testing.T) {
 		var redeploys []*Instance
 
 		ctx := logSpanAttributes(t, ctx)
-		assert.NilError(t, reconciler.rolloutInstances(ctx, cluster, observed, accumulate(&redeploys)))
+		_, err := reconciler.rolloutInstances(ctx, cluster, observed, accumulate(&redeploys))
+		assert.NilError(t, err)
 		assert.Equal(t, len(redeploys), 1)
 		assert.Equal(t, redeploys[0].Name, "one", `expected the "lowest" name`)
 	})
@@ -425,7 +493,8 @@ func TestReconcilerRolloutInstances(t *testing.T) {
 		var redeploys []*Instance
 
 		ctx := logSpanAttributes(t, ctx)
-		assert.NilError(t, reconciler.rolloutInstances(ctx, cluster, observed, accumulate(&redeploys)))
+		_, err := reconciler.rolloutInstances(ctx, cluster, observed, accumulate(&redeploys))
+		assert.NilError(t, err)
 		assert.Equal(t, len(redeploys), 1)
 		assert.Equal(t, redeploys[0].Name, "not-primary")
 	})
@@ -495,7 +564,8 @@ func TestReconcilerRolloutInstances(t *testing.T) {
 		var redeploys []*Instance
 
 		ctx := logSpanAttributes(t, ctx)
-		assert.NilError(t, reconciler.rolloutInstances(ctx, cluster, observed, accumulate(&redeploys)))
+		_, err := reconciler.rolloutInstances(ctx, cluster, observed, accumulate(&redeploys))
+		assert.NilError(t, err)
 		assert.Equal(t, len(redeploys), 1)
 		assert.Equal(t, redeploys[0].Name, "not-ready")
 	})
@@ -564,11 +634,12 @@ func TestReconcilerRolloutInstances(t *testing.T) {
 		observed := &observedInstances{forCluster: instances}
 
 		ctx := logSpanAttributes(t, ctx)
-		assert.NilError(t, reconciler.rolloutInstances(ctx, cluster, observed,
+		_, err := reconciler.rolloutInstances(ctx, cluster, observed,
 			func(context.Context, *Instance) error {
 				t.Fatal("expected no redeploys")
 				return nil
-			}))
+			})
+		assert.NilError(t, err)
 	})
 
 	// Two instances do not match PodTemplate, one is orphaned. Do nothing.
@@ -633,10 +704,164 @@ func TestReconcilerRolloutInstances(t *testing.T) {
 		observed := &observedInstances{forCluster: instances}
 
 		ctx := logSpanAttributes(t, ctx)
-		assert.NilError(t, reconciler.rolloutInstances(ctx, cluster, observed,
+		_, err := reconciler.rolloutInstances(ctx, cluster, observed,
 			func(context.Context, *Instance) error {
 				t.Fatal("expected no redeploys")
 				return nil
-			}))
+			})
+		assert.NilError(t, err)
+	})
+
+	t.Run("Strategy", func(t *testing.T) {
+		newCluster := func(t testing.TB, strategy string) *v1beta1.PostgresCluster {
+			cluster := new(v1beta1.PostgresCluster)
+			require.UnmarshalInto(t, &cluster.Spec, `{
+				instances: [{ name: "00", replicas: 2 }, { name: "01", replicas: 2 }],
+				rollout: `+strategy+`,
+			}`)
+			return cluster
+		}
+
+		// Four ready instances, all outdated; the primary is "00-a".
+		newObserved := func(cluster *v1beta1.PostgresCluster, status map[string]string) *observedInstances {
+			var instances []*Instance
+			for _, name := range []string{"00-a", "00-b", "01-a", "01-b"} {
+				set := &cluster.Spec.InstanceSets[0]
+				if strings.HasPrefix(name, "01") {
+					set = &cluster.Spec.InstanceSets[1]
+				}
+				instances = append(instances, &Instance{
+					Name: name,
+					Spec: set,
+					Pods: []*corev1.Pod{{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{"status": status[name]},
+							Labels: map[string]string{
+								"controller-revision-hash": "beta",
+							},
+						},
+						Status: corev1.PodStatus{
+							Conditions: []corev1.PodCondition{{
+								Type:   corev1.PodReady,
+								Status: corev1.ConditionTrue,
+							}},
+						},
+					}},
+					Runner: &appsv1.StatefulSet{
+						ObjectMeta: metav1.ObjectMeta{
+							Generation: 1,
+						},
+						Status: appsv1.StatefulSetStatus{
+							ObservedGeneration: 1,
+							UpdateRevision:     "gamma",
+						},
+					},
+				})
+			}
+			instances[0].Pods[0].Labels["postgres-operator.crunchydata.com/role"] = "master"
+			return &observedInstances{forCluster: instances}
+		}
+
+		names := func(instances []*Instance) []string {
+			var result []string
+			for _, instance := range instances {
+				result = append(result, instance.Name)
+			}
+			return result
+		}
+
+		t.Run("MaxUnavailable", func(t *testing.T) {
+			cluster := newCluster(t, `{ maxUnavailable: 3 }`)
+			observed := newObserved(cluster, nil)
+
+			var redeploys []*Instance
+
+			ctx := logSpanAttributes(t, ctx)
+			_, err := reconciler.rolloutInstances(ctx, cluster, observed, accumulate(&redeploys))
+			assert.NilError(t, err)
+			assert.DeepEqual(t, names(redeploys), []string{"00-b", "01-a", "01-b"})
+		})
+
+		t.Run("InstanceSetOrder", func(t *testing.T) {
+			cluster := newCluster(t, `{ instanceSetOrder: ["01"], maxUnavailable: 4 }`)
+			observed := newObserved(cluster, nil)
+
+			var redeploys []*Instance
+
+			ctx := logSpanAttributes(t, ctx)
+			_, err := reconciler.rolloutInstances(ctx, cluster, observed, accumulate(&redeploys))
+			assert.NilError(t, err)
+			assert.DeepEqual(t, names(redeploys), []string{"01-a", "01-b", "00-b", "00-a"})
+		})
+
+		t.Run("Paused", func(t *testing.T) {
+			cluster := newCluster(t, `{ paused: true }`)
+			observed := newObserved(cluster, nil)
+
+			ctx := logSpanAttributes(t, ctx)
+			_, err := reconciler.rolloutInstances(ctx, cluster, observed,
+				func(context.Context, *Instance) error {
+					t.Fatal("expected no redeploys")
+					return nil
+				})
+			assert.NilError(t, err)
+		})
+
+		t.Run("MaxReplicaLag", func(t *testing.T) {
+			cluster := newCluster(t, `{ maxReplicaLag: 16Mi }`)
+			status := map[string]string{
+				"00-a": `{"role":"primary","xlog_location":50331648}`,
+				"00-b": `{"role":"replica","xlog_location":50331648,"replay_lsn":50331648}`,
+				"01-a": `{"role":"replica","xlog_location":33554432,"replay_lsn":33554432}`,
+				"01-b": `{"role":"replica","xlog_location":50331648,"replay_lsn":16777216}`,
+			}
+
+			var redeploys []*Instance
+
+			// A replica is measured by what it has replayed, not received.
+			ctx := logSpanAttributes(t, ctx)
+			requeue, err := reconciler.rolloutInstances(ctx, cluster,
+				newObserved(cluster, status), accumulate(&redeploys))
+			assert.NilError(t, err)
+			assert.Equal(t, len(redeploys), 0, "expected to wait for 01-b to catch up")
+			assert.Equal(t, requeue, replicaLagCheckInterval)
+
+			status["01-b"] = `{"role":"replica","xlog_location":50331648,"replay_lsn":33554432}`
+			requeue, err = reconciler.rolloutInstances(ctx, cluster,
+				newObserved(cluster, status), accumulate(&redeploys))
+			assert.NilError(t, err)
+			assert.DeepEqual(t, names(redeploys), []string{"00-b"})
+			assert.Equal(t, requeue, time.Duration(0))
+
+			delete(status, "00-a")
+			redeploys = nil
+			requeue, err = reconciler.rolloutInstances(ctx, cluster,
+				newObserved(cluster, status), accumulate(&redeploys))
+			assert.NilError(t, err)
+			assert.Equal(t, len(redeploys), 0, "expected to wait for the primary")
+			assert.Equal(t, requeue, replicaLagCheckInterval)
+		})
+
+		t.Run("MinReadySeconds", func(t *testing.T) {
+			cluster := newCluster(t, `{ minReadySeconds: 30 }`)
+			observed := newObserved(cluster, nil)
+			for _, instance := range observed.forCluster {
+				instance.Runner.Spec.MinReadySeconds = 30
+				instance.Runner.Status.AvailableReplicas = 1
+			}
+			observed.forCluster[3].Runner.Status.AvailableReplicas = 0
+
+			var redeploys []*Instance
+
+			ctx := logSpanAttributes(t, ctx)
+			_, err := reconciler.rolloutInstances(ctx, cluster, observed, accumulate(&redeploys))
+			assert.NilError(t, err)
+			assert.Equal(t, len(redeploys), 0, "expected to wait for 01-b")
+
+			observed.forCluster[3].Runner.Status.AvailableReplicas = 1
+			_, err = reconciler.rolloutInstances(ctx, cluster, observed, accumulate(&redeploys))
+			assert.NilError(t, err)
+			assert.DeepEqual(t, names(redeploys), []string{"00-b"})
+		})
 	})
 }
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/crunchydata/postgres-operator/internal/collector"
 	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
@@ -1434,6 +1435,57 @@ func TestGenerateInstanceStatefulSetIntent(t *testing.T) {
 			assert.Equal(t, ss.Spec.Template.Spec.PriorityClassName,
 				"some-priority-class")
 		},
+	}, {
+		name: "no replication delay",
+		run: func(t *testing.T, ss *appsv1.StatefulSet) {
+			_, labeled := ss.Spec.Template.Labels[naming.LabelLoadBalance]
+			assert.Assert(t, !labeled)
+		},
+	}, {
+		name: "replication delay",
+		ip: intentParams{
+			cluster: func() *v1beta1.PostgresCluster {
+				cluster := testCluster()
+				cluster.Spec.InstanceSets = append(cluster.Spec.InstanceSets,
+					v1beta1.PostgresInstanceSetSpec{
+						Name:             "delayed",
+						ReplicationDelay: require.Value(v1beta1.NewDuration("1h")),
+					})
+				return cluster
+			}(),
+			spec: &v1beta1.PostgresInstanceSetSpec{Name: "instance1"},
+		},
+		run: func(t *testing.T, ss *appsv1.StatefulSet) {
+			assert.Equal(t, ss.Spec.Template.Labels[naming.LabelLoadBalance], "true")
+		},
+	}, {
+		name: "rollout min ready seconds",
+		ip: intentParams{
+			cluster: func() *v1beta1.PostgresCluster {
+				cluster := testCluster()
+				cluster.Spec.Rollout = &v1beta1.RolloutStrategy{
+					MinReadySeconds: initialize.Int32(30),
+				}
+				return cluster
+			}(),
+		},
+		run: func(t *testing.T, ss *appsv1.StatefulSet) {
+			assert.Equal(t, ss.Spec.MinReadySeconds, int32(30))
+		},
+	}, {
+		name: "replica lag does not affect readiness",
+		ip: intentParams{
+			cluster: func() *v1beta1.PostgresCluster {
+				cluster := testCluster()
+				require.UnmarshalInto(t, &cluster.Spec, `{ replicaRouting: { maxLag: 16Mi } }`)
+				return cluster
+			}(),
+		},
+		run: func(t *testing.T, ss *appsv1.StatefulSet) {
+			// Lagging replicas stay available to rollouts; only Services skip them.
+			// See [Reconciler.reconcileReplicaLag].
+			assert.Assert(t, ss.Spec.Template.Spec.ReadinessGates == nil)
+		},
 	}, {
 		name: "check default scheduling constraints are added",
 		run: func(t *testing.T, ss *appsv1.StatefulSet) {
@@ -1626,6 +1678,94 @@ func TestGenerateInstanceStatefulSetIntent(t *testing.T) {
 	}
 }
 
+func TestReplicationSource(t *testing.T) {
+	t.Parallel()
+
+	cluster := v1beta1.NewPostgresCluster()
+	require.UnmarshalInto(t, &cluster.Spec, `{
+		instances: [
+			{ name: near, replicas: 2 },
+			{ name: far, patroni: { tags: { replicateFrom: near } } },
+		],
+	}`)
+
+	now := metav1.Now()
+	observed := newObservedInstances(cluster, nil, []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pg-near-zzzz-0", Labels: map[string]string{
+			naming.LabelInstanceSet: "near", naming.LabelInstance: "pg-near-zzzz",
+		}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pg-near-bbbb-0", Labels: map[string]string{
+			naming.LabelInstanceSet: "near", naming.LabelInstance: "pg-near-bbbb",
+		}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pg-near-aaaa-0", DeletionTimestamp: &now, Labels: map[string]string{
+			naming.LabelInstanceSet: "near", naming.LabelInstance: "pg-near-aaaa",
+		}}},
+	})
+
+	assert.Equal(t, replicationSource(cluster, observed, &cluster.Spec.InstanceSets[0]), "")
+	assert.Equal(t, replicationSource(cluster, observed, &cluster.Spec.InstanceSets[1]), "pg-near-bbbb-0")
+
+	// Replicate from the primary when the tags form a cycle.
+	cycle := cluster.DeepCopy()
+	cycle.Spec.InstanceSets[0].Patroni = &v1beta1.PatroniInstanceSetSpec{
+		Tags: &v1beta1.PatroniTags{ReplicateFrom: "far"},
+	}
+	assert.Equal(t, replicationSource(cycle, observed, &cycle.Spec.InstanceSets[1]), "")
+
+	// Replicate from the primary when the set has no Pods.
+	observed = newObservedInstances(cluster, nil, nil)
+	assert.Equal(t, replicationSource(cluster, observed, &cluster.Spec.InstanceSets[1]), "")
+}
+
+func TestReconcileLoadBalanceLabels(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace = "ns1"
+	require.UnmarshalInto(t, &cluster.Spec, `{
+		instances: [
+			{ name: near },
+			{ name: far, replicationDelay: 1h },
+		],
+	}`)
+
+	pod := func(name, value string) *corev1.Pod {
+		pod := &corev1.Pod{}
+		pod.Namespace, pod.Name = "ns1", name
+		if value != "" {
+			pod.Labels = map[string]string{naming.LabelLoadBalance: value}
+		}
+		return pod
+	}
+	pods := []*corev1.Pod{pod("pg-near-aaaa-0", ""), pod("pg-near-bbbb-0", "true"), pod("pg-far-cccc-0", "true")}
+
+	cc := fake.NewClientBuilder().WithScheme(runtime.Scheme).
+		WithObjects(pods[0].DeepCopy(), pods[1].DeepCopy(), pods[2].DeepCopy()).Build()
+	r := &Reconciler{Reader: cc, Writer: cc}
+
+	assert.NilError(t, r.reconcileLoadBalanceLabels(ctx, cluster, &cluster.Spec.InstanceSets[0],
+		[]*Instance{{Pods: pods[0:1]}, {Pods: pods[1:2]}}))
+	assert.NilError(t, r.reconcileLoadBalanceLabels(ctx, cluster, &cluster.Spec.InstanceSets[1],
+		[]*Instance{{Pods: pods[2:3]}}))
+
+	for name, expected := range map[string]string{
+		"pg-near-aaaa-0": "true", "pg-near-bbbb-0": "true", "pg-far-cccc-0": "false",
+	} {
+		actual := &corev1.Pod{}
+		assert.NilError(t, cc.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: name}, actual))
+		assert.Equal(t, actual.Labels[naming.LabelLoadBalance], expected, "pod %q", name)
+	}
+
+	t.Run("NotExcluded", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.InstanceSets[1].ReplicationDelay = nil
+
+		r := &Reconciler{}
+		assert.NilError(t, r.reconcileLoadBalanceLabels(ctx, cluster, &cluster.Spec.InstanceSets[0],
+			[]*Instance{{Pods: pods[0:1]}}), "expected no calls")
+	})
+}
+
 func TestFindAvailableInstanceNames(t *testing.T) {
 
 	testCases := []struct {
@@ -2049,7 +2189,7 @@ func TestReconcileInstanceConfigMap(t *testing.T) {
 		pgParameters := r.generatePostgresParameters(ctx, cluster, true)
 		otelConfig := collector.NewConfigForPostgresPod(ctx, cluster, pgParameters)
 
-		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, otelConfig, true)
+		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, "", otelConfig, true)
 		assert.NilError(t, err)
 		assert.Equal(t, cm.Name, "test-hippo-1-instance-config")
 		assert.Equal(t, cm.Data["collector.yaml"], "")
@@ -2079,7 +2219,7 @@ func TestReconcileInstanceConfigMap(t *testing.T) {
 		pgParameters := r.generatePostgresParameters(ctx, cluster, true)
 		otelConfig := collector.NewConfigForPostgresPod(ctx, cluster, pgParameters)
 
-		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, otelConfig, true)
+		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, "", otelConfig, true)
 		assert.NilError(t, err)
 		assert.Equal(t, cm.Name, "test-hippo-2-instance-config")
 		assert.Equal(t, cm.Data["collector.yaml"], "")
@@ -2110,7 +2250,7 @@ func TestReconcileInstanceConfigMap(t *testing.T) {
 		pgParameters := r.generatePostgresParameters(ctx, cluster, true)
 		otelConfig := collector.NewConfigForPostgresPod(ctx, cluster, pgParameters)
 
-		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, otelConfig, true)
+		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, "", otelConfig, true)
 		assert.NilError(t, err)
 		assert.Equal(t, cm.Name, "test-hippo-3-instance-config")
 		// We test the contents of the collector yaml elsewhere, I just want to
@@ -2143,7 +2283,7 @@ func TestReconcileInstanceConfigMap(t *testing.T) {
 		pgParameters := r.generatePostgresParameters(ctx, cluster, true)
 		otelConfig := collector.NewConfigForPostgresPod(ctx, cluster, pgParameters)
 
-		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, otelConfig, true)
+		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, "", otelConfig, true)
 		assert.NilError(t, err)
 		assert.Equal(t, cm.Name, "test-hippo-4-instance-config")
 		// We test the contents of the collector and logrotate configs elsewhere,
@@ -2176,7 +2316,7 @@ func TestReconcileInstanceConfigMap(t *testing.T) {
 		pgParameters := r.generatePostgresParameters(ctx, cluster, true)
 		otelConfig := collector.NewConfigForPostgresPod(ctx, cluster, pgParameters)
 
-		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, otelConfig, true)
+		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, "", otelConfig, true)
 		assert.NilError(t, err)
 		assert.Equal(t, cm.Name, "test-hippo-5-instance-config")
 		// We test the contents of the collector yaml elsewhere, I just want to
@@ -2209,7 +2349,7 @@ func TestReconcileInstanceConfigMap(t *testing.T) {
 		pgParameters := r.generatePostgresParameters(ctx, cluster, true)
 		otelConfig := collector.NewConfigForPostgresPod(ctx, cluster, pgParameters)
 
-		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, otelConfig, true)
+		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, "", otelConfig, true)
 		assert.NilError(t, err)
 		assert.Equal(t, cm.Name, "test-hippo-6-instance-config")
 		// We test the contents of the collector and logrotate configs elsewhere,
@@ -2235,7 +2375,7 @@ func TestReconcileInstanceConfigMap(t *testing.T) {
 		pgParameters := r.generatePostgresParameters(ctx, cluster, false)
 		otelConfig := collector.NewConfigForPostgresPod(ctx, cluster, pgParameters)
 
-		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, otelConfig, false)
+		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, "", otelConfig, false)
 		assert.NilError(t, err)
 		assert.Equal(t, cm.Name, "test-hippo-7-instance-config")
 		assert.Equal(t, cm.Data["collector.yaml"], "")
@@ -2266,7 +2406,7 @@ func TestReconcileInstanceConfigMap(t *testing.T) {
 		pgParameters := r.generatePostgresParameters(ctx, cluster, false)
 		otelConfig := collector.NewConfigForPostgresPod(ctx, cluster, pgParameters)
 
-		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, otelConfig, false)
+		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, "", otelConfig, false)
 		assert.NilError(t, err)
 		assert.Equal(t, cm.Name, "test-hippo-8-instance-config")
 		assert.Assert(t, len(cm.Data["collector.yaml"]) > 0)
@@ -2297,7 +2437,7 @@ func TestReconcileInstanceConfigMap(t *testing.T) {
 		pgParameters := r.generatePostgresParameters(ctx, cluster, false)
 		otelConfig := collector.NewConfigForPostgresPod(ctx, cluster, pgParameters)
 
-		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, otelConfig, false)
+		cm, err := r.reconcileInstanceConfigMap(ctx, cluster, spec, instance, "", otelConfig, false)
 		assert.NilError(t, err)
 		assert.Equal(t, cm.Name, "test-hippo-9-instance-config")
 		assert.Assert(t, len(cm.Data["collector.yaml"]) > 0)
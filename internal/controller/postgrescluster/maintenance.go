@@ -0,0 +1,102 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crunchydata/postgres-operator/internal/maintenance"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/patroni"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// maintenanceAllowed returns whether or not cluster can be disrupted right now.
+// See [maintenance.Allowed].
+func maintenanceAllowed(cluster *v1beta1.PostgresCluster) bool {
+	allowed, _, _ := maintenance.Allowed(cluster, time.Now())
+	return allowed
+}
+
+// +kubebuilder:rbac:groups="apps",resources="deployments",verbs={get}
+
+// reconcilePendingMaintenance sets the PendingMaintenance condition of cluster
+// to the disruptions that are waiting for a maintenance window. It returns
+// how long until the next window opens when anything is waiting.
+func (r *Reconciler) reconcilePendingMaintenance(
+	ctx context.Context, cluster *v1beta1.PostgresCluster, instances *observedInstances,
+) (time.Duration, error) {
+	now := time.Now()
+	allowed, next, invalid := maintenance.Allowed(cluster, now)
+
+	if invalid != nil {
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "InvalidMaintenanceWindow",
+			"Maintenance windows that are not valid never open: %v", invalid)
+	}
+
+	var pending []string
+
+	if !allowed {
+		// These are the instances that [Reconciler.rolloutInstances] and
+		// [Reconciler.handlePatroniRestarts] would disrupt.
+		for _, instance := range instances.forCluster {
+			if instance.Spec == nil {
+				continue
+			}
+			if matches, known := instance.PodMatchesPodTemplate(); known && !matches {
+				pending = append(pending, "rollout of "+instance.Name)
+			} else if len(instance.Pods) > 0 && patroni.PodRequiresRestart(instance.Pods[0]) {
+				pending = append(pending, "restart of "+instance.Name)
+			}
+		}
+
+		// The PgBouncer Deployment is paused while its Pods are out of date.
+		// See [Reconciler.reconcilePGBouncerDeployment].
+		deploy := &appsv1.Deployment{ObjectMeta: naming.ClusterPGBouncer(cluster)}
+		err := errors.WithStack(client.IgnoreNotFound(
+			r.Reader.Get(ctx, client.ObjectKeyFromObject(deploy), deploy)))
+		if err != nil {
+			return 0, err
+		}
+
+		if deploy.Spec.Paused && deploy.Status.UpdatedReplicas < deploy.Status.Replicas {
+			pending = append(pending, "rollout of PgBouncer")
+		}
+	}
+
+	if len(pending) == 0 {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, v1beta1.PendingMaintenance)
+		return 0, nil
+	}
+
+	message := "Waiting for a maintenance window: " + strings.Join(pending, ", ") + "."
+	if !next.IsZero() {
+		message += fmt.Sprintf(" The next window opens at %s.", next.UTC().Format(time.RFC3339))
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:    v1beta1.PendingMaintenance,
+		Status:  metav1.ConditionTrue,
+		Reason:  "OutsideMaintenanceWindow",
+		Message: message,
+
+		ObservedGeneration: cluster.GetGeneration(),
+	})
+
+	if next.IsZero() {
+		return 0, nil
+	}
+	return next.Sub(now), nil
+}
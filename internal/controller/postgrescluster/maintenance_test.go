@@ -0,0 +1,144 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/events"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestReconcilePendingMaintenance(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Namespace, cluster.Name = "ns1", "hippo"
+	cluster.Spec.InstanceSets = []v1beta1.PostgresInstanceSetSpec{
+		{Name: "00", Replicas: initialize.Int32(3)},
+	}
+
+	instance := func(name, revision, status string) *Instance {
+		return &Instance{
+			Name: name,
+			Spec: &cluster.Spec.InstanceSets[0],
+			Pods: []*corev1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"status": status},
+					Labels:      map[string]string{"controller-revision-hash": revision},
+				},
+			}},
+			Runner: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Status: appsv1.StatefulSetStatus{
+					ObservedGeneration: 1,
+					UpdateRevision:     "gamma",
+				},
+			},
+		}
+	}
+	instances := &observedInstances{forCluster: []*Instance{
+		instance("hippo-00-abcd", "beta", `{"role":"replica"}`),
+		instance("hippo-00-efgh", "gamma", `{"role":"primary","pending_restart":true}`),
+		instance("hippo-00-ijkl", "gamma", `{"role":"replica"}`),
+		{Name: "hippo-01-mnop", Pods: []*corev1.Pod{{}}},
+	}}
+
+	deploy := &appsv1.Deployment{ObjectMeta: naming.ClusterPGBouncer(cluster)}
+	deploy.Spec.Paused = true
+	deploy.Status.Replicas = 2
+
+	reconciler := func(t *testing.T) (*Reconciler, *events.Recorder) {
+		cc := fake.NewClientBuilder().WithScheme(runtime.Scheme).
+			WithObjects(deploy.DeepCopy()).Build()
+		recorder := events.NewRecorder(t, runtime.Scheme)
+
+		return &Reconciler{Reader: cc, Recorder: recorder}, recorder
+	}
+
+	t.Run("NoWindows", func(t *testing.T) {
+		r, recorder := reconciler(t)
+		cluster := cluster.DeepCopy()
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type: v1beta1.PendingMaintenance, Status: metav1.ConditionTrue, Reason: "Old",
+		})
+
+		requeue, err := r.reconcilePendingMaintenance(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, time.Duration(0))
+		assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions, v1beta1.PendingMaintenance) == nil)
+		assert.Equal(t, len(recorder.Events), 0)
+	})
+
+	t.Run("OutsideWindow", func(t *testing.T) {
+		r, recorder := reconciler(t)
+		cluster := cluster.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			instances: [{ name: "00", replicas: 3 }],
+			maintenanceWindows: [{ schedule: "0 0 29 2 *", duration: 1m }],
+		}`)
+
+		before := time.Now()
+		requeue, err := r.reconcilePendingMaintenance(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.Equal(t, len(recorder.Events), 0)
+
+		opens := time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)
+		assert.Assert(t, requeue <= opens.Sub(before))
+		assert.Assert(t, requeue >= time.Until(opens), "expected to requeue when the window opens")
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, v1beta1.PendingMaintenance)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionTrue)
+		assert.Equal(t, condition.Reason, "OutsideMaintenanceWindow")
+		assert.Equal(t, condition.Message, "Waiting for a maintenance window: "+
+			"rollout of hippo-00-abcd, restart of hippo-00-efgh, rollout of PgBouncer. "+
+			"The next window opens at 2028-02-29T00:00:00Z.")
+
+		t.Run("Ignored", func(t *testing.T) {
+			cluster.Annotations = map[string]string{naming.IgnoreMaintenanceWindows: "true"}
+
+			requeue, err := r.reconcilePendingMaintenance(ctx, cluster, instances)
+			assert.NilError(t, err)
+			assert.Equal(t, requeue, time.Duration(0))
+			assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions, v1beta1.PendingMaintenance) == nil)
+		})
+	})
+
+	t.Run("InvalidWindow", func(t *testing.T) {
+		r, recorder := reconciler(t)
+		cluster := cluster.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			instances: [{ name: "00", replicas: 3 }],
+			maintenanceWindows: [{ schedule: "0 0 * * *", duration: 1h, timeZone: Nowhere }],
+		}`)
+
+		requeue, err := r.reconcilePendingMaintenance(ctx, cluster, &observedInstances{})
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, time.Duration(0), "expected no window to open")
+
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "InvalidMaintenanceWindow")
+		assert.Assert(t, cmp.Contains(recorder.Events[0].Note, "unknown time zone Nowhere"))
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, v1beta1.PendingMaintenance)
+		assert.Assert(t, condition != nil, "expected PgBouncer to wait")
+		assert.Equal(t, condition.Message, "Waiting for a maintenance window: rollout of PgBouncer.")
+	})
+}
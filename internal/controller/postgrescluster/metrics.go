@@ -0,0 +1,340 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"maps"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/pki"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// These metrics describe the most recent verification of each pgBackRest
+// repository. They are served by the controller-runtime metrics endpoint.
+var (
+	verifyDurationSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "postgres_operator",
+		Subsystem: "pgbackrest_verify",
+		Name:      "duration_seconds",
+		Help:      "How long the latest verification of a pgBackRest repository took.",
+	}, []string{"namespace", "cluster", "repo"})
+
+	verifySucceeded = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "postgres_operator",
+		Subsystem: "pgbackrest_verify",
+		Name:      "succeeded",
+		Help:      "Whether or not the latest verification of a pgBackRest repository succeeded.",
+	}, []string{"namespace", "cluster", "repo"})
+
+	verifyLastSuccessTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "postgres_operator",
+		Subsystem: "pgbackrest_verify",
+		Name:      "last_success_timestamp_seconds",
+		Help:      "When the latest successful verification of a pgBackRest repository finished.",
+	}, []string{"namespace", "cluster", "repo"})
+)
+
+// These metrics describe each PostgresCluster and what the operator does to it.
+// They are served by the controller-runtime metrics endpoint.
+var (
+	clusterPhase = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "postgres_operator",
+		Name:      "cluster_phase",
+		Help:      "Whether or not a PostgresCluster is in a phase: Paused, Shutdown, Progressing, or Ready.",
+	}, []string{"namespace", "cluster", "phase"})
+
+	reconcileErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "postgres_operator",
+		Name:      "reconcile_errors_total",
+		Help:      "The number of times a step in reconciling a PostgresCluster failed.",
+	}, []string{"namespace", "cluster", "step"})
+
+	// Only scheduled backups are counted here. Manual backups and PGBackRestJobs
+	// are reflected in backupLastSuccessTimestamp, which reads the repository.
+	scheduledBackupJobs = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "postgres_operator",
+		Subsystem: "pgbackrest_scheduled_backup",
+		Name:      "jobs",
+		Help:      "The number of scheduled backup Jobs of a pgBackRest repository that are kept, by outcome. Manual backups are not counted.",
+	}, []string{"namespace", "cluster", "repo", "type", "outcome"})
+
+	scheduledBackupDurationSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "postgres_operator",
+		Subsystem: "pgbackrest_scheduled_backup",
+		Name:      "duration_seconds",
+		Help:      "How long the latest successful scheduled backup Job of a pgBackRest repository took. Manual backups are not counted.",
+	}, []string{"namespace", "cluster", "repo", "type"})
+
+	backupLastSuccessTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "postgres_operator",
+		Subsystem: "pgbackrest_backup",
+		Name:      "last_success_timestamp_seconds",
+		Help:      "When the latest backup in a pgBackRest repository finished, however it was started.",
+	}, []string{"namespace", "cluster", "repo"})
+
+	certificateExpirationTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "postgres_operator",
+		Subsystem: "certificate",
+		Name:      "expiration_timestamp_seconds",
+		Help:      "When the certificate in a Secret expires.",
+	}, []string{"namespace", "cluster", "secret"})
+
+	volumeAutoGrowTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "postgres_operator",
+		Subsystem: "volume",
+		Name:      "autogrow_total",
+		Help:      "The number of volume expansions requested by auto-grow.",
+	}, []string{"namespace", "cluster", "volume", "name"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		verifyDurationSeconds,
+		verifySucceeded,
+		verifyLastSuccessTimestamp,
+
+		clusterPhase,
+		reconcileErrorsTotal,
+		scheduledBackupJobs,
+		scheduledBackupDurationSeconds,
+		backupLastSuccessTimestamp,
+		certificateExpirationTimestamp,
+		volumeAutoGrowTotal,
+	)
+}
+
+// clusterMetrics are all the metrics that have "namespace" and "cluster" labels.
+var clusterMetrics = []interface {
+	DeletePartialMatch(prometheus.Labels) int
+}{
+	verifyDurationSeconds,
+	verifySucceeded,
+	verifyLastSuccessTimestamp,
+
+	clusterPhase,
+	reconcileErrorsTotal,
+	scheduledBackupJobs,
+	scheduledBackupDurationSeconds,
+	backupLastSuccessTimestamp,
+	certificateExpirationTimestamp,
+	volumeAutoGrowTotal,
+}
+
+// repoMetrics are all the metrics that have "namespace", "cluster", and "repo" labels.
+var repoMetrics = []interface {
+	prometheus.Collector
+	DeletePartialMatch(prometheus.Labels) int
+}{
+	verifyDurationSeconds,
+	verifySucceeded,
+	verifyLastSuccessTimestamp,
+
+	scheduledBackupJobs,
+	scheduledBackupDurationSeconds,
+	backupLastSuccessTimestamp,
+}
+
+// forgetClusterMetrics removes every metric of cluster.
+func forgetClusterMetrics(cluster *v1beta1.PostgresCluster) {
+	labels := prometheus.Labels{"namespace": cluster.Namespace, "cluster": cluster.Name}
+
+	for _, metric := range clusterMetrics {
+		metric.DeletePartialMatch(labels)
+	}
+}
+
+// recordClusterMetrics updates the metrics that come from the status of cluster.
+func recordClusterMetrics(cluster *v1beta1.PostgresCluster) {
+	labels := prometheus.Labels{"namespace": cluster.Namespace, "cluster": cluster.Name}
+
+	// Set every phase so that a cluster counts in only one at a time.
+	phase := clusterPhaseOf(cluster)
+	for _, p := range []string{"Paused", "Shutdown", "Progressing", "Ready"} {
+		value := 0.0
+		if p == phase {
+			value = 1
+		}
+		clusterPhase.With(withLabels(labels, prometheus.Labels{"phase": p})).Set(value)
+	}
+
+	// Remove the metrics of repos that are no longer in the spec.
+	repos := sets.New[string]()
+	for _, repo := range cluster.Spec.Backups.PGBackRest.Repos {
+		repos.Insert(repo.Name)
+	}
+	forgetRepoMetrics(labels, repos)
+
+	if cluster.Status.PGBackRest == nil {
+		return
+	}
+
+	for _, repo := range cluster.Status.PGBackRest.Repos {
+		if repo.Catalog == nil {
+			continue
+		}
+		var latest time.Time
+		for _, backup := range []*v1beta1.RepoBackup{
+			repo.Catalog.LatestFull, repo.Catalog.LatestDifferential, repo.Catalog.LatestIncremental,
+		} {
+			if backup != nil && backup.StopTime != nil && backup.StopTime.After(latest) {
+				latest = backup.StopTime.Time
+			}
+		}
+		if !latest.IsZero() {
+			backupLastSuccessTimestamp.With(withLabels(labels, prometheus.Labels{"repo": repo.Name})).
+				Set(float64(latest.Unix()))
+		}
+	}
+
+	// Jobs come and go, so count only those in the status now.
+	scheduledBackupJobs.DeletePartialMatch(labels)
+
+	latest := map[[2]string]v1beta1.PGBackRestScheduledBackupStatus{}
+	for _, job := range cluster.Status.PGBackRest.ScheduledBackups {
+		outcome := "active"
+		switch {
+		case job.Succeeded > 0:
+			outcome = "succeeded"
+		case job.Failed > 0 && job.Active == 0:
+			outcome = "failed"
+		}
+		scheduledBackupJobs.With(withLabels(labels, prometheus.Labels{
+			"repo": job.RepoName, "type": job.Type, "outcome": outcome,
+		})).Inc()
+
+		key := [2]string{job.RepoName, job.Type}
+		if job.Succeeded > 0 && job.StartTime != nil && job.CompletionTime != nil {
+			if previous, ok := latest[key]; !ok || job.CompletionTime.After(previous.CompletionTime.Time) {
+				latest[key] = job
+			}
+		}
+	}
+	for key, job := range latest {
+		scheduledBackupDurationSeconds.With(withLabels(labels, prometheus.Labels{"repo": key[0], "type": key[1]})).
+			Set(job.CompletionTime.Sub(job.StartTime.Time).Seconds())
+	}
+}
+
+// forgetRepoMetrics removes the repo metrics that match labels but are not
+// about one of keep.
+func forgetRepoMetrics(labels prometheus.Labels, keep sets.Set[string]) {
+	for _, metric := range repoMetrics {
+		forget := sets.New[string]()
+
+		// Gather everything before deleting; [prometheus.Collector.Collect]
+		// holds a lock that delete needs.
+		collected := make(chan prometheus.Metric)
+		go func() { metric.Collect(collected); close(collected) }()
+
+		for m := range collected {
+			var written dto.Metric
+			if m.Write(&written) != nil {
+				continue
+			}
+			values := make(map[string]string, len(written.GetLabel()))
+			for _, pair := range written.GetLabel() {
+				values[pair.GetName()] = pair.GetValue()
+			}
+			if values["namespace"] == labels["namespace"] &&
+				values["cluster"] == labels["cluster"] && !keep.Has(values["repo"]) {
+				forget.Insert(values["repo"])
+			}
+		}
+
+		for repo := range forget {
+			metric.DeletePartialMatch(withLabels(labels, prometheus.Labels{"repo": repo}))
+		}
+	}
+}
+
+// clusterPhaseOf summarizes the status of cluster in one word.
+func clusterPhaseOf(cluster *v1beta1.PostgresCluster) string {
+	switch {
+	case initialize.FromPointer(cluster.Spec.Paused):
+		return "Paused"
+	case initialize.FromPointer(cluster.Spec.Shutdown):
+		return "Shutdown"
+	case len(cluster.Status.InstanceSets) != len(cluster.Spec.InstanceSets):
+		return "Progressing"
+	}
+
+	for _, set := range cluster.Status.InstanceSets {
+		if set.Replicas == 0 || set.ReadyReplicas != set.Replicas || set.UpdatedReplicas != set.Replicas {
+			return "Progressing"
+		}
+	}
+	return "Ready"
+}
+
+// recordReconcileError counts err against step of cluster, when it is not nil.
+// It returns err.
+func recordReconcileError(cluster *v1beta1.PostgresCluster, step string, err error) error {
+	if err != nil {
+		reconcileErrorsTotal.With(prometheus.Labels{
+			"namespace": cluster.Namespace, "cluster": cluster.Name, "step": step,
+		}).Inc()
+	}
+	return err
+}
+
+// recordCertificateExpiry updates the expiration of the certificate in secret.
+func recordCertificateExpiry(
+	cluster *v1beta1.PostgresCluster, secret string, certificate pki.Certificate,
+) {
+	if expires := certificate.NotAfter(); !expires.IsZero() {
+		certificateExpirationTimestamp.With(prometheus.Labels{
+			"namespace": cluster.Namespace, "cluster": cluster.Name, "secret": secret,
+		}).Set(float64(expires.Unix()))
+	}
+}
+
+// forgetCertificateExpiry removes the expiration of the certificate in secret.
+func forgetCertificateExpiry(cluster *v1beta1.PostgresCluster, secret string) {
+	certificateExpirationTimestamp.Delete(prometheus.Labels{
+		"namespace": cluster.Namespace, "cluster": cluster.Name, "secret": secret,
+	})
+}
+
+// recordVolumeAutoGrow counts a volume expansion requested for name in cluster.
+func recordVolumeAutoGrow(cluster *v1beta1.PostgresCluster, volume, name string) {
+	volumeAutoGrowTotal.With(prometheus.Labels{
+		"namespace": cluster.Namespace, "cluster": cluster.Name, "volume": volume, "name": name,
+	}).Inc()
+}
+
+// withLabels returns a copy of a with the labels of b.
+func withLabels(a, b prometheus.Labels) prometheus.Labels {
+	out := maps.Clone(a)
+	maps.Copy(out, b)
+	return out
+}
+
+// recordVerifyMetrics updates the verification metrics of repo in cluster.
+func recordVerifyMetrics(
+	cluster *v1beta1.PostgresCluster, repo string, status *v1beta1.RepoVerifyStatus,
+) {
+	labels := prometheus.Labels{
+		"namespace": cluster.Namespace, "cluster": cluster.Name, "repo": repo,
+	}
+
+	if status.Duration != nil {
+		verifyDurationSeconds.With(labels).Set(status.Duration.Seconds())
+	}
+	if status.Succeeded {
+		verifySucceeded.With(labels).Set(1)
+		if status.CompletionTime != nil {
+			verifyLastSuccessTimestamp.With(labels).Set(float64(status.CompletionTime.Unix()))
+		}
+	} else {
+		verifySucceeded.With(labels).Set(0)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestClusterPhaseOf(t *testing.T) {
+	cluster := v1beta1.NewPostgresCluster()
+	require.UnmarshalInto(t, &cluster.Spec, `{
+		instances: [{ name: "00", replicas: 2 }],
+	}`)
+
+	assert.Equal(t, clusterPhaseOf(cluster), "Progressing", "expected no status")
+
+	cluster.Status.InstanceSets = []v1beta1.PostgresInstanceSetStatus{
+		{Name: "00", Replicas: 2, ReadyReplicas: 1, UpdatedReplicas: 2},
+	}
+	assert.Equal(t, clusterPhaseOf(cluster), "Progressing", "expected one not ready")
+
+	cluster.Status.InstanceSets[0].ReadyReplicas = 2
+	assert.Equal(t, clusterPhaseOf(cluster), "Ready")
+
+	cluster.Spec.Shutdown = initialize.Bool(true)
+	assert.Equal(t, clusterPhaseOf(cluster), "Shutdown")
+
+	cluster.Spec.Paused = initialize.Bool(true)
+	assert.Equal(t, clusterPhaseOf(cluster), "Paused")
+}
+
+func TestRecordClusterMetrics(t *testing.T) {
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace, cluster.Name = "ns1", "metrics"
+	cluster.Spec.InstanceSets = []v1beta1.PostgresInstanceSetSpec{{Name: "00"}}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{Name: "repo1"}}
+	t.Cleanup(func() { forgetClusterMetrics(cluster) })
+
+	labels := func(extra ...string) prometheus.Labels {
+		out := prometheus.Labels{"namespace": "ns1", "cluster": "metrics"}
+		for i := 0; i < len(extra); i += 2 {
+			out[extra[i]] = extra[i+1]
+		}
+		return out
+	}
+
+	start := metav1.NewTime(time.Unix(1700000000, 0))
+	finish := metav1.NewTime(start.Add(90 * time.Second))
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{{
+			Name: "repo1",
+			Catalog: &v1beta1.RepoCatalog{
+				LatestFull:        &v1beta1.RepoBackup{Label: "a", StopTime: &start},
+				LatestIncremental: &v1beta1.RepoBackup{Label: "b", StopTime: &finish},
+			},
+		}},
+		ScheduledBackups: []v1beta1.PGBackRestScheduledBackupStatus{
+			{RepoName: "repo1", Type: "full", StartTime: &start, CompletionTime: &finish, Succeeded: 1},
+			{RepoName: "repo1", Type: "full", Failed: 2},
+			{RepoName: "repo1", Type: "incr", Active: 1, Failed: 1},
+		},
+	}
+
+	recordClusterMetrics(cluster)
+
+	assert.Equal(t, testutil.ToFloat64(clusterPhase.With(labels("phase", "Progressing"))), 1.0)
+	assert.Equal(t, testutil.ToFloat64(clusterPhase.With(labels("phase", "Ready"))), 0.0)
+
+	assert.Equal(t, testutil.ToFloat64(backupLastSuccessTimestamp.With(labels("repo", "repo1"))),
+		float64(finish.Unix()))
+	assert.Equal(t, testutil.ToFloat64(scheduledBackupDurationSeconds.With(labels("repo", "repo1", "type", "full"))),
+		90.0)
+
+	assert.Equal(t, testutil.ToFloat64(scheduledBackupJobs.With(
+		labels("repo", "repo1", "type", "full", "outcome", "succeeded"))), 1.0)
+	assert.Equal(t, testutil.ToFloat64(scheduledBackupJobs.With(
+		labels("repo", "repo1", "type", "full", "outcome", "failed"))), 1.0)
+	assert.Equal(t, testutil.ToFloat64(scheduledBackupJobs.With(
+		labels("repo", "repo1", "type", "incr", "outcome", "active"))), 1.0)
+
+	t.Run("RemovedRepo", func(t *testing.T) {
+		recordVerifyMetrics(cluster, "repo2", &v1beta1.RepoVerifyStatus{Succeeded: true})
+		assert.Equal(t, testutil.ToFloat64(verifySucceeded.With(labels("repo", "repo2"))), 1.0)
+
+		recordClusterMetrics(cluster)
+		assert.Equal(t, testutil.CollectAndCount(verifySucceeded), 0, "expected repo2 to be gone")
+		assert.Equal(t, testutil.CollectAndCount(backupLastSuccessTimestamp), 1, "expected repo1 to remain")
+
+		other := cluster.DeepCopy()
+		other.Name = "other"
+		recordVerifyMetrics(other, "repo2", &v1beta1.RepoVerifyStatus{Succeeded: true})
+		t.Cleanup(func() { forgetClusterMetrics(other) })
+
+		recordClusterMetrics(cluster)
+		assert.Equal(t, testutil.CollectAndCount(verifySucceeded), 1, "expected other cluster to remain")
+	})
+
+	t.Run("Certificate", func(t *testing.T) {
+		certificateExpirationTimestamp.With(labels("secret", "metrics-00-abcd-certs")).Set(1)
+		assert.Equal(t, testutil.CollectAndCount(certificateExpirationTimestamp), 1)
+
+		forgetCertificateExpiry(cluster, "metrics-00-abcd-certs")
+		assert.Equal(t, testutil.CollectAndCount(certificateExpirationTimestamp), 0)
+	})
+
+	t.Run("Forget", func(t *testing.T) {
+		assert.NilError(t, recordReconcileError(cluster, "patroni", nil))
+		assert.ErrorContains(t, recordReconcileError(cluster, "patroni", errors.New("boom")), "boom")
+		assert.Equal(t, testutil.ToFloat64(reconcileErrorsTotal.With(labels("step", "patroni"))), 1.0)
+
+		forgetClusterMetrics(cluster)
+		assert.Equal(t, testutil.CollectAndCount(reconcileErrorsTotal), 0)
+		assert.Equal(t, testutil.CollectAndCount(scheduledBackupJobs), 0)
+		assert.Equal(t, testutil.CollectAndCount(clusterPhase), 0)
+	})
+}
@@ -6,14 +6,23 @@ package postgrescluster
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crunchydata/postgres-operator/internal/initialize"
@@ -52,6 +61,12 @@ func (r *Reconciler) handlePatroniRestarts(
 	const container = naming.ContainerDatabase
 	var primaryNeedsRestart, replicaNeedsRestart *Instance
 
+	// Restarts interrupt PostgreSQL connections. Wait for a maintenance window.
+	// See [Reconciler.reconcilePendingMaintenance].
+	if !maintenanceAllowed(cluster) {
+		return nil
+	}
+
 	// Look for one primary and one replica that need to restart. Ignore
 	// containers that are terminating or not running; Kubernetes will start
 	// them again, and calls to their Patroni API will likely be interrupted anyway.
@@ -175,6 +190,9 @@ func (r *Reconciler) reconcilePatroniDynamicConfiguration(
 	ctx context.Context, cluster *v1beta1.PostgresCluster, instances *observedInstances,
 	pgHBAs *postgres.OrderedHBAs, pgParameters *postgres.ParameterSet,
 ) error {
+	r.validateInstanceSets(cluster)
+	r.validateSynchronousReplication(cluster)
+
 	if !patroni.ClusterBootstrapped(cluster) {
 		// Patroni has not yet bootstrapped. Dynamic configuration happens through
 		// configuration files during bootstrap, so there's nothing to do here.
@@ -343,23 +361,478 @@ func (r *Reconciler) reconcilePatroniStatus(
 		}
 	}
 
+	// Patroni writes the names of its synchronous standbys to DCS as a
+	// comma-separated list. It removes them when synchronous mode is off.
+	// - https://patroni.readthedocs.io/en/latest/replication_modes.html
+	sync := &corev1.Endpoints{ObjectMeta: naming.PatroniSynchronousState(cluster)}
+	if err == nil {
+		err = errors.WithStack(client.IgnoreNotFound(
+			r.Reader.Get(ctx, client.ObjectKeyFromObject(sync), sync)))
+	}
+	if err == nil {
+		cluster.Status.Patroni.SynchronousStandbys = nil
+
+		for name := range strings.SplitSeq(sync.Annotations["sync_standby"], ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				cluster.Status.Patroni.SynchronousStandbys = append(
+					cluster.Status.Patroni.SynchronousStandbys, name)
+			}
+		}
+		slices.Sort(cluster.Status.Patroni.SynchronousStandbys)
+	}
+
+	if err == nil {
+		members := observePatroniMembers(observedInstances)
+		previous := cluster.Status.Patroni
+		now := metav1.Now()
+
+		// Locations in the write-ahead log change with every write to PostgreSQL.
+		// Keep the previous locations for a while so that status is not written
+		// constantly, unless something else about the members changed.
+		observed := now
+		if previous.MembersObservedTime != nil &&
+			now.Sub(previous.MembersObservedTime.Time) < patroniMembersInterval &&
+			samePatroniMembers(previous.Members, members) {
+			observed = *previous.MembersObservedTime
+			for i := range members {
+				index := slices.IndexFunc(previous.Members,
+					func(m v1beta1.PatroniMemberStatus) bool { return m.Name == members[i].Name })
+				members[i].ReceivedLSN = previous.Members[index].ReceivedLSN
+				members[i].ReplayedLSN = previous.Members[index].ReplayedLSN
+				members[i].LagBytes = previous.Members[index].LagBytes
+				members[i].LagSeconds = previous.Members[index].LagSeconds
+			}
+		} else {
+			cluster.Status.Patroni.MembersObservedTime = &now
+		}
+
+		cluster.Status.Patroni.Members = members
+
+		// Locations change without any change to Pods, so observe them again
+		// when those in status are due.
+		if len(members) > 0 {
+			if due := observed.Add(patroniMembersInterval).Sub(now.Time); requeue == 0 || due < requeue {
+				requeue = due
+			}
+		}
+	}
+
 	return requeue, err
 }
 
+// patroniMembersInterval is how long the write-ahead log locations of Patroni
+// members are kept in status before they are observed again.
+const patroniMembersInterval = time.Minute
+
+// samePatroniMembers returns true when a and b describe the same members that
+// Patroni reported on, ignoring their write-ahead log locations and lag.
+func samePatroniMembers(a, b []v1beta1.PatroniMemberStatus) bool {
+	strip := func(in []v1beta1.PatroniMemberStatus) []v1beta1.PatroniMemberStatus {
+		out := make([]v1beta1.PatroniMemberStatus, 0, len(in))
+		for _, m := range in {
+			// Members without a role were added by [Reconciler.reconcileReplicaLag].
+			if m.Role != "" {
+				m.ReceivedLSN, m.ReplayedLSN, m.LagBytes, m.LagSeconds, m.Lagging = "", "", nil, nil, nil
+				out = append(out, m)
+			}
+		}
+		return out
+	}
+	return equality.Semantic.DeepEqual(strip(a), strip(b))
+}
+
+// patroniMemberState is what Patroni writes to the "status" annotation of
+// each member's Pod.
+//
+// TODO(cbandy): This works only when using Kubernetes for DCS.
+//
+// - https://github.com/zalando/patroni/blob/v4.0.0/patroni/ha.py#L421
+type patroniMemberState struct {
+	Role           string `json:"role"`
+	State          string `json:"state"`
+	Timeline       *int64 `json:"timeline"`
+	XLogLocation   *int64 `json:"xlog_location"`
+	ReceiveLSN     *int64 `json:"receive_lsn"`
+	ReplayLSN      *int64 `json:"replay_lsn"`
+	PendingRestart bool   `json:"pending_restart"`
+}
+
+// patroniMemberStateOf returns what Patroni reported about instance and
+// whether or not it reported anything.
+func patroniMemberStateOf(instance *Instance) (patroniMemberState, bool) {
+	var m patroniMemberState
+	if len(instance.Pods) != 1 ||
+		json.Unmarshal([]byte(instance.Pods[0].Annotations["status"]), &m) != nil {
+		return m, false
+	}
+	if m.Role == "master" {
+		m.Role = "primary"
+	}
+	return m, true
+}
+
+// leader returns true when m is the leader of its Patroni cluster.
+func (m patroniMemberState) leader() bool {
+	return m.Role == "primary" || m.Role == "standby_leader"
+}
+
+// observePatroniMembers returns the status of every observed instance that
+// Patroni has reported on, sorted by name.
+func observePatroniMembers(observed *observedInstances) []v1beta1.PatroniMemberStatus {
+	instances := make([]*Instance, 0, len(observed.forCluster))
+	members := make([]patroniMemberState, 0, len(observed.forCluster))
+
+	// Lag is measured from the WAL position of the leader to the position
+	// each replica has replayed.
+	var leader *int64
+	for _, instance := range observed.forCluster {
+		m, ok := patroniMemberStateOf(instance)
+		if !ok {
+			continue
+		}
+		if m.leader() {
+			leader = m.XLogLocation
+		}
+		instances = append(instances, instance)
+		members = append(members, m)
+	}
+
+	var statuses []v1beta1.PatroniMemberStatus
+	for i, m := range members {
+		pod := instances[i].Pods[0]
+		status := v1beta1.PatroniMemberStatus{
+			Name:            instances[i].Name,
+			Pod:             pod.Name,
+			Role:            m.Role,
+			State:           m.State,
+			Timeline:        m.Timeline,
+			PendingRestart:  initialize.Bool(m.PendingRestart),
+			PostgresVersion: postgresVersionOf(pod),
+		}
+
+		if m.leader() {
+			status.ReplayedLSN = formatLSN(m.XLogLocation)
+		} else {
+			status.ReceivedLSN = formatLSN(m.ReceiveLSN)
+			status.ReplayedLSN = formatLSN(m.ReplayLSN)
+
+			if leader != nil && m.ReplayLSN != nil {
+				status.LagBytes = initialize.Int64(max(0, *leader-*m.ReplayLSN))
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	slices.SortFunc(statuses, func(a, b v1beta1.PatroniMemberStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return statuses
+}
+
+// postgresVersionOf returns the major version of PostgreSQL that runs in pod,
+// according to the data directory of its database container. It returns zero
+// when that cannot be determined. See [postgres.DataDirectory].
+func postgresVersionOf(pod *corev1.Pod) int32 {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != naming.ContainerDatabase {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.Name != "PGDATA" {
+				continue
+			}
+			if v, ok := strings.CutPrefix(path.Base(env.Value), "pg"); ok {
+				if version, err := strconv.ParseInt(v, 10, 32); err == nil {
+					return int32(version)
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// formatLSN returns lsn in the text format of PostgreSQL's pg_lsn type, or
+// empty string when lsn is nil.
+func formatLSN(lsn *int64) string {
+	if lsn == nil {
+		return ""
+	}
+	return fmt.Sprintf("%X/%X", uint64(*lsn)>>32, uint32(*lsn))
+}
+
+// +kubebuilder:rbac:groups="",resources="pods",verbs={patch}
+// +kubebuilder:rbac:groups="",resources="pods/exec",verbs={create}
+
+// reconcileReplicaLag labels the instance Pods of cluster with whether or not
+// they are too far behind the primary to receive traffic from the replica
+// Service. It asks the Patroni API of each instance where it is in the
+// write-ahead log, records which instances are lagging in
+// cluster.Status.Patroni.Members, and returns how long until lag should be
+// checked again.
+func (r *Reconciler) reconcileReplicaLag(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	observedInstances *observedInstances,
+) (time.Duration, error) {
+	if !limitsReplicaLag(cluster) {
+		return 0, nil
+	}
+
+	// Replication lag changes without any change to Kubernetes objects, so
+	// check it periodically.
+	const interval = 30 * time.Second
+	log := logging.FromContext(ctx)
+	now := time.Now()
+
+	// Patroni answers for its own member only, so ask every running instance.
+	// Instances that cannot answer have unknown lag.
+	var leader *int64
+	members := make(map[string]patroni.MemberStatus, len(observedInstances.forCluster))
+	for _, instance := range observedInstances.forCluster {
+		if running, known := instance.IsRunning(naming.ContainerDatabase); !running ||
+			!known || len(instance.Pods) != 1 {
+			continue
+		}
+		pod := instance.Pods[0]
+		exec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			return r.PodExec(ctx, pod.Namespace, pod.Name, naming.ContainerDatabase, stdin,
+				stdout, stderr, command...)
+		}
+
+		member, err := patroni.Executor(exec).GetMemberStatus(ctx)
+		if err != nil {
+			log.V(1).Info("unable to read replication status", "pod", pod.Name, "error", err.Error())
+			continue
+		}
+		members[instance.Name] = member
+		if member.Leader() {
+			leader = member.Location
+		}
+	}
+
+	for _, instance := range observedInstances.forCluster {
+		if len(instance.Pods) != 1 {
+			continue
+		}
+		pod := instance.Pods[0]
+		member, found := members[instance.Name]
+		lag := replicaLagOf(now, leader, member)
+
+		// Replicas with unknown lag are lagging. The leader never lags.
+		lagging := !found || (!member.Leader() && lag.exceeds(cluster.Spec.ReplicaRouting))
+
+		// Add to the status observed by [Reconciler.reconcilePatroniStatus].
+		index := slices.IndexFunc(cluster.Status.Patroni.Members,
+			func(status v1beta1.PatroniMemberStatus) bool { return status.Name == instance.Name })
+		if index < 0 {
+			index = len(cluster.Status.Patroni.Members)
+			cluster.Status.Patroni.Members = append(cluster.Status.Patroni.Members,
+				v1beta1.PatroniMemberStatus{Name: instance.Name, Pod: pod.Name})
+		}
+		status := &cluster.Status.Patroni.Members[index]
+		status.Lagging = initialize.Bool(lagging)
+		if status.LagSeconds == nil && lag.time != nil &&
+			cluster.Spec.ReplicaRouting.MaxLagTime != nil {
+			status.LagSeconds = initialize.Int64(int64(lag.time.Seconds()))
+		}
+
+		// Pods without this label receive no traffic until it is set here.
+		if value := strconv.FormatBool(lagging); pod.Labels[naming.LabelLagging] != value {
+			patch := client.RawPatch(client.Merge.Type(), []byte(fmt.Sprintf(
+				`{"metadata":{"labels":{%q:%q}}}`, naming.LabelLagging, value)))
+
+			err := errors.WithStack(client.IgnoreNotFound(r.Writer.Patch(ctx, pod, patch)))
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	slices.SortFunc(cluster.Status.Patroni.Members, func(a, b v1beta1.PatroniMemberStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return interval, nil
+}
+
+// replicaLag is how far a replica is behind the leader of its Patroni cluster.
+// Each field is nil when it is not known.
+type replicaLag struct {
+	bytes *int64
+	time  *time.Duration
+}
+
+// replicaLagOf calculates how far member is behind the leader at the WAL
+// location leader. A replica that has replayed everything the leader has
+// written is not behind, no matter how long ago that was.
+func replicaLagOf(now time.Time, leader *int64, member patroni.MemberStatus) replicaLag {
+	var lag replicaLag
+	if leader != nil && member.ReplayedLocation != nil {
+		lag.bytes = initialize.Int64(max(0, *leader-*member.ReplayedLocation))
+	}
+	switch {
+	case lag.bytes != nil && *lag.bytes == 0:
+		lag.time = initialize.Pointer(time.Duration(0))
+	case member.ReplayedTimestamp != nil:
+		lag.time = initialize.Pointer(max(0, now.Sub(*member.ReplayedTimestamp)))
+	}
+	return lag
+}
+
+// exceeds returns true when lag is beyond either limit in spec or unknown.
+func (lag replicaLag) exceeds(spec *v1beta1.ReplicaRoutingSpec) bool {
+	if spec.MaxLag != nil && (lag.bytes == nil || *lag.bytes > spec.MaxLag.Value()) {
+		return true
+	}
+	if spec.MaxLagTime != nil &&
+		(lag.time == nil || *lag.time > spec.MaxLagTime.AsDuration().Duration) {
+		return true
+	}
+	return false
+}
+
+// validateSynchronousReplication emits warnings when the synchronous standbys
+// in cluster cannot be satisfied by its instances. NOTE(validation)
+func (r *Reconciler) validateSynchronousReplication(cluster *v1beta1.PostgresCluster) {
+	if cluster.Spec.Patroni == nil || !cluster.Spec.Patroni.Synchronous.Enabled() {
+		return
+	}
+
+	errs := field.ErrorList{}
+	path := field.NewPath("spec", "patroni", "synchronous")
+	sync := cluster.Spec.Patroni.Synchronous
+
+	var eligible int32
+	for _, name := range sync.InstanceSets {
+		if !slices.ContainsFunc(cluster.Spec.InstanceSets,
+			func(set v1beta1.PostgresInstanceSetSpec) bool { return set.Name == name },
+		) {
+			errs = append(errs, field.NotFound(path.Child("instanceSets"), name))
+		}
+	}
+	for _, set := range cluster.Spec.InstanceSets {
+		if sync.Eligible(set.Name) && set.ReplicationDelay == nil &&
+			(set.Patroni == nil || set.Patroni.Tags == nil || !set.Patroni.Tags.NoSync) {
+			eligible += max(1, initialize.FromPointer(set.Replicas))
+		}
+	}
+
+	// When every instance is eligible, one of them is the primary.
+	standbys := max(1, initialize.FromPointer(sync.Standbys))
+	if len(sync.InstanceSets) == 0 {
+		eligible--
+	}
+	if eligible < standbys {
+		errs = append(errs, field.Invalid(path.Child("standbys"), standbys,
+			fmt.Sprintf("only %d eligible replicas", max(0, eligible))))
+	}
+
+	if len(errs) > 0 {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "InvalidSynchronousReplication",
+			errs.ToAggregate().Error())
+	}
+}
+
+// validateInstanceSets emits warnings when no instance set can become the
+// primary or when a "replicateFrom" tag does not name another instance set or
+// forms a cycle. These are also reported in a condition that is removed once
+// the instance sets are valid.
+func (r *Reconciler) validateInstanceSets(cluster *v1beta1.PostgresCluster) {
+	errs := field.ErrorList{}
+	path := field.NewPath("spec", "instances")
+
+	// Delayed instances are never promoted.
+	if !slices.ContainsFunc(cluster.Spec.InstanceSets,
+		func(set v1beta1.PostgresInstanceSetSpec) bool {
+			if set.Patroni != nil && set.Patroni.Tags != nil {
+				tags := set.Patroni.Tags
+				if tags.NoFailover || (tags.FailoverPriority != nil && *tags.FailoverPriority == 0) {
+					return false
+				}
+			}
+			return set.ReplicationDelay == nil
+		},
+	) {
+		errs = append(errs, field.Invalid(path, len(cluster.Spec.InstanceSets),
+			"at least one instance set must be able to become primary"))
+	}
+
+	for i, set := range cluster.Spec.InstanceSets {
+		if set.Patroni == nil || set.Patroni.Tags == nil || set.Patroni.Tags.ReplicateFrom == "" {
+			continue
+		}
+		if from := set.Patroni.Tags.ReplicateFrom; from == set.Name ||
+			!slices.ContainsFunc(cluster.Spec.InstanceSets,
+				func(set v1beta1.PostgresInstanceSetSpec) bool { return set.Name == from },
+			) {
+			errs = append(errs, field.Invalid(
+				path.Index(i).Child("patroni", "tags", "replicateFrom"), from,
+				"must be the name of another instance set"))
+		} else if replicationLoops(cluster, &set) {
+			errs = append(errs, field.Invalid(
+				path.Index(i).Child("patroni", "tags", "replicateFrom"), from,
+				"must not form a cycle"))
+		}
+	}
+
+	if len(errs) == 0 {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, v1beta1.InstanceSetsValid)
+		return
+	}
+
+	r.Recorder.Event(cluster, corev1.EventTypeWarning, "InvalidInstanceSets",
+		errs.ToAggregate().Error())
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:    v1beta1.InstanceSetsValid,
+		Status:  metav1.ConditionFalse,
+		Reason:  "InvalidInstanceSets",
+		Message: errs.ToAggregate().Error(),
+
+		ObservedGeneration: cluster.GetGeneration(),
+	})
+}
+
+// replicationLoops returns true when following the "replicateFrom" tags of
+// cluster from set never reaches an instance set that replicates from the
+// primary.
+func replicationLoops(cluster *v1beta1.PostgresCluster, set *v1beta1.PostgresInstanceSetSpec) bool {
+	from := make(map[string]string, len(cluster.Spec.InstanceSets))
+	for _, set := range cluster.Spec.InstanceSets {
+		if set.Patroni != nil && set.Patroni.Tags != nil {
+			from[set.Name] = set.Patroni.Tags.ReplicateFrom
+		}
+	}
+
+	seen := sets.New(set.Name)
+	for name := from[set.Name]; name != ""; name = from[name] {
+		if seen.Has(name) {
+			return true
+		}
+		seen.Insert(name)
+	}
+	return false
+}
+
 // reconcileReplicationSecret creates a secret containing the TLS
 // certificate, key and CA certificate for use with the replication and
-// pg_rewind accounts in Postgres.
+// pg_rewind accounts in Postgres. The issued projection, when not nil, is
+// used unless there is a custom secret.
 // TODO: As part of future work we will use this secret to setup a superuser
 // account and enable cert authentication for that user
 func (r *Reconciler) reconcileReplicationSecret(
 	ctx context.Context, cluster *v1beta1.PostgresCluster,
-	root *pki.RootCertificateAuthority,
+	root *pki.RootCertificateAuthority, issued *corev1.SecretProjection,
 ) (*corev1.Secret, error) {
+	projection := cluster.Spec.CustomReplicationClientTLSSecret
+	if projection == nil {
+		projection = issued
+	}
 
-	// if a custom postgrescluster secret is provided, just return it
-	if cluster.Spec.CustomReplicationClientTLSSecret != nil {
+	// if a custom or issued secret is provided, just return it
+	if projection != nil {
 		custom := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
-			Name:      cluster.Spec.CustomReplicationClientTLSSecret.Name,
+			Name:      projection.Name,
 			Namespace: cluster.Namespace,
 		}}
 		err := errors.WithStack(r.Reader.Get(ctx,
@@ -412,12 +885,15 @@ func (r *Reconciler) reconcileReplicationSecret(
 		err = errors.WithStack(err)
 	}
 	if err == nil {
-		intent.Data[naming.ReplicationCACert], err = root.Certificate.MarshalText()
+		intent.Data[naming.ReplicationCACert], err = root.Bundle().MarshalText()
 		err = errors.WithStack(err)
 	}
 	if err == nil {
 		err = errors.WithStack(r.apply(ctx, intent))
 	}
+	if err == nil {
+		recordCertificateExpiry(cluster, intent.Name, leaf.Certificate)
+	}
 	return intent, err
 }
 
@@ -19,14 +19,20 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
 	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/patroni"
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/events"
 	"github.com/crunchydata/postgres-operator/internal/testing/require"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
@@ -342,7 +348,7 @@ func TestPatroniReplicationSecret(t *testing.T) {
 	assert.NilError(t, err)
 
 	t.Run("reconcile", func(t *testing.T) {
-		_, err = r.reconcileReplicationSecret(ctx, postgresCluster, rootCA)
+		_, err = r.reconcileReplicationSecret(ctx, postgresCluster, rootCA, nil)
 		assert.NilError(t, err)
 	})
 
@@ -407,7 +413,7 @@ func TestPatroniReplicationSecret(t *testing.T) {
 		rootCA, err := r.reconcileRootCertificate(ctx, postgresCluster)
 		assert.NilError(t, err)
 
-		testReplicationSecret, err := r.reconcileReplicationSecret(ctx, postgresCluster, rootCA)
+		testReplicationSecret, err := r.reconcileReplicationSecret(ctx, postgresCluster, rootCA, nil)
 		assert.NilError(t, err)
 
 		t.Run("check standard secret projection", func(t *testing.T) {
@@ -524,6 +530,614 @@ func TestReconcilePatroniStatus(t *testing.T) {
 	}
 }
 
+func TestReconcilePatroniStatusSynchronous(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace = "ns1"
+	cluster.Name = "pg2"
+	cluster.Status.Patroni.SynchronousStandbys = []string{"previous"}
+
+	t.Run("NotFound", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		reconciler := &Reconciler{
+			Reader: fake.NewClientBuilder().WithScheme(runtime.Scheme).Build(),
+		}
+
+		_, err := reconciler.reconcilePatroniStatus(ctx, cluster, new(observedInstances))
+		assert.NilError(t, err)
+		assert.Assert(t, cluster.Status.Patroni.SynchronousStandbys == nil)
+	})
+
+	t.Run("Standbys", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		sync := &corev1.Endpoints{ObjectMeta: naming.PatroniSynchronousState(cluster)}
+		sync.Annotations = map[string]string{
+			"leader":       "pg2-one-abcd-0",
+			"sync_standby": "pg2-two-wxyz-0,pg2-one-efgh-0",
+		}
+
+		reconciler := &Reconciler{
+			Reader: fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(sync).Build(),
+		}
+
+		_, err := reconciler.reconcilePatroniStatus(ctx, cluster, new(observedInstances))
+		assert.NilError(t, err)
+		assert.DeepEqual(t, cluster.Status.Patroni.SynchronousStandbys,
+			[]string{"pg2-one-efgh-0", "pg2-two-wxyz-0"})
+	})
+}
+
+func TestReconcilePatroniStatusMembers(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace = "ns1"
+	cluster.Name = "pg2"
+	cluster.Spec.PostgresVersion = 17
+	cluster.Status.Patroni.Members = []v1beta1.PatroniMemberStatus{{Name: "previous"}}
+
+	reconciler := &Reconciler{
+		Reader: fake.NewClientBuilder().WithScheme(runtime.Scheme).Build(),
+	}
+
+	pod := func(name, status string) []*corev1.Pod {
+		pod := &corev1.Pod{}
+		pod.Name = name
+		pod.Annotations = map[string]string{"status": status}
+		pod.Spec.Containers = []corev1.Container{{
+			Name: naming.ContainerDatabase,
+			Env:  []corev1.EnvVar{{Name: "PGDATA", Value: "/pgdata/pg17"}},
+		}}
+		return []*corev1.Pod{pod}
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+
+		_, err := reconciler.reconcilePatroniStatus(ctx, cluster, new(observedInstances))
+		assert.NilError(t, err)
+		assert.Assert(t, cluster.Status.Patroni.Members == nil)
+	})
+
+	t.Run("Members", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		instances := &observedInstances{forCluster: []*Instance{
+			{Name: "pg2-00-mnop", Pods: pod("pg2-00-mnop-0",
+				`{"role":"replica","state":"running","timeline":3,"xlog_location":83886080,"pending_restart":true}`)},
+			{Name: "pg2-00-efgh", Pods: pod("pg2-00-efgh-0",
+				`{"role":"master","state":"running","timeline":4,"xlog_location":4311744512}`)},
+			{Name: "pg2-00-abcd", Pods: pod("pg2-00-abcd-0",
+				`{"role":"replica","state":"running","timeline":4,"xlog_location":4311744000,"receive_lsn":4311744000,"replay_lsn":4311743000}`)},
+			{Name: "pg2-00-ijkl", Pods: pod("pg2-00-ijkl-0", `not json`)},
+			{Name: "pg2-00-qrst"},
+		}}
+
+		// The version comes from each Pod rather than the cluster spec.
+		instances.forCluster[0].Pods[0].Spec.Containers[0].Env[0].Value = "/pgdata/pg16"
+
+		requeue, err := reconciler.reconcilePatroniStatus(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, patroniMembersInterval)
+		assert.Assert(t, cmp.MarshalMatches(cluster.Status.Patroni.Members, `
+- lagBytes: 1512
+  name: pg2-00-abcd
+  pendingRestart: false
+  pod: pg2-00-abcd-0
+  postgresVersion: 17
+  receivedLSN: 1/FFFE00
+  replayedLSN: 1/FFFA18
+  role: replica
+  state: running
+  timeline: 4
+- name: pg2-00-efgh
+  pendingRestart: false
+  pod: pg2-00-efgh-0
+  postgresVersion: 17
+  replayedLSN: 1/1000000
+  role: primary
+  state: running
+  timeline: 4
+- name: pg2-00-mnop
+  pendingRestart: true
+  pod: pg2-00-mnop-0
+  postgresVersion: 16
+  role: replica
+  state: running
+  timeline: 3
+		`))
+	})
+
+	t.Run("Interval", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		status := func(role string, location int) []*corev1.Pod {
+			return pod("pg2-00-abcd-0", fmt.Sprintf(
+				`{"role":%q,"state":"running","timeline":4,"xlog_location":%d}`, role, location))
+		}
+
+		_, err := reconciler.reconcilePatroniStatus(ctx, cluster, &observedInstances{
+			forCluster: []*Instance{{Name: "pg2-00-abcd", Pods: status("master", 100)}},
+		})
+		assert.NilError(t, err)
+		assert.Assert(t, cluster.Status.Patroni.MembersObservedTime != nil)
+		assert.Equal(t, cluster.Status.Patroni.Members[0].ReplayedLSN, "0/64")
+		observed := cluster.Status.Patroni.MembersObservedTime
+
+		// Only the location changed, so the previous location is kept until
+		// the interval passes.
+		requeue, err := reconciler.reconcilePatroniStatus(ctx, cluster, &observedInstances{
+			forCluster: []*Instance{{Name: "pg2-00-abcd", Pods: status("master", 200)}},
+		})
+		assert.NilError(t, err)
+		assert.Assert(t, requeue > 0 && requeue <= patroniMembersInterval, "got %v", requeue)
+		assert.Equal(t, cluster.Status.Patroni.MembersObservedTime, observed)
+		assert.Equal(t, cluster.Status.Patroni.Members[0].ReplayedLSN, "0/64")
+
+		// The role changed, so the location is observed.
+		_, err = reconciler.reconcilePatroniStatus(ctx, cluster, &observedInstances{
+			forCluster: []*Instance{{Name: "pg2-00-abcd", Pods: status("replica", 300)}},
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, cluster.Status.Patroni.Members[0].ReplayedLSN, "")
+
+		// The interval passed, so the location is observed.
+		cluster.Status.Patroni.MembersObservedTime = &metav1.Time{
+			Time: time.Now().Add(-patroniMembersInterval),
+		}
+		_, err = reconciler.reconcilePatroniStatus(ctx, cluster, &observedInstances{
+			forCluster: []*Instance{{Name: "pg2-00-abcd", Pods: status("master", 400)}},
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, cluster.Status.Patroni.Members[0].ReplayedLSN, "0/190")
+		assert.Assert(t, cluster.Status.Patroni.MembersObservedTime.After(
+			time.Now().Add(-time.Minute/2)))
+	})
+}
+
+func TestReconcileReplicaLag(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace = "ns1"
+	cluster.Name = "pg2"
+	cluster.Status.Patroni.Members = []v1beta1.PatroniMemberStatus{{Name: "previous"}}
+
+	pod := func(name, lagging string, running bool) *corev1.Pod {
+		pod := &corev1.Pod{}
+		pod.Namespace, pod.Name = "ns1", name
+		pod.Labels = map[string]string{}
+		if lagging != "" {
+			pod.Labels[naming.LabelLagging] = lagging
+		}
+		status := corev1.ContainerStatus{Name: naming.ContainerDatabase}
+		if running {
+			status.State.Running = new(corev1.ContainerStateRunning)
+		}
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{status}
+		return pod
+	}
+	pods := []*corev1.Pod{
+		pod("pg2-00-abcd-0", "", true),
+		pod("pg2-00-efgh-0", "", true),
+		pod("pg2-00-ijkl-0", "false", true),
+		pod("pg2-00-mnop-0", "false", true),
+		pod("pg2-00-qrst-0", "", false),
+	}
+	instances := &observedInstances{forCluster: []*Instance{
+		{Name: "pg2-00-abcd", Pods: pods[0:1]},
+		{Name: "pg2-00-efgh", Pods: pods[1:2]},
+		{Name: "pg2-00-ijkl", Pods: pods[2:3]},
+		{Name: "pg2-00-mnop", Pods: pods[3:4]},
+		{Name: "pg2-00-qrst", Pods: pods[4:5]},
+		{Name: "pg2-00-uvwx"},
+	}}
+
+	// The replica "abcd" received everything but has not replayed 512 bytes
+	// of it; "ijkl" is 100MB behind and replayed a transaction an hour ago;
+	// Patroni in "mnop" does not answer.
+	recent := time.Now().Add(-time.Second).UTC().Format("2006-01-02 15:04:05.999999-07:00")
+	hourAgo := time.Now().Add(-time.Hour).UTC().Format("2006-01-02 15:04:05.999999-07:00")
+	responses := map[string]string{
+		"pg2-00-abcd-0": `{"role":"replica","state":"running","xlog":{"received_location":4311744512,"replayed_location":4311744000,"replayed_timestamp":"` + recent + `"}}`,
+		"pg2-00-efgh-0": `{"role":"primary","state":"running","xlog":{"location":4311744512}}`,
+		"pg2-00-ijkl-0": `{"role":"replica","state":"running","xlog":{"received_location":4211744512,"replayed_location":4211744512,"replayed_timestamp":"` + hourAgo + `"}}`,
+	}
+
+	reconciler := func(t *testing.T) (*Reconciler, client.Client, *[]string) {
+		var objects []client.Object
+		for _, pod := range pods {
+			objects = append(objects, pod.DeepCopy())
+		}
+		cc := fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(objects...).Build()
+
+		var called []string
+		return &Reconciler{
+			Reader: cc, Writer: cc,
+			PodExec: func(
+				ctx context.Context, namespace, pod, container string,
+				stdin io.Reader, stdout, stderr io.Writer, command ...string,
+			) error {
+				called = append(called, pod)
+				assert.Equal(t, namespace, "ns1")
+				assert.Equal(t, container, naming.ContainerDatabase)
+
+				response, ok := responses[pod]
+				if !ok {
+					return errors.New("connection refused")
+				}
+				_, _ = stdout.Write([]byte(response))
+				return nil
+			},
+		}, cc, &called
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		r, cc, called := reconciler(t)
+
+		requeue, err := r.reconcileReplicaLag(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, time.Duration(0))
+		assert.Equal(t, len(*called), 0)
+		assert.DeepEqual(t, cluster.Status.Patroni.Members,
+			[]v1beta1.PatroniMemberStatus{{Name: "previous"}})
+
+		actual := &corev1.Pod{}
+		assert.NilError(t, cc.Get(ctx, client.ObjectKeyFromObject(pods[0]), actual))
+		assert.Equal(t, len(actual.Labels), 0)
+	})
+
+	t.Run("MaxLag", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{ replicaRouting: { maxLag: 16Mi } }`)
+
+		// The lag observed by reconcilePatroniStatus is kept.
+		require.UnmarshalInto(t, &cluster.Status.Patroni, `{
+			members: [{ name: pg2-00-abcd, pod: pg2-00-abcd-0, lagBytes: 512 }],
+		}`)
+
+		r, cc, called := reconciler(t)
+
+		requeue, err := r.reconcileReplicaLag(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.Assert(t, requeue > 0, "expected to check lag again")
+
+		// Only running instances are asked.
+		assert.DeepEqual(t, *called,
+			[]string{"pg2-00-abcd-0", "pg2-00-efgh-0", "pg2-00-ijkl-0", "pg2-00-mnop-0"})
+
+		assert.Assert(t, cmp.MarshalMatches(cluster.Status.Patroni.Members, `
+- lagBytes: 512
+  lagging: false
+  name: pg2-00-abcd
+  pod: pg2-00-abcd-0
+- lagging: false
+  name: pg2-00-efgh
+  pod: pg2-00-efgh-0
+- lagging: true
+  name: pg2-00-ijkl
+  pod: pg2-00-ijkl-0
+- lagging: true
+  name: pg2-00-mnop
+  pod: pg2-00-mnop-0
+- lagging: true
+  name: pg2-00-qrst
+  pod: pg2-00-qrst-0
+		`))
+
+		for pod, expected := range map[string]string{
+			"pg2-00-abcd-0": "false",
+			"pg2-00-efgh-0": "false",
+			"pg2-00-ijkl-0": "true",
+			"pg2-00-mnop-0": "true",
+			"pg2-00-qrst-0": "true",
+		} {
+			actual := &corev1.Pod{}
+			assert.NilError(t, cc.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: pod}, actual))
+			assert.Equal(t, actual.Labels[naming.LabelLagging], expected, "pod %q", pod)
+		}
+	})
+
+	t.Run("MaxLagBytesReplayed", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{ replicaRouting: { maxLag: "256" } }`)
+
+		r, cc, _ := reconciler(t)
+
+		// The replica "abcd" received everything but is 512 bytes behind in replay.
+		_, err := r.reconcileReplicaLag(ctx, cluster, instances)
+		assert.NilError(t, err)
+
+		actual := &corev1.Pod{}
+		assert.NilError(t, cc.Get(ctx, client.ObjectKeyFromObject(pods[0]), actual))
+		assert.Equal(t, actual.Labels[naming.LabelLagging], "true")
+	})
+
+	t.Run("MaxLagTime", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{ replicaRouting: { maxLagTime: 5m } }`)
+
+		r, cc, _ := reconciler(t)
+
+		requeue, err := r.reconcileReplicaLag(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.Assert(t, requeue > 0, "expected to check lag again")
+
+		// The replica "ijkl" replayed its last transaction an hour ago.
+		for pod, expected := range map[string]string{
+			"pg2-00-abcd-0": "false",
+			"pg2-00-efgh-0": "false",
+			"pg2-00-ijkl-0": "true",
+			"pg2-00-mnop-0": "true",
+		} {
+			actual := &corev1.Pod{}
+			assert.NilError(t, cc.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: pod}, actual))
+			assert.Equal(t, actual.Labels[naming.LabelLagging], expected, "pod %q", pod)
+		}
+
+		assert.Equal(t, len(cluster.Status.Patroni.Members), 6)
+		abcd, efgh, ijkl := cluster.Status.Patroni.Members[0],
+			cluster.Status.Patroni.Members[1], cluster.Status.Patroni.Members[2]
+		assert.Equal(t, abcd.Name, "pg2-00-abcd")
+		assert.Assert(t, abcd.LagSeconds != nil && *abcd.LagSeconds < 60)
+		assert.Assert(t, efgh.LagSeconds == nil, "expected no lag on the leader")
+		assert.Assert(t, ijkl.LagSeconds != nil && *ijkl.LagSeconds >= 3600)
+	})
+
+	t.Run("NoLeader", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{ replicaRouting: { maxLag: 1Gi } }`)
+
+		r, cc, _ := reconciler(t)
+
+		// Without a leader, the lag of every replica is unknown.
+		_, err := r.reconcileReplicaLag(ctx, cluster, &observedInstances{
+			forCluster: []*Instance{{Name: "pg2-00-abcd", Pods: pods[0:1]}},
+		})
+		assert.NilError(t, err)
+
+		actual := &corev1.Pod{}
+		assert.NilError(t, cc.Get(ctx, client.ObjectKeyFromObject(pods[0]), actual))
+		assert.Equal(t, actual.Labels[naming.LabelLagging], "true")
+	})
+}
+
+func TestReplicaLagOf(t *testing.T) {
+	now := time.Date(2024, time.November, 14, 22, 13, 20, 0, time.UTC)
+
+	t.Run("Unknown", func(t *testing.T) {
+		lag := replicaLagOf(now, nil, patroni.MemberStatus{ReplayedLocation: initialize.Int64(100)})
+		assert.Assert(t, lag.bytes == nil)
+		assert.Assert(t, lag.time == nil)
+
+		assert.Assert(t, lag.exceeds(&v1beta1.ReplicaRoutingSpec{
+			MaxLag: initialize.Pointer(resource.MustParse("1Gi")),
+		}))
+		assert.Assert(t, lag.exceeds(&v1beta1.ReplicaRoutingSpec{
+			MaxLagTime: require.Value(v1beta1.NewDuration("1h")),
+		}))
+	})
+
+	t.Run("CaughtUp", func(t *testing.T) {
+		// A replica that replayed everything is not behind, even when its last
+		// transaction was long ago.
+		lag := replicaLagOf(now, initialize.Int64(100), patroni.MemberStatus{
+			ReplayedLocation:  initialize.Int64(100),
+			ReplayedTimestamp: initialize.Pointer(now.Add(-24 * time.Hour)),
+		})
+		assert.Equal(t, *lag.bytes, int64(0))
+		assert.Equal(t, *lag.time, time.Duration(0))
+		assert.Assert(t, !lag.exceeds(&v1beta1.ReplicaRoutingSpec{
+			MaxLag:     initialize.Pointer(resource.MustParse("0")),
+			MaxLagTime: require.Value(v1beta1.NewDuration("1s")),
+		}))
+	})
+
+	t.Run("Behind", func(t *testing.T) {
+		lag := replicaLagOf(now, initialize.Int64(2048), patroni.MemberStatus{
+			ReplayedLocation:  initialize.Int64(1024),
+			ReplayedTimestamp: initialize.Pointer(now.Add(-90 * time.Second)),
+		})
+		assert.Equal(t, *lag.bytes, int64(1024))
+		assert.Equal(t, *lag.time, 90*time.Second)
+
+		assert.Assert(t, !lag.exceeds(&v1beta1.ReplicaRoutingSpec{
+			MaxLag: initialize.Pointer(resource.MustParse("1Ki")),
+		}))
+		assert.Assert(t, lag.exceeds(&v1beta1.ReplicaRoutingSpec{
+			MaxLag: initialize.Pointer(resource.MustParse("1023")),
+		}))
+		assert.Assert(t, !lag.exceeds(&v1beta1.ReplicaRoutingSpec{
+			MaxLagTime: require.Value(v1beta1.NewDuration("2m")),
+		}))
+		assert.Assert(t, lag.exceeds(&v1beta1.ReplicaRoutingSpec{
+			MaxLag:     initialize.Pointer(resource.MustParse("1Ki")),
+			MaxLagTime: require.Value(v1beta1.NewDuration("1m")),
+		}))
+	})
+}
+
+func TestValidateSynchronousReplication(t *testing.T) {
+	t.Parallel()
+
+	newCluster := func(t *testing.T, spec string) *v1beta1.PostgresCluster {
+		cluster := v1beta1.NewPostgresCluster()
+		cluster.Name = "pg1"
+		require.UnmarshalInto(t, &cluster.Spec, spec)
+		return cluster
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+
+		reconciler.validateSynchronousReplication(newCluster(t, `{
+			instances: [{ name: one }],
+		}`))
+		reconciler.validateSynchronousReplication(newCluster(t, `{
+			instances: [{ name: one }],
+			patroni: { synchronous: { mode: "Off", standbys: 3 } },
+		}`))
+		assert.Equal(t, len(recorder.Events), 0)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+
+		reconciler.validateSynchronousReplication(newCluster(t, `{
+			instances: [{ name: one, replicas: 2 }],
+			patroni: { synchronous: {} },
+		}`))
+		reconciler.validateSynchronousReplication(newCluster(t, `{
+			instances: [{ name: one }, { name: two, replicas: 2 }],
+			patroni: { synchronous: { standbys: 2, instanceSets: [two] } },
+		}`))
+		assert.Equal(t, len(recorder.Events), 0)
+	})
+
+	t.Run("NotEnoughReplicas", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+
+		reconciler.validateSynchronousReplication(newCluster(t, `{
+			instances: [{ name: one, replicas: 2 }],
+			patroni: { synchronous: { mode: Strict, standbys: 2 } },
+		}`))
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "InvalidSynchronousReplication")
+		assert.Assert(t, cmp.Contains(recorder.Events[0].Note, "spec.patroni.synchronous.standbys"))
+		assert.Assert(t, cmp.Contains(recorder.Events[0].Note, "only 1 eligible replicas"))
+
+		// Delayed instances are not eligible.
+		reconciler.validateSynchronousReplication(newCluster(t, `{
+			instances: [{ name: one }, { name: two, replicas: 2, replicationDelay: 1h }],
+			patroni: { synchronous: {} },
+		}`))
+		assert.Equal(t, len(recorder.Events), 2)
+		assert.Assert(t, cmp.Contains(recorder.Events[1].Note, "only 0 eligible replicas"))
+
+		// Instances tagged "nosync" are not eligible.
+		reconciler.validateSynchronousReplication(newCluster(t, `{
+			instances: [{ name: one }, { name: two, replicas: 2, patroni: { tags: { noSync: true } } }],
+			patroni: { synchronous: {} },
+		}`))
+		assert.Equal(t, len(recorder.Events), 3)
+		assert.Assert(t, cmp.Contains(recorder.Events[2].Note, "only 0 eligible replicas"))
+	})
+
+	t.Run("MissingInstanceSet", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+
+		reconciler.validateSynchronousReplication(newCluster(t, `{
+			instances: [{ name: one, replicas: 2 }],
+			patroni: { synchronous: { instanceSets: [one, two] } },
+		}`))
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Assert(t, cmp.Contains(recorder.Events[0].Note,
+			`spec.patroni.synchronous.instanceSets: Not found: "two"`))
+	})
+}
+
+func TestValidateInstanceSets(t *testing.T) {
+	t.Parallel()
+
+	newCluster := func(t *testing.T, spec string) *v1beta1.PostgresCluster {
+		cluster := v1beta1.NewPostgresCluster()
+		cluster.Name = "pg1"
+		require.UnmarshalInto(t, &cluster.Spec, spec)
+		return cluster
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+
+		reconciler.validateInstanceSets(newCluster(t, `{
+			instances: [{ name: one }],
+		}`))
+		reconciler.validateInstanceSets(newCluster(t, `{
+			instances: [
+				{ name: one, patroni: { tags: { failoverPriority: 2 } } },
+				{ name: two, replicationDelay: 1h },
+				{ name: dr, patroni: { tags: { noFailover: true, replicateFrom: one } } },
+			],
+		}`))
+		assert.Equal(t, len(recorder.Events), 0)
+	})
+
+	t.Run("NoFailover", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+
+		reconciler.validateInstanceSets(newCluster(t, `{
+			instances: [
+				{ name: one, replicationDelay: 1h },
+				{ name: two, patroni: { tags: { noFailover: true } } },
+				{ name: three, patroni: { tags: { failoverPriority: 0 } } },
+			],
+		}`))
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "InvalidInstanceSets")
+		assert.Assert(t, cmp.Contains(recorder.Events[0].Note,
+			"spec.instances: Invalid value: 3: at least one instance set must be able to become primary"))
+	})
+
+	t.Run("ReplicateFrom", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+
+		reconciler.validateInstanceSets(newCluster(t, `{
+			instances: [
+				{ name: one, patroni: { tags: { replicateFrom: one } } },
+				{ name: two, patroni: { tags: { replicateFrom: missing } } },
+			],
+		}`))
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Assert(t, cmp.Contains(recorder.Events[0].Note,
+			`spec.instances[0].patroni.tags.replicateFrom: Invalid value: "one"`))
+		assert.Assert(t, cmp.Contains(recorder.Events[0].Note,
+			`spec.instances[1].patroni.tags.replicateFrom: Invalid value: "missing"`))
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+
+		cluster := newCluster(t, `{
+			instances: [
+				{ name: one },
+				{ name: two, patroni: { tags: { replicateFrom: three } } },
+				{ name: three, patroni: { tags: { replicateFrom: two } } },
+				{ name: four, patroni: { tags: { replicateFrom: three } } },
+			],
+		}`)
+		reconciler.validateInstanceSets(cluster)
+
+		assert.Equal(t, len(recorder.Events), 1)
+		for _, expected := range []string{
+			`spec.instances[1].patroni.tags.replicateFrom: Invalid value: "three": must not form a cycle`,
+			`spec.instances[2].patroni.tags.replicateFrom: Invalid value: "two": must not form a cycle`,
+			`spec.instances[3].patroni.tags.replicateFrom: Invalid value: "three": must not form a cycle`,
+		} {
+			assert.Assert(t, cmp.Contains(recorder.Events[0].Note, expected))
+		}
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, v1beta1.InstanceSetsValid)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Assert(t, cmp.Contains(condition.Message, "must not form a cycle"))
+
+		// The condition is removed once the tags are valid.
+		cluster.Spec.InstanceSets[2].Patroni = nil
+		reconciler.validateInstanceSets(cluster)
+		assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions, v1beta1.InstanceSetsValid) == nil)
+
+		assert.Assert(t, !replicationLoops(cluster, &cluster.Spec.InstanceSets[1]))
+	})
+}
+
 func TestReconcilePatroniSwitchover(t *testing.T) {
 	var called, failover, callError, callFails bool
 	var timelineCallNoLeader, timelineCall bool
@@ -998,3 +1612,31 @@ func TestReconcilePatroniSwitchover(t *testing.T) {
 		assert.Assert(t, cluster.Status.Patroni.SwitchoverTimeline == nil)
 	})
 }
+
+func TestPatroniMemberStateOf(t *testing.T) {
+	var instance Instance
+
+	// No pods
+	_, ok := patroniMemberStateOf(&instance)
+	assert.Assert(t, !ok)
+
+	// No annotations
+	instance.Pods = []*corev1.Pod{{}}
+	_, ok = patroniMemberStateOf(&instance)
+	assert.Assert(t, !ok)
+
+	// Older versions of Patroni call the primary "master"
+	instance.Pods[0].Annotations = map[string]string{"status": `{"role":"master"}`}
+	m, ok := patroniMemberStateOf(&instance)
+	assert.Assert(t, ok)
+	assert.Equal(t, m.Role, "primary")
+	assert.Assert(t, m.XLogLocation == nil)
+
+	// Patroni replica
+	instance.Pods[0].Annotations["status"] = `{"role":"replica","state":"running","xlog_location":83886240,"replay_lsn":83886000,"timeline":2}`
+	m, ok = patroniMemberStateOf(&instance)
+	assert.Assert(t, ok)
+	assert.Equal(t, *m.XLogLocation, int64(83886240))
+	assert.Equal(t, *m.ReplayLSN, int64(83886000))
+	assert.Equal(t, *m.Timeline, int64(2))
+}
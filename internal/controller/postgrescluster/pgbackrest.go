@@ -5,6 +5,7 @@
 package postgrescluster
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 	"regexp"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -70,6 +72,10 @@ const (
 	// and in-place pgBackRest restore is in progress
 	ConditionPGBackRestRestoreProgressing = "PGBackRestoreProgressing"
 
+	// ConditionRecoveryTargetReached is the type used in a condition to indicate whether or not
+	// PostgreSQL reached its recovery target when restoring with pgBackRest
+	ConditionRecoveryTargetReached = "PGBackRestRecoveryTargetReached"
+
 	// EventRepoHostNotFound is used to indicate that a pgBackRest repository was not
 	// found when reconciling
 	EventRepoHostNotFound = "RepoDeploymentNotFound"
@@ -103,6 +109,12 @@ const (
 	incremental  = "incr"
 )
 
+// expire identifies the CronJob that expires backups according to the retention of a repo
+const expire = "expire"
+
+// verify identifies the CronJob that verifies the backups in a repo
+const verify = "verify"
+
 // regexRepoIndex is the regex used to obtain the repo index from a pgBackRest repo name
 var regexRepoIndex = regexp.MustCompile(`\d+`)
 
@@ -112,7 +124,9 @@ type RepoResources struct {
 	hosts                   []*appsv1.StatefulSet
 	cronjobs                []*batchv1.CronJob
 	manualBackupJobs        []*batchv1.Job
+	pgbackrestJobs          []*batchv1.Job
 	replicaCreateBackupJobs []*batchv1.Job
+	verifyJobs              []*batchv1.Job
 	pvcs                    []*corev1.PersistentVolumeClaim
 	sas                     []*corev1.ServiceAccount
 	roles                   []*rbacv1.Role
@@ -423,10 +437,14 @@ func backupScheduleFound(repo v1beta1.PGBackRestRepo, backupType string) bool {
 			return repo.BackupSchedules.Differential != nil
 		case incremental:
 			return repo.BackupSchedules.Incremental != nil
-		default:
-			return false
 		}
 	}
+	switch backupType {
+	case expire:
+		return repo.Retention != nil
+	case verify:
+		return repo.Verify != nil
+	}
 	return false
 }
 
@@ -457,7 +475,8 @@ func unstructuredToRepoResources(kind string, repoResources *RepoResources,
 		if err != nil {
 			return errors.WithStack(err)
 		}
-		// we care about replica create backup jobs and manual backup jobs
+		// we care about replica create backup jobs, manual backup jobs, the
+		// jobs that verify repos, and the jobs of PGBackRestJobs
 		for i, job := range jobList.Items {
 			switch job.GetLabels()[naming.LabelPGBackRestBackup] {
 			case string(naming.BackupReplicaCreate):
@@ -467,6 +486,12 @@ func unstructuredToRepoResources(kind string, repoResources *RepoResources,
 				repoResources.manualBackupJobs =
 					append(repoResources.manualBackupJobs, &jobList.Items[i])
 			}
+			if job.GetLabels()[naming.LabelPGBackRestCronJob] == verify {
+				repoResources.verifyJobs = append(repoResources.verifyJobs, &jobList.Items[i])
+			}
+			if job.GetLabels()[naming.LabelPGBackRestJob] != "" {
+				repoResources.pgbackrestJobs = append(repoResources.pgbackrestJobs, &jobList.Items[i])
+			}
 		}
 	case "ConfigMapList":
 		// Repository host now uses mTLS for encryption, authentication, and authorization.
@@ -539,7 +564,8 @@ func (r *Reconciler) setScheduledJobStatus(ctx context.Context,
 	for _, job := range jobList.Items {
 		// we only care about the scheduled backup Jobs created by the
 		// associated CronJobs
-		if job.GetLabels()[naming.LabelPGBackRestCronJob] != "" {
+		if cronJobType := job.GetLabels()[naming.LabelPGBackRestCronJob]; cronJobType != "" &&
+			cronJobType != expire && cronJobType != verify {
 			sbs := v1beta1.PGBackRestScheduledBackupStatus{}
 
 			if len(job.OwnerReferences) > 0 {
@@ -790,11 +816,8 @@ func (r *Reconciler) generateBackupJobSpecIntent(ctx context.Context, postgresCl
 	repo v1beta1.PGBackRestRepo, serviceAccountName string,
 	labels, annotations map[string]string, opts ...string) *batchv1.JobSpec {
 
-	repoIndex := regexRepoIndex.FindString(repo.Name)
-	cmdOpts := []string{
-		"--stanza=" + pgbackrest.DefaultStanzaName,
-		"--repo=" + repoIndex,
-	}
+	var cmdOpts []string
+
 	// If VolumeSnapshots are enabled, use archive-copy and archive-check options
 	if postgresCluster.Spec.Backups.Snapshots != nil && feature.Enabled(ctx, feature.VolumeSnapshots) {
 		cmdOpts = append(cmdOpts, "--archive-copy=y", "--archive-check=y")
@@ -802,6 +825,23 @@ func (r *Reconciler) generateBackupJobSpecIntent(ctx context.Context, postgresCl
 
 	cmdOpts = append(cmdOpts, opts...)
 
+	return r.generateRepoJobSpecIntent(postgresCluster, repo, "backup",
+		serviceAccountName, labels, annotations, cmdOpts...)
+}
+
+// generateRepoJobSpecIntent generates a JobSpec for a Job that runs a pgBackRest command
+// against repo, e.g. "backup", "expire", or "stanza-upgrade"
+func (r *Reconciler) generateRepoJobSpecIntent(postgresCluster *v1beta1.PostgresCluster,
+	repo v1beta1.PGBackRestRepo, command, serviceAccountName string,
+	labels, annotations map[string]string, opts ...string) *batchv1.JobSpec {
+
+	// The stanza commands apply to every repo at once.
+	cmdOpts := []string{"--stanza=" + pgbackrest.DefaultStanzaName}
+	if !strings.HasPrefix(command, "stanza-") {
+		cmdOpts = append(cmdOpts, "--repo="+regexRepoIndex.FindString(repo.Name))
+	}
+	cmdOpts = append(cmdOpts, opts...)
+
 	container := corev1.Container{
 		Image:           config.PGBackRestContainerImage(postgresCluster),
 		ImagePullPolicy: postgresCluster.Spec.ImagePullPolicy,
@@ -811,12 +851,12 @@ func (r *Reconciler) generateBackupJobSpecIntent(ctx context.Context, postgresCl
 
 	// If the repo that we are backing up to is a local volume, we will configure
 	// the job to use the pgbackrest go binary to exec into the repo host and run
-	// the backup. If the repo is a cloud-based repo, we will run the pgbackrest
-	// backup command directly in the job pod.
+	// the command. If the repo is a cloud-based repo, we will run the pgbackrest
+	// command directly in the job pod.
 	if repo.Volume != nil {
 		container.Command = []string{"/opt/crunchy/bin/pgbackrest"}
 		container.Env = []corev1.EnvVar{
-			{Name: "COMMAND", Value: "backup"},
+			{Name: "COMMAND", Value: command},
 			{Name: "COMMAND_OPTS", Value: strings.Join(cmdOpts, " ")},
 			{Name: "COMPARE_HASH", Value: "true"},
 			{Name: "CONTAINER", Value: naming.PGBackRestRepoContainerName},
@@ -830,7 +870,7 @@ func (r *Reconciler) generateBackupJobSpecIntent(ctx context.Context, postgresCl
 			mkdirCommand += shell.MakeDirectories(cloudLogPath, cloudLogPath) + "; "
 		}
 
-		container.Command = []string{"sh", "-c", "--", mkdirCommand + `exec "$@"`, "--", "/bin/pgbackrest", "backup"}
+		container.Command = []string{"sh", "-c", "--", mkdirCommand + `exec "$@"`, "--", "/bin/pgbackrest", command}
 		container.Command = append(container.Command, cmdOpts...)
 	}
 
@@ -932,11 +972,109 @@ func (r *Reconciler) generateBackupJobSpecIntent(ctx context.Context, postgresCl
 	return jobSpec
 }
 
+// generateVerifyJobSpecIntent generates a JobSpec that runs "pgbackrest verify"
+// against repo. When the repo has a restore test, the Job then restores the
+// latest backup into a temporary volume the same way a restore Job would.
+func (r *Reconciler) generateVerifyJobSpecIntent(cluster *v1beta1.PostgresCluster,
+	repo v1beta1.PGBackRestRepo, labels, annotations map[string]string,
+) (*batchv1.JobSpec, error) {
+	repoOpts := []string{
+		"--stanza=" + pgbackrest.DefaultStanzaName,
+		"--repo=" + regexRepoIndex.FindString(repo.Name),
+	}
+
+	// The data volume is mounted only for a restore test, so do not log to a file.
+	verifyOpts := append(slices.Clone(repoOpts), "--log-level-file=off")
+
+	var restore []string
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+
+	if test := repo.Verify.RestoreTest; test != nil {
+		dataVolumeMount := postgres.DataVolumeMount()
+		pgdata := postgres.DataDirectory(cluster)
+
+		// Restore WAL and any tablespaces onto the temporary volume, too.
+		restoreOpts := append(slices.Clone(repoOpts),
+			"--pg1-path="+pgdata,
+			"--link-map=pg_wal="+postgres.WALDirectory(cluster, &v1beta1.PostgresInstanceSetSpec{}),
+			"--tablespace-map-all="+dataVolumeMount.MountPath+"/tablespaces",
+		)
+
+		params := postgres.NewParameterSet()
+		postgres.SetHugePages(cluster, params)
+
+		restore = pgbackrest.RestoreCommand(cluster.Spec.PostgresVersion, pgdata, params,
+			strings.Join(shell.QuoteWords(restoreOpts...), " "))
+
+		// The volume is created with the Pod and deleted along with it.
+		// - https://docs.k8s.io/concepts/storage/ephemeral-volumes/#generic-ephemeral-volumes
+		volumes = append(volumes, corev1.Volume{
+			Name: dataVolumeMount.Name,
+			VolumeSource: corev1.VolumeSource{
+				Ephemeral: &corev1.EphemeralVolumeSource{
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: cluster.Spec.Metadata.GetAnnotationsOrNil(),
+							Labels: naming.Merge(cluster.Spec.Metadata.GetLabelsOrNil(),
+								map[string]string{naming.LabelCluster: cluster.Name}),
+						},
+						Spec: test.VolumeClaimSpec.AsPersistentVolumeClaimSpec(),
+					},
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, dataVolumeMount)
+	}
+
+	// Schedule the Job like other pgBackRest Jobs.
+	dataSource := &v1beta1.PostgresClusterDataSource{}
+	if jobs := cluster.Spec.Backups.PGBackRest.Jobs; jobs != nil {
+		dataSource.Resources = jobs.Resources
+		dataSource.Affinity = jobs.Affinity
+		dataSource.Tolerations = jobs.Tolerations
+		dataSource.PriorityClassName = jobs.PriorityClassName
+	}
+
+	job := &batchv1.Job{}
+	if err := r.generateRestoreJobIntent(cluster, "", "",
+		pgbackrest.VerifyCommand(strings.Join(shell.QuoteWords(verifyOpts...), " "), restore...),
+		volumeMounts, volumes, dataSource, job); err != nil {
+		return nil, err
+	}
+
+	// Replace the labels of a restore Job so this is not mistaken for one.
+	job.Spec.Template.Labels = labels
+	job.Spec.Template.Annotations = naming.Merge(annotations, map[string]string{
+		naming.DefaultContainerAnnotation: naming.PGBackRestRestoreContainerName,
+	})
+
+	if jobs := cluster.Spec.Backups.PGBackRest.Jobs; jobs != nil {
+		job.Spec.TTLSecondsAfterFinished = jobs.TTLSecondsAfterFinished
+	}
+
+	// add pgBackRest configs to template; the cluster is the source of its own backups
+	pgbackrest.AddConfigToRestorePod(cluster, cluster, &job.Spec.Template.Spec)
+
+	// add nss_wrapper init container and add nss_wrapper env vars to the pgbackrest restore
+	// container
+	addNSSWrapper(
+		config.PGBackRestContainerImage(cluster),
+		cluster.Spec.ImagePullPolicy,
+		&job.Spec.Template)
+
+	AddTMPEmptyDir(&job.Spec.Template)
+
+	return &job.Spec, nil
+}
+
 // +kubebuilder:rbac:groups="",resources="configmaps",verbs={delete,list}
 // +kubebuilder:rbac:groups="",resources="secrets",verbs={list,delete}
 // +kubebuilder:rbac:groups="",resources="endpoints",verbs={get}
 // +kubebuilder:rbac:groups="batch",resources="jobs",verbs={list}
 
+// +kubebuilder:rbac:groups="",resources="pods",verbs={list}
+
 // observeRestoreEnv observes the current Kubernetes environment to obtain any resources applicable
 // to performing pgBackRest restores (e.g. when initializing a new cluster using an existing
 // pgBackRest backup, or when restoring in-place).  This includes finding any existing Endpoints
@@ -994,6 +1132,21 @@ func (r *Reconciler) observeRestoreEnv(ctx context.Context,
 		completed := jobCompleted(restoreJob)
 		failed := jobFailed(restoreJob)
 
+		// The restore Pod reports where recovery ended in its termination
+		// message. When it fails, the message is the end of its log.
+		var report string
+		if completed || failed {
+			var err error
+			if report, err = r.restoreJobReport(ctx, cluster, restoreJob,
+				client.MatchingLabelsSelector{
+					Selector: naming.PGBackRestRestoreJobSelector(cluster.GetName()),
+				}); err != nil {
+				return nil, nil, err
+			}
+		}
+		targetNotReached := failed &&
+			strings.Contains(report, "recovery ended before configured recovery target was reached")
+
 		if cluster.Status.PGBackRest != nil && cluster.Status.PGBackRest.Restore != nil {
 			cluster.Status.PGBackRest.Restore.StartTime = restoreJob.Status.StartTime
 			cluster.Status.PGBackRest.Restore.CompletionTime = restoreJob.Status.CompletionTime
@@ -1003,8 +1156,13 @@ func (r *Reconciler) observeRestoreEnv(ctx context.Context,
 			if completed || failed {
 				cluster.Status.PGBackRest.Restore.Finished = true
 			}
+			if completed {
+				parseRestoreReport(report, cluster.Status.PGBackRest.Restore)
+			}
 		}
 
+		setRecoveryCondition(cluster, completed, failed, targetNotReached)
+
 		// update the data source initialized condition if the Job has finished running, and is
 		// therefore in a completed or failed
 		if completed {
@@ -1046,12 +1204,16 @@ func (r *Reconciler) observeRestoreEnv(ctx context.Context,
 				}
 			}
 		} else if failed {
+			message := "pgBackRest restore failed"
+			if targetNotReached {
+				message += ": recovery target not reached"
+			}
 			meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
 				ObservedGeneration: cluster.GetGeneration(),
 				Type:               ConditionPostgresDataInitialized,
 				Status:             metav1.ConditionFalse,
 				Reason:             "PGBackRestRestoreFailed",
-				Message:            "pgBackRest restore failed",
+				Message:            message,
 			})
 		}
 	}
@@ -1059,6 +1221,117 @@ func (r *Reconciler) observeRestoreEnv(ctx context.Context,
 	return currentEndpoints, restoreJob, nil
 }
 
+// restoreJobReport returns the termination message of the restore container
+// that most recently finished in job. The Pods of job must match selector.
+func (r *Reconciler) restoreJobReport(ctx context.Context,
+	cluster *v1beta1.PostgresCluster, job *batchv1.Job, selector client.ListOption,
+) (string, error) {
+
+	pods := &corev1.PodList{}
+	if err := r.Reader.List(ctx, pods,
+		client.InNamespace(cluster.Namespace), selector,
+	); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	var report string
+	var finished time.Time
+	for i := range pods.Items {
+		if owner := metav1.GetControllerOf(&pods.Items[i]); owner == nil || owner.UID != job.UID {
+			continue
+		}
+		for _, status := range pods.Items[i].Status.ContainerStatuses {
+			if terminated := status.State.Terminated; terminated != nil &&
+				status.Name == naming.PGBackRestRestoreContainerName &&
+				!terminated.FinishedAt.Time.Before(finished) {
+				report, finished = terminated.Message, terminated.FinishedAt.Time
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// parseRestoreReport copies the values reported by [pgbackrest.RestoreCommand]
+// into status.
+func parseRestoreReport(report string, status *v1beta1.PGBackRestRestoreStatus) {
+	for line := range strings.Lines(report) {
+		key, value, _ := strings.Cut(strings.TrimSpace(line), "=")
+
+		switch key {
+		case "lsn":
+			status.RecoveredLSN = value
+		case "time":
+			if epoch, err := strconv.ParseInt(value, 10, 64); err == nil {
+				status.RecoveredTime = initialize.Pointer(metav1.NewTime(time.Unix(epoch, 0).UTC()))
+			}
+		case "timeline":
+			status.Timeline, _ = strconv.ParseInt(value, 10, 64)
+		case "system-identifier":
+			status.SystemIdentifier = value
+		}
+	}
+}
+
+// setRecoveryCondition sets the condition that indicates whether or not the restore Job
+// for cluster reached its recovery target. PostgreSQL v13 and later stop with an error
+// when all available WAL is replayed before reaching the target.
+// - https://www.postgresql.org/docs/current/runtime-config-wal.html#RUNTIME-CONFIG-WAL-RECOVERY-TARGET
+func setRecoveryCondition(cluster *v1beta1.PostgresCluster, completed, failed, targetNotReached bool) {
+	condition := metav1.Condition{
+		ObservedGeneration: cluster.GetGeneration(),
+		Type:               ConditionRecoveryTargetReached,
+		Status:             metav1.ConditionUnknown,
+		Reason:             "PGBackRestRestoreRunning",
+		Message:            "Waiting for PostgreSQL to finish recovery",
+	}
+
+	switch {
+	case completed:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "RecoveryTargetReached"
+		condition.Message = "PostgreSQL finished recovery"
+
+		if cluster.Status.PGBackRest != nil && cluster.Status.PGBackRest.Restore != nil &&
+			cluster.Status.PGBackRest.Restore.RecoveredLSN != "" {
+			condition.Message = fmt.Sprintf("PostgreSQL finished recovery at %s on timeline %d",
+				cluster.Status.PGBackRest.Restore.RecoveredLSN, cluster.Status.PGBackRest.Restore.Timeline)
+		}
+	case targetNotReached:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "RecoveryTargetNotReached"
+		condition.Message = "PostgreSQL replayed all available WAL without reaching the " +
+			"recovery target. Choose a target that is covered by the backups and WAL in " +
+			"the repository, then remove the PostgresCluster and create it again."
+	case failed:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "PGBackRestRestoreFailed"
+		condition.Message = "pgBackRest restore failed, please check pod logs"
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+}
+
+// bootstrapRestoreID returns the ID of the restore that initializes the data
+// directory of a new cluster. There is no restore annotation then.
+func bootstrapRestoreID(cluster *v1beta1.PostgresCluster) string {
+	return "~pgo-bootstrap-" + cluster.GetName()
+}
+
+// setBootstrapRestoreStatus ensures cluster has a restore status for the restore
+// that initializes its data directory so that [Reconciler.observeRestoreEnv]
+// records where recovery ended.
+func setBootstrapRestoreStatus(cluster *v1beta1.PostgresCluster) {
+	if cluster.Status.PGBackRest == nil {
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
+	}
+	if cluster.Status.PGBackRest.Restore == nil {
+		cluster.Status.PGBackRest.Restore = &v1beta1.PGBackRestRestoreStatus{
+			PGBackRestJobStatus: v1beta1.PGBackRestJobStatus{ID: bootstrapRestoreID(cluster)},
+		}
+	}
+}
+
 // +kubebuilder:rbac:groups="",resources="endpoints",verbs={delete}
 // +kubebuilder:rbac:groups="apps",resources="statefulsets",verbs={delete}
 // +kubebuilder:rbac:groups="batch",resources="jobs",verbs={delete}
@@ -1084,9 +1357,10 @@ func (r *Reconciler) prepareForRestore(ctx context.Context,
 	}
 
 	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
-	cluster.Status.PGBackRest.Restore = &v1beta1.PGBackRestJobStatus{
-		ID: restoreID,
+	cluster.Status.PGBackRest.Restore = &v1beta1.PGBackRestRestoreStatus{
+		PGBackRestJobStatus: v1beta1.PGBackRestJobStatus{ID: restoreID},
 	}
+	meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionRecoveryTargetReached)
 
 	// find all runners, the primary, and determine if the cluster is still running
 	var clusterRunning bool
@@ -1370,6 +1644,10 @@ func (r *Reconciler) generateRestoreJobIntent(cluster *v1beta1.PostgresCluster,
 					Env:             []corev1.EnvVar{{Name: "PGHOST", Value: "/tmp"}},
 					SecurityContext: initialize.RestrictedSecurityContext(),
 					Resources:       dataSource.Resources,
+
+					// The restore command reports where recovery ended. Fallback
+					// to the end of the logs when it fails before it can do so.
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 				}},
 				RestartPolicy: corev1.RestartPolicyNever,
 				Volumes:       volumes,
@@ -1563,6 +1841,22 @@ func (r *Reconciler) reconcilePGBackRest(ctx context.Context,
 		result.RequeueAfter = 10 * time.Second
 	}
 
+	// record the results of the Jobs that verify repos
+	if err := r.reconcileVerifyStatus(ctx, postgresCluster,
+		repoResources.verifyJobs); err != nil {
+		log.Error(err, "unable to reconcile verify status")
+		result.Requeue = true
+	}
+
+	// refresh the catalog of backups in each repo, and requeue to do so again
+	next, err := r.reconcileBackupCatalog(ctx, postgresCluster, instances)
+	if err != nil {
+		log.Error(err, "unable to reconcile backup catalog")
+	}
+	if next > 0 && (result.RequeueAfter == 0 || next < result.RequeueAfter) {
+		result.RequeueAfter = next
+	}
+
 	// Reconcile the initial backup that is needed to enable replica creation using pgBackRest.
 	// This is done once stanza creation is successful
 	if err := r.reconcileReplicaCreateBackup(ctx, postgresCluster, instances,
@@ -1579,6 +1873,15 @@ func (r *Reconciler) reconcilePGBackRest(ctx context.Context,
 		result.Requeue = true
 	}
 
+	// Run the commands requested by PGBackRestJobs, one at a time
+	if next, err := r.reconcilePGBackRestJobs(ctx, postgresCluster, repoResources.pgbackrestJobs,
+		sa, instances); err != nil {
+		log.Error(err, "unable to reconcile PGBackRestJobs")
+		result.Requeue = true
+	} else if next > 0 && (result.RequeueAfter == 0 || next < result.RequeueAfter) {
+		result.RequeueAfter = next
+	}
+
 	return result, nil
 }
 
@@ -1714,6 +2017,16 @@ func (r *Reconciler) reconcilePostgresClusterDataSource(ctx context.Context,
 		return nil
 	}
 
+	// Restore the backup described by a PGBackRestBackup, if any.
+	if dataSource.BackupName != "" {
+		var err error
+		dataSource, err = r.setDataSourceBackup(ctx, cluster, dataSource,
+			sourceClusterNamespace, sourceClusterName)
+		if err != nil || dataSource == nil {
+			return err
+		}
+	}
+
 	// Define a fake STS to use when calling the reconcile functions below since when
 	// bootstrapping the cluster it will not exist until after the restore is complete.
 	fakeSTS := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{
@@ -1739,6 +2052,7 @@ func (r *Reconciler) reconcilePostgresClusterDataSource(ctx context.Context,
 	// we only want to replay the WAL.
 
 	// reconcile the pgBackRest restore Job to populate the cluster's data directory
+	setBootstrapRestoreStatus(cluster)
 	if err := r.reconcileRestoreJob(ctx, cluster, sourceCluster, pgdata, pgwal, pgtablespaces,
 		dataSource, instanceName, instanceSetName, configHash, pgbackrest.DefaultStanzaName); err != nil {
 		return errors.WithStack(err)
@@ -1747,6 +2061,49 @@ func (r *Reconciler) reconcilePostgresClusterDataSource(ctx context.Context,
 	return nil
 }
 
+// +kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="pgbackrestbackups",verbs={get}
+
+// setDataSourceBackup returns a copy of dataSource that restores the backup
+// described by its PGBackRestBackup. That must be a backup in the repo of
+// dataSource that was taken by the source cluster. When it is not, or when
+// dataSource already chooses a backup, it emits an event and returns nil.
+func (r *Reconciler) setDataSourceBackup(ctx context.Context,
+	cluster *v1beta1.PostgresCluster, dataSource *v1beta1.PostgresClusterDataSource,
+	sourceClusterNamespace, sourceClusterName string,
+) (*v1beta1.PostgresClusterDataSource, error) {
+	backup := &v1beta1.PGBackRestBackup{}
+	if err := r.Reader.Get(ctx,
+		client.ObjectKey{Name: dataSource.BackupName, Namespace: sourceClusterNamespace},
+		backup); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "InvalidDataSource",
+				"PGBackRestBackup %q does not exist", dataSource.BackupName)
+			return nil, nil
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	// The spec of a PGBackRestBackup is read-only.
+	if backup.Spec.PostgresClusterName != sourceClusterName ||
+		backup.Spec.RepoName != dataSource.RepoName {
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "InvalidDataSource",
+			"PGBackRestBackup %q is not a backup in repo %q of PostgresCluster %q",
+			dataSource.BackupName, dataSource.RepoName, sourceClusterName)
+		return nil, nil
+	}
+	for _, opt := range dataSource.Options {
+		if strings.HasPrefix(strings.TrimSpace(opt), "--set") {
+			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "InvalidDataSource",
+				"Option %q cannot be used with PGBackRestBackup %q", opt, dataSource.BackupName)
+			return nil, nil
+		}
+	}
+
+	dataSource = dataSource.DeepCopy()
+	dataSource.Options = append(dataSource.Options, "--set="+backup.Spec.Label)
+	return dataSource, nil
+}
+
 // +kubebuilder:rbac:groups="",resources="persistentvolumeclaims",verbs={create,patch}
 // +kubebuilder:rbac:groups="batch",resources="jobs",verbs={create,patch,delete}
 
@@ -1848,6 +2205,7 @@ func (r *Reconciler) reconcileCloudBasedDataSource(ctx context.Context,
 
 	// reconcile the pgBackRest restore Job to populate the cluster's data directory
 	// Note that the 'source cluster' is nil as this is not used by this restore type.
+	setBootstrapRestoreStatus(cluster)
 	if err := r.reconcileRestoreJob(ctx, cluster, nil, pgdata, pgwal, pgtablespaces, tmpDataSource,
 		instanceName, instanceSetName, configHash, dataSource.Stanza); err != nil {
 		return errors.WithStack(err)
@@ -3122,6 +3480,23 @@ func (r *Reconciler) reconcileScheduledBackups(
 				}
 			}
 		}
+		// pgBackRest expires backups after each backup it takes. Expire them on a
+		// schedule, too, so that time-based retention applies without new backups.
+		if repo.Retention != nil {
+			schedule := cmp.Or(initialize.FromPointer(repo.Retention.ExpireSchedule), "@daily")
+			if err := r.reconcilePGBackRestCronJob(ctx, cluster, repo,
+				expire, &schedule, sa, cronjobs); err != nil {
+				log.Error(err, "unable to reconcile expire for "+repo.Name)
+				requeue = true
+			}
+		}
+		if repo.Verify != nil {
+			if err := r.reconcilePGBackRestCronJob(ctx, cluster, repo,
+				verify, &repo.Verify.Schedule, sa, cronjobs); err != nil {
+				log.Error(err, "unable to reconcile verify for "+repo.Name)
+				requeue = true
+			}
+		}
 	}
 	return requeue
 }
@@ -3129,7 +3504,7 @@ func (r *Reconciler) reconcileScheduledBackups(
 // +kubebuilder:rbac:groups="batch",resources="cronjobs",verbs={create,patch}
 
 // reconcilePGBackRestCronJob creates the CronJob for the given repo, pgBackRest
-// backup type and schedule
+// backup type (or expire) and schedule
 func (r *Reconciler) reconcilePGBackRestCronJob(
 	ctx context.Context, cluster *v1beta1.PostgresCluster, repo v1beta1.PGBackRestRepo,
 	backupType string, schedule *string, serviceAccount *corev1.ServiceAccount,
@@ -3205,11 +3580,24 @@ func (r *Reconciler) reconcilePGBackRestCronJob(
 		return nil
 	}
 
-	// set backup type (i.e. "full", "diff", "incr")
-	backupOpts := []string{"--type=" + backupType}
+	var jobSpec *batchv1.JobSpec
+	switch backupType {
+	case expire:
+		jobSpec = r.generateRepoJobSpecIntent(cluster, repo, expire,
+			serviceAccount.GetName(), labels, annotations)
+	case verify:
+		var err error
+		if jobSpec, err = r.generateVerifyJobSpecIntent(cluster, repo,
+			labels, annotations); err != nil {
+			return err
+		}
+	default:
+		// set backup type (i.e. "full", "diff", "incr")
+		backupOpts := []string{"--type=" + backupType}
 
-	jobSpec := r.generateBackupJobSpecIntent(ctx, cluster, repo,
-		serviceAccount.GetName(), labels, annotations, backupOpts...)
+		jobSpec = r.generateBackupJobSpecIntent(ctx, cluster, repo,
+			serviceAccount.GetName(), labels, annotations, backupOpts...)
+	}
 
 	// Suspend cronjobs when shutdown or read-only. Any jobs that have already
 	// started will continue.
@@ -3256,6 +3644,281 @@ func (r *Reconciler) reconcilePGBackRestCronJob(
 	return err
 }
 
+// jobFinishTime returns when job completed or failed, or nil when it has not.
+func jobFinishTime(job *batchv1.Job) *metav1.Time {
+	for i := range job.Status.Conditions {
+		if condition := job.Status.Conditions[i]; condition.Status == corev1.ConditionTrue &&
+			(condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) {
+			return &condition.LastTransitionTime
+		}
+	}
+	return nil
+}
+
+// parseVerifyReport copies the values reported by [pgbackrest.VerifyCommand]
+// into status. Problems are found in the tail of the logs that Kubernetes
+// reports when the command fails.
+func parseVerifyReport(report string, status *v1beta1.RepoVerifyStatus) {
+	for line := range strings.Lines(report) {
+		line = strings.TrimSpace(line)
+
+		if label, ok := strings.CutPrefix(line, "verified="); ok {
+			status.LastVerifiedBackup = label
+		} else if strings.Contains(line, "ERROR:") || strings.Contains(line, "status: error") ||
+			strings.Contains(line, "FATAL:") {
+			status.Errors = append(status.Errors, line)
+		}
+	}
+}
+
+// +kubebuilder:rbac:groups="",resources="pods",verbs={list}
+
+// reconcileVerifyStatus records the result of the latest verification of each
+// repo in the status of cluster. When a verification finishes, it emits an
+// event and updates metrics.
+func (r *Reconciler) reconcileVerifyStatus(ctx context.Context,
+	cluster *v1beta1.PostgresCluster, jobs []*batchv1.Job,
+) error {
+	latest := make(map[string]*batchv1.Job)
+	for _, job := range jobs {
+		repo := job.GetLabels()[naming.LabelPGBackRestRepo]
+		if finished := jobFinishTime(job); finished != nil &&
+			(latest[repo] == nil || jobFinishTime(latest[repo]).Before(finished)) {
+			latest[repo] = job
+		}
+	}
+
+	for i := range cluster.Status.PGBackRest.Repos {
+		repoStatus := &cluster.Status.PGBackRest.Repos[i]
+		job := latest[repoStatus.Name]
+		if job == nil {
+			continue
+		}
+
+		// Nothing to do when this Job has already been recorded.
+		finished := jobFinishTime(job)
+		if repoStatus.Verify != nil && repoStatus.Verify.CompletionTime.Equal(finished) {
+			continue
+		}
+
+		report, err := r.restoreJobReport(ctx, cluster, job,
+			client.MatchingLabelsSelector{Selector: naming.PGBackRestCronJobLabels(
+				cluster.Name, repoStatus.Name, verify).AsSelector()})
+		if err != nil {
+			return err
+		}
+
+		status := &v1beta1.RepoVerifyStatus{
+			StartTime:      job.Status.StartTime,
+			CompletionTime: finished,
+			Succeeded:      jobCompleted(job),
+		}
+		if status.StartTime != nil {
+			status.Duration = &metav1.Duration{Duration: finished.Sub(status.StartTime.Time)}
+		}
+		parseVerifyReport(report, status)
+
+		if status.Succeeded {
+			// A restore test reports where recovery ended.
+			var restored v1beta1.PGBackRestRestoreStatus
+			parseRestoreReport(report, &restored)
+
+			message := "Verified " + repoStatus.Name
+			if status.LastVerifiedBackup != "" {
+				message = fmt.Sprintf("Verified backup %s in %s", status.LastVerifiedBackup, repoStatus.Name)
+			}
+			if restored.RecoveredLSN != "" {
+				message += fmt.Sprintf(" and restored it to %s on timeline %d",
+					restored.RecoveredLSN, restored.Timeline)
+			}
+			r.Recorder.Event(cluster, corev1.EventTypeNormal, "BackupVerified", message)
+		} else {
+			message := "please check pod logs"
+			if len(status.Errors) > 0 {
+				message = strings.Join(status.Errors, "; ")
+			}
+			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "BackupVerifyFailed",
+				"Verification of %s failed, %s", repoStatus.Name, message)
+		}
+
+		// Keep the last verified backup until another is verified.
+		if status.LastVerifiedBackup == "" && repoStatus.Verify != nil {
+			status.LastVerifiedBackup = repoStatus.Verify.LastVerifiedBackup
+		}
+
+		repoStatus.Verify = status
+		recordVerifyMetrics(cluster, repoStatus.Name, status)
+	}
+
+	return nil
+}
+
+// backupCatalogInterval is how often the catalog of backups in each repo is refreshed
+const backupCatalogInterval = 5 * time.Minute
+
+// backupCatalogRetry is how long to wait before reading a repo that could not
+// be read. It doubles with each failure in a row, up to backupCatalogInterval.
+const backupCatalogRetry = 30 * time.Second
+
+// backupCatalogDue returns when the repo summarized by catalog should be read.
+func backupCatalogDue(catalog *v1beta1.RepoCatalog) time.Time {
+	if catalog == nil || catalog.AttemptTime == nil {
+		return time.Time{}
+	}
+	wait := backupCatalogInterval
+	if catalog.Failures > 0 {
+		wait = min(backupCatalogRetry<<min(catalog.Failures-1, 10), backupCatalogInterval)
+	}
+	return catalog.AttemptTime.Add(wait)
+}
+
+// reconcileBackupCatalog periodically runs "pgbackrest info" in the writable
+// instance and records a summary of each repo in the status of cluster. When
+// a repo cannot be read, it records why and waits longer before trying again.
+// It returns how long to wait before the catalog should be refreshed again.
+func (r *Reconciler) reconcileBackupCatalog(ctx context.Context,
+	cluster *v1beta1.PostgresCluster, instances *observedInstances,
+) (time.Duration, error) {
+	var writableInstanceName string
+	for _, instance := range instances.forCluster {
+		if writable, known := instance.IsWritable(); writable && known {
+			writableInstanceName = instance.Name + "-0"
+			break
+		}
+	}
+
+	// Wait for a writable instance and a stanza in at least one repo.
+	var stanzaCreated bool
+	for _, repoStatus := range cluster.Status.PGBackRest.Repos {
+		stanzaCreated = stanzaCreated || repoStatus.StanzaCreated
+	}
+	if writableInstanceName == "" || !stanzaCreated {
+		return 0, nil
+	}
+
+	// Refresh the catalog when any repo is due.
+	now := time.Now().Truncate(time.Second)
+	wait := func() time.Duration {
+		wait := backupCatalogInterval
+		for _, repoStatus := range cluster.Status.PGBackRest.Repos {
+			if repoStatus.StanzaCreated {
+				wait = min(wait, backupCatalogDue(repoStatus.Catalog).Sub(now))
+			}
+		}
+		return wait
+	}
+	if next := wait(); next > 0 {
+		return next, nil
+	}
+
+	exec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+		command ...string) error {
+		return r.PodExec(ctx, cluster.GetNamespace(), writableInstanceName,
+			naming.ContainerDatabase, stdin, stdout, stderr, command...)
+	}
+
+	catalogs, err := pgbackrest.Executor(exec).Catalogs(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error(err, "unable to read pgBackRest repos")
+	}
+
+	attempted := metav1.NewTime(now)
+	for i := range cluster.Status.PGBackRest.Repos {
+		repoStatus := &cluster.Status.PGBackRest.Repos[i]
+		if !repoStatus.StanzaCreated {
+			continue
+		}
+
+		// Keep the previous summary of a repo that cannot be read.
+		catalog := catalogs[repoStatus.Name]
+		if catalog == nil {
+			if repoStatus.Catalog == nil {
+				repoStatus.Catalog = &v1beta1.RepoCatalog{}
+			}
+			repoStatus.Catalog.AttemptTime = &attempted
+			repoStatus.Catalog.Failures++
+			repoStatus.Catalog.Error = "pgBackRest could not read the repo"
+			if err != nil {
+				repoStatus.Catalog.Error = strings.TrimSpace(err.Error())
+			}
+			continue
+		}
+
+		summary := catalog.Summary()
+		summary.AttemptTime = &attempted
+		summary.UpdateTime = &attempted
+		repoStatus.Catalog = summary
+	}
+
+	if err != nil {
+		return wait(), nil
+	}
+	return wait(), r.reconcileBackupObjects(ctx, cluster, catalogs)
+}
+
+// +kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="pgbackrestbackups",verbs={list,watch}
+// +kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="pgbackrestbackups",verbs={create,patch}
+// +kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="pgbackrestbackups",verbs={delete}
+
+// reconcileBackupObjects writes a PGBackRestBackup for every backup in catalogs
+// and deletes those of backups that have expired. It also deletes those of
+// repos that are no longer defined in the spec of cluster.
+func (r *Reconciler) reconcileBackupObjects(ctx context.Context,
+	cluster *v1beta1.PostgresCluster, catalogs map[string]*pgbackrest.Catalog,
+) error {
+	definedRepos := make(map[string]bool)
+	for _, repo := range cluster.Spec.Backups.PGBackRest.Repos {
+		definedRepos[repo.Name] = true
+	}
+
+	current := make(map[string]bool)
+	for repoName, catalog := range catalogs {
+		if !definedRepos[repoName] {
+			continue
+		}
+		for i := range catalog.Backups {
+			backup := &v1beta1.PGBackRestBackup{
+				ObjectMeta: naming.PGBackRestRepoBackup(cluster, repoName, catalog.Backups[i].Label),
+				Spec:       catalog.Backups[i],
+			}
+			backup.SetGroupVersionKind(v1beta1.GroupVersion.WithKind("PGBackRestBackup"))
+			backup.Labels = naming.PGBackRestRepoLabels(cluster.Name, repoName)
+			backup.Spec.PostgresClusterName = cluster.Name
+			current[backup.Name] = true
+
+			if err := errors.WithStack(r.setControllerReference(cluster, backup)); err != nil {
+				return err
+			}
+			if err := errors.WithStack(r.apply(ctx, backup)); err != nil {
+				return err
+			}
+		}
+	}
+
+	backups := &v1beta1.PGBackRestBackupList{}
+	if err := errors.WithStack(r.Reader.List(ctx, backups,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{naming.LabelCluster: cluster.Name},
+	)); err != nil {
+		return err
+	}
+
+	// Keep the backups of repos that could not be read this time.
+	for i := range backups.Items {
+		backup := &backups.Items[i]
+		_, read := catalogs[backup.Spec.RepoName]
+
+		if !current[backup.Name] && (read || !definedRepos[backup.Spec.RepoName]) {
+			if err := errors.WithStack(client.IgnoreNotFound(
+				r.deleteControlled(ctx, cluster, backup))); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 // BackupsEnabled checks the state of the backups (i.e., if backups are in the spec,
 // if a repo-host StatefulSet exists, if the annotation permitting backup deletion exists)
 // and determines whether reconciliation is allowed.
@@ -31,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -41,6 +42,7 @@ import (
 	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/pgbackrest"
 	"github.com/crunchydata/postgres-operator/internal/pki"
+	"github.com/crunchydata/postgres-operator/internal/postgres"
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
 	"github.com/crunchydata/postgres-operator/internal/testing/events"
 	"github.com/crunchydata/postgres-operator/internal/testing/require"
@@ -536,6 +538,14 @@ topologySpreadConstraints:
 			assert.Assert(t, backupScheduleFound(testrepo, "full"))
 			assert.Assert(t, backupScheduleFound(testrepo, "diff"))
 			assert.Assert(t, backupScheduleFound(testrepo, "incr"))
+			assert.Assert(t, !backupScheduleFound(testrepo, "expire"))
+
+			testrepo.Retention = &v1beta1.PGBackRestRetention{Full: initialize.Int32(2)}
+			assert.Assert(t, backupScheduleFound(testrepo, "expire"))
+
+			assert.Assert(t, !backupScheduleFound(testrepo, "verify"))
+			testrepo.Verify = &v1beta1.PGBackRestVerify{Schedule: testCronSchedule}
+			assert.Assert(t, backupScheduleFound(testrepo, "verify"))
 
 		})
 
@@ -2153,6 +2163,8 @@ func TestReconcilePostgresClusterDataSource(t *testing.T) {
 				if len(restoreJobs.Items) == 1 {
 					assert.Assert(t, restoreJobs.Items[0].Labels[naming.LabelStartupInstance] != "")
 					assert.Assert(t, restoreJobs.Items[0].Annotations[naming.PGBackRestConfigHash] != "")
+					assert.Assert(t, cluster.Status.PGBackRest != nil && cluster.Status.PGBackRest.Restore != nil)
+					assert.Equal(t, cluster.Status.PGBackRest.Restore.ID, bootstrapRestoreID(cluster))
 					for _, cmd := range tc.result.expectedCommandPieces {
 						assert.Assert(t, cmp.Contains(
 							strings.Join(restoreJobs.Items[0].Spec.Template.Spec.Containers[0].Command, " "),
@@ -2361,6 +2373,8 @@ func TestReconcileCloudBasedDataSource(t *testing.T) {
 				if len(restoreJobs.Items) == 1 {
 					assert.Assert(t, restoreJobs.Items[0].Labels[naming.LabelStartupInstance] != "")
 					assert.Assert(t, restoreJobs.Items[0].Annotations[naming.PGBackRestConfigHash] != "")
+					assert.Assert(t, cluster.Status.PGBackRest != nil && cluster.Status.PGBackRest.Restore != nil)
+					assert.Equal(t, cluster.Status.PGBackRest.Restore.ID, bootstrapRestoreID(cluster))
 				}
 
 				dataPVCs := &corev1.PersistentVolumeClaimList{}
@@ -2796,6 +2810,27 @@ volumes:
 		`))
 	})
 
+	t.Run("Expire", func(t *testing.T) {
+		volume := r.generateRepoJobSpecIntent(&cluster, v1beta1.PGBackRestRepo{
+			Name:   "repo2",
+			Volume: &v1beta1.RepoPVC{},
+		}, "expire", "", nil, nil)
+
+		assert.DeepEqual(t, volume.Template.Spec.Containers[0].Env[:2], []corev1.EnvVar{
+			{Name: "COMMAND", Value: "expire"},
+			{Name: "COMMAND_OPTS", Value: "--stanza=db --repo=2"},
+		})
+
+		cloud := r.generateRepoJobSpecIntent(&cluster, v1beta1.PGBackRestRepo{
+			Name: "repo3",
+			GCS:  &v1beta1.RepoGCS{Bucket: "bucket"},
+		}, "expire", "", nil, nil)
+
+		assert.DeepEqual(t, cloud.Template.Spec.Containers[0].Command[5:], []string{
+			"/bin/pgbackrest", "expire", "--stanza=db", "--repo=3",
+		})
+	})
+
 	t.Run("ImagePullPolicy", func(t *testing.T) {
 		cluster := &v1beta1.PostgresCluster{
 			Spec: v1beta1.PostgresClusterSpec{
@@ -3690,6 +3725,10 @@ func TestGenerateRestoreJobIntent(t *testing.T) {
 								assert.DeepEqual(t, job.Spec.Template.Spec.Containers[0].Resources,
 									dataSource.Resources)
 							})
+							t.Run("TerminationMessagePolicy", func(t *testing.T) {
+								assert.Equal(t, job.Spec.Template.Spec.Containers[0].TerminationMessagePolicy,
+									corev1.TerminationMessageFallbackToLogsOnError)
+							})
 						})
 						t.Run("RestartPolicy", func(t *testing.T) {
 							assert.Equal(t, job.Spec.Template.Spec.RestartPolicy,
@@ -3975,6 +4014,539 @@ func TestObserveRestoreEnv(t *testing.T) {
 	}
 }
 
+func TestParseRestoreReport(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		status := &v1beta1.PGBackRestRestoreStatus{}
+		parseRestoreReport("", status)
+		assert.DeepEqual(t, status, &v1beta1.PGBackRestRestoreStatus{})
+	})
+
+	t.Run("Complete", func(t *testing.T) {
+		status := &v1beta1.PGBackRestRestoreStatus{}
+		parseRestoreReport(strings.Join([]string{
+			"lsn=0/5000060",
+			"time=1700000000",
+			"timeline=3",
+			"system-identifier=7301234567890123456",
+		}, "\n"), status)
+
+		assert.Equal(t, status.RecoveredLSN, "0/5000060")
+		assert.Assert(t, status.RecoveredTime != nil)
+		assert.Equal(t, status.RecoveredTime.UTC().Format(time.RFC3339), "2023-11-14T22:13:20Z")
+		assert.Equal(t, status.Timeline, int64(3))
+		assert.Equal(t, status.SystemIdentifier, "7301234567890123456")
+	})
+
+	t.Run("NoTransactions", func(t *testing.T) {
+		status := &v1beta1.PGBackRestRestoreStatus{}
+		parseRestoreReport("lsn=0/3000000\ntime=\ntimeline=1\n", status)
+
+		assert.Equal(t, status.RecoveredLSN, "0/3000000")
+		assert.Assert(t, status.RecoveredTime == nil)
+		assert.Equal(t, status.Timeline, int64(1))
+	})
+}
+
+func TestBootstrapRestoreStatus(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Name = "hippo"
+	cluster.Namespace = "ns1"
+
+	// A new cluster has no restore status, not even pgBackRest status.
+	setBootstrapRestoreStatus(cluster)
+	assert.Assert(t, cluster.Status.PGBackRest != nil)
+	assert.Assert(t, cluster.Status.PGBackRest.Restore != nil)
+	assert.Equal(t, cluster.Status.PGBackRest.Restore.ID, "~pgo-bootstrap-hippo")
+
+	// Calling it again keeps what was observed.
+	cluster.Status.PGBackRest.Restore.Finished = true
+	setBootstrapRestoreStatus(cluster)
+	assert.Assert(t, cluster.Status.PGBackRest.Restore.Finished)
+
+	cluster.Status.PGBackRest.Restore.Finished = false
+
+	job := &batchv1.Job{ObjectMeta: naming.PGBackRestRestoreJob(cluster)}
+	job.UID = "job-uid"
+	job.Labels = naming.PGBackRestRestoreJobLabels(cluster.Name)
+	job.Status.StartTime = initialize.Pointer(metav1.Now())
+	job.Status.CompletionTime = initialize.Pointer(metav1.Now())
+	job.Status.Succeeded = 1
+	job.Status.Conditions = []batchv1.JobCondition{{
+		Type: batchv1.JobComplete, Status: corev1.ConditionTrue,
+	}}
+
+	pod := &corev1.Pod{}
+	pod.Name = "hippo-restore-abcde"
+	pod.Namespace = cluster.Namespace
+	pod.Labels = naming.PGBackRestRestoreJobLabels(cluster.Name)
+	pod.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: "batch/v1", Kind: "Job", Name: job.Name, UID: job.UID,
+		Controller: initialize.Bool(true),
+	}}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name: naming.PGBackRestRestoreContainerName,
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			Message:    "lsn=0/5000060\ntimeline=2\nsystem-identifier=7301234567890123456\n",
+			FinishedAt: metav1.Now(),
+		}},
+	}}
+
+	r := &Reconciler{
+		Reader: fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(job, pod).Build(),
+	}
+
+	// The restore Job of a data source records where recovery ended.
+	_, _, err := r.observeRestoreEnv(ctx, cluster)
+	assert.NilError(t, err)
+
+	restore := cluster.Status.PGBackRest.Restore
+	assert.Equal(t, restore.ID, "~pgo-bootstrap-hippo")
+	assert.Assert(t, restore.Finished)
+	assert.Equal(t, restore.Succeeded, int32(1))
+	assert.Equal(t, restore.RecoveredLSN, "0/5000060")
+	assert.Equal(t, restore.Timeline, int64(2))
+	assert.Equal(t, restore.SystemIdentifier, "7301234567890123456")
+
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionRecoveryTargetReached)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Message, "PostgreSQL finished recovery at 0/5000060 on timeline 2")
+}
+
+func TestGenerateVerifyJobSpecIntent(t *testing.T) {
+	r := Reconciler{}
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Name = "hippo"
+	cluster.Spec.PostgresVersion = 17
+	cluster.Spec.Backups.PGBackRest.Jobs = &v1beta1.BackupJobs{
+		PriorityClassName:       initialize.String("some-priority"),
+		TTLSecondsAfterFinished: initialize.Int32(100),
+	}
+
+	labels := naming.PGBackRestCronJobLabels(cluster.Name, "repo2", verify)
+	repo := v1beta1.PGBackRestRepo{
+		Name:   "repo2",
+		Verify: &v1beta1.PGBackRestVerify{Schedule: "@weekly"},
+	}
+
+	t.Run("Verify", func(t *testing.T) {
+		spec, err := r.generateVerifyJobSpecIntent(cluster, repo, labels, nil)
+		assert.NilError(t, err)
+
+		assert.DeepEqual(t, spec.Template.Labels, map[string]string(labels))
+		assert.Equal(t, spec.Template.Annotations[naming.DefaultContainerAnnotation],
+			naming.PGBackRestRestoreContainerName)
+		assert.Equal(t, *spec.TTLSecondsAfterFinished, int32(100))
+		assert.Equal(t, spec.Template.Spec.PriorityClassName, "some-priority")
+
+		container := spec.Template.Spec.Containers[0]
+		assert.Equal(t, container.Name, naming.PGBackRestRestoreContainerName)
+		assert.Equal(t, container.TerminationMessagePolicy,
+			corev1.TerminationMessageFallbackToLogsOnError)
+		assert.Assert(t, cmp.Contains(container.Command[3], "pgbackrest verify"))
+		assert.DeepEqual(t, container.Command[4:], []string{"-",
+			`'--stanza=db' '--repo=2' '--log-level-file=off'`})
+
+		for _, volume := range spec.Template.Spec.Volumes {
+			assert.Assert(t, volume.Ephemeral == nil, "expected no temporary volume")
+		}
+	})
+
+	t.Run("RestoreTest", func(t *testing.T) {
+		repo := *repo.DeepCopy()
+		repo.Verify.RestoreTest = &v1beta1.PGBackRestRestoreTest{}
+		require.UnmarshalInto(t, &repo.Verify.RestoreTest.VolumeClaimSpec, `{
+			accessModes: [ReadWriteOnce],
+			resources: { requests: { storage: 1Gi } },
+		}`)
+
+		spec, err := r.generateVerifyJobSpecIntent(cluster, repo, labels, nil)
+		assert.NilError(t, err)
+
+		container := spec.Template.Spec.Containers[0]
+		assert.Assert(t, cmp.Contains(container.Command[3], "pgbackrest verify"))
+		assert.Assert(t, cmp.Contains(strings.Join(container.Command[6:], "\n"), "pgbackrest restore"))
+		assert.Equal(t, container.Command[len(container.Command)-1], strings.Join([]string{
+			`'--stanza=db'`, `'--repo=2'`, `'--pg1-path=/pgdata/pg17'`,
+			`'--link-map=pg_wal=/pgdata/pg17_wal'`, `'--tablespace-map-all=/pgdata/tablespaces'`,
+		}, " "))
+		assert.Assert(t, cmp.Contains(container.VolumeMounts, postgres.DataVolumeMount()))
+
+		var found bool
+		for _, volume := range spec.Template.Spec.Volumes {
+			if volume.Name == postgres.DataVolumeMount().Name {
+				found = true
+				assert.Assert(t, cmp.MarshalMatches(volume.Ephemeral, `
+volumeClaimTemplate:
+  metadata:
+    labels:
+      postgres-operator.crunchydata.com/cluster: hippo
+  spec:
+    accessModes:
+    - ReadWriteOnce
+    resources:
+      requests:
+        storage: 1Gi
+				`))
+			}
+		}
+		assert.Assert(t, found, "expected a temporary data volume")
+	})
+}
+
+func TestParseVerifyReport(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		status := &v1beta1.RepoVerifyStatus{}
+		parseVerifyReport("", status)
+		assert.DeepEqual(t, status, &v1beta1.RepoVerifyStatus{})
+	})
+
+	t.Run("Verified", func(t *testing.T) {
+		status := &v1beta1.RepoVerifyStatus{}
+		parseVerifyReport("lsn=0/5000060\ntimeline=2\nverified=20240102-000000F\n", status)
+
+		assert.Equal(t, status.LastVerifiedBackup, "20240102-000000F")
+		assert.Assert(t, cmp.Len(status.Errors, 0))
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		status := &v1beta1.RepoVerifyStatus{}
+		parseVerifyReport(strings.Join([]string{
+			"stanza: db",
+			"status: error",
+			"  backup: 20240101-000000F, status: invalid, total files checked: 10",
+			"ERROR: [028]: backup and archive info files exist but do not match the database",
+		}, "\n"), status)
+
+		assert.Equal(t, status.LastVerifiedBackup, "")
+		assert.DeepEqual(t, status.Errors, []string{
+			"status: error",
+			"ERROR: [028]: backup and archive info files exist but do not match the database",
+		})
+	})
+}
+
+func TestReconcileVerifyStatus(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Namespace, cluster.Name = "ns1", "hippo"
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{{Name: "repo1"}, {Name: "repo2"}},
+	}
+
+	started := metav1.NewTime(time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC))
+	finished := metav1.NewTime(started.Add(90 * time.Second))
+
+	job := func(name, repo string, condition batchv1.JobConditionType) *batchv1.Job {
+		job := &batchv1.Job{}
+		job.Namespace, job.Name, job.UID = cluster.Namespace, name, types.UID(name)
+		job.Labels = naming.PGBackRestCronJobLabels(cluster.Name, repo, verify)
+		job.Status.StartTime = &started
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type: condition, Status: corev1.ConditionTrue, LastTransitionTime: finished,
+		}}
+		return job
+	}
+	pod := func(job *batchv1.Job, message string) *corev1.Pod {
+		pod := &corev1.Pod{}
+		pod.Namespace, pod.Name, pod.Labels = job.Namespace, job.Name+"-pod", job.Labels
+		pod.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "batch/v1", Kind: "Job", Name: job.Name, UID: job.UID,
+			Controller: initialize.Bool(true),
+		}}
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name: naming.PGBackRestRestoreContainerName,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Message: message, FinishedAt: finished,
+			}},
+		}}
+		return pod
+	}
+
+	passed := job("passed", "repo1", batchv1.JobComplete)
+	failed := job("failed", "repo2", batchv1.JobFailed)
+	running := job("running", "repo2", batchv1.JobSuspended)
+	running.Status.Conditions[0].LastTransitionTime = metav1.NewTime(finished.Add(time.Hour))
+
+	recorder := events.NewRecorder(t, runtime.Scheme)
+	r := &Reconciler{
+		Reader: fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(
+			pod(passed, "lsn=0/5000060\ntimeline=2\nverified=20240102-000000F\n"),
+			pod(failed, "ERROR: [082]: no backups exist"),
+		).Build(),
+		Recorder: recorder,
+	}
+
+	assert.NilError(t, r.reconcileVerifyStatus(ctx, cluster,
+		[]*batchv1.Job{passed, failed, running}))
+
+	repo1 := cluster.Status.PGBackRest.Repos[0].Verify
+	assert.Assert(t, repo1 != nil)
+	assert.Assert(t, repo1.Succeeded)
+	assert.Equal(t, repo1.LastVerifiedBackup, "20240102-000000F")
+	assert.Equal(t, repo1.Duration.Duration, 90*time.Second)
+	assert.Assert(t, repo1.CompletionTime.Equal(&finished))
+
+	repo2 := cluster.Status.PGBackRest.Repos[1].Verify
+	assert.Assert(t, repo2 != nil)
+	assert.Assert(t, !repo2.Succeeded)
+	assert.DeepEqual(t, repo2.Errors, []string{"ERROR: [082]: no backups exist"})
+
+	assert.Equal(t, len(recorder.Events), 2)
+	assert.Equal(t, recorder.Events[0].Reason, "BackupVerified")
+	assert.Equal(t, recorder.Events[0].Note,
+		"Verified backup 20240102-000000F in repo1 and restored it to 0/5000060 on timeline 2")
+	assert.Equal(t, recorder.Events[1].Reason, "BackupVerifyFailed")
+	assert.Equal(t, recorder.Events[1].Regarding.Name, cluster.Name)
+
+	t.Run("Recorded", func(t *testing.T) {
+		assert.NilError(t, r.reconcileVerifyStatus(ctx, cluster,
+			[]*batchv1.Job{passed, failed, running}))
+		assert.Equal(t, len(recorder.Events), 2, "expected no more events")
+	})
+}
+
+func TestReconcileBackupCatalog(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Namespace, cluster.Name, cluster.UID = "ns1", "hippo", "hippo-uid"
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+		{Name: "repo1"}, {Name: "repo2"},
+	}
+
+	writable := newObservedInstances(cluster, nil, []corev1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"status": `"role":"primary"`},
+			Labels: map[string]string{
+				naming.LabelCluster:  cluster.Name,
+				naming.LabelInstance: "hippo-abc",
+				naming.LabelRole:     naming.RolePatroniLeader,
+			},
+		},
+	}})
+
+	// Backups that are no longer in a repo or whose repo is gone.
+	expired := func(repoName, label string) *v1beta1.PGBackRestBackup {
+		backup := &v1beta1.PGBackRestBackup{
+			ObjectMeta: naming.PGBackRestRepoBackup(cluster, repoName, label),
+		}
+		backup.Labels = map[string]string{naming.LabelCluster: cluster.Name}
+		backup.Spec.RepoName = repoName
+		assert.NilError(t, controllerutil.SetControllerReference(cluster, backup, runtime.Scheme))
+		return backup
+	}
+
+	cc := fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(
+		expired("repo1", "20231201-000000F"),
+		expired("repo3", "20231201-000000F"),
+	).Build()
+
+	var calls []string
+	r := &Reconciler{
+		Reader: cc,
+		Writer: client.WithFieldOwner(cc, t.Name()),
+		PodExec: func(_ context.Context, namespace, pod, container string,
+			_ io.Reader, stdout, _ io.Writer, command ...string) error {
+			calls = append(calls, namespace+"/"+pod+"/"+container)
+			_, _ = stdout.Write([]byte(`[{"name": "db",
+				"backup": [{"database": {"repo-key": 1}, "label": "20240101-000000F", "type": "full"}],
+				"repo": [{"key": 1, "status": {"code": 0}}, {"key": 2, "status": {"code": 0}}]
+			}]`))
+			return nil
+		},
+	}
+
+	t.Run("NotReady", func(t *testing.T) {
+		calls = nil
+		cluster := cluster.DeepCopy()
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+			Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: false}},
+		}
+
+		next, err := r.reconcileBackupCatalog(ctx, cluster, writable)
+		assert.NilError(t, err)
+		assert.Equal(t, next, time.Duration(0))
+
+		cluster.Status.PGBackRest.Repos[0].StanzaCreated = true
+		next, err = r.reconcileBackupCatalog(ctx, cluster, &observedInstances{})
+		assert.NilError(t, err)
+		assert.Equal(t, next, time.Duration(0))
+		assert.Assert(t, cmp.Len(calls, 0))
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		calls = nil
+		cluster := cluster.DeepCopy()
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+			Repos: []v1beta1.RepoStatus{
+				{Name: "repo1", StanzaCreated: true},
+				{Name: "repo2", StanzaCreated: true},
+			},
+		}
+
+		next, err := r.reconcileBackupCatalog(ctx, cluster, writable)
+		assert.NilError(t, err)
+		assert.Equal(t, next, backupCatalogInterval)
+		assert.DeepEqual(t, calls, []string{"ns1/hippo-abc-0/database"})
+
+		repo1 := cluster.Status.PGBackRest.Repos[0].Catalog
+		assert.Assert(t, repo1 != nil && repo1.UpdateTime != nil)
+		assert.Equal(t, repo1.Backups, int32(1))
+		assert.Equal(t, repo1.LatestFull.Label, "20240101-000000F")
+
+		repo2 := cluster.Status.PGBackRest.Repos[1].Catalog
+		assert.Assert(t, repo2 != nil && repo2.UpdateTime != nil)
+		assert.Equal(t, repo2.Backups, int32(0))
+
+		backups := &v1beta1.PGBackRestBackupList{}
+		assert.NilError(t, cc.List(ctx, backups))
+		assert.Equal(t, len(backups.Items), 1, "expected expired backups to be deleted")
+
+		backup := backups.Items[0]
+		assert.Equal(t, backup.Name, "hippo-repo1-20240101-000000f")
+		assert.Equal(t, backup.Labels[naming.LabelCluster], "hippo")
+		assert.Equal(t, backup.Labels[naming.LabelPGBackRestRepo], "repo1")
+		assert.Assert(t, metav1.IsControlledBy(&backup, cluster))
+		assert.Equal(t, backup.Spec.PostgresClusterName, "hippo")
+		assert.Equal(t, backup.Spec.RepoName, "repo1")
+		assert.Equal(t, backup.Spec.Type, "full")
+		assert.Equal(t, backup.Spec.Label, "20240101-000000F")
+
+		// The catalog is up to date.
+		next, err = r.reconcileBackupCatalog(ctx, cluster, writable)
+		assert.NilError(t, err)
+		assert.Assert(t, next > 0 && next <= backupCatalogInterval)
+		assert.Equal(t, len(calls), 1, "expected no more calls")
+
+		// The catalog is out of date.
+		past := metav1.NewTime(time.Now().Add(-time.Hour))
+		cluster.Status.PGBackRest.Repos[1].Catalog.AttemptTime = &past
+
+		_, err = r.reconcileBackupCatalog(ctx, cluster, writable)
+		assert.NilError(t, err)
+		assert.Equal(t, len(calls), 2)
+		assert.Assert(t, past.Before(cluster.Status.PGBackRest.Repos[1].Catalog.UpdateTime))
+	})
+
+	t.Run("Unreadable", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+			Repos: []v1beta1.RepoStatus{
+				{Name: "repo1", StanzaCreated: true},
+				{Name: "repo2", StanzaCreated: true},
+			},
+		}
+
+		var calls int
+		r := *r
+		r.PodExec = func(_ context.Context, _, _, _ string,
+			_ io.Reader, stdout, _ io.Writer, _ ...string) error {
+			calls++
+			_, _ = stdout.Write([]byte(`[{"name": "db",
+				"repo": [{"key": 1, "status": {"code": 0}}, {"key": 2, "status": {"code": 99}}]
+			}]`))
+			return nil
+		}
+
+		next, err := r.reconcileBackupCatalog(ctx, cluster, writable)
+		assert.NilError(t, err)
+		assert.Equal(t, next, backupCatalogRetry)
+		assert.Equal(t, calls, 1)
+
+		repo1 := cluster.Status.PGBackRest.Repos[0].Catalog
+		assert.Equal(t, repo1.Error, "")
+		assert.Equal(t, repo1.Failures, int32(0))
+
+		repo2 := cluster.Status.PGBackRest.Repos[1].Catalog
+		assert.Assert(t, repo2.AttemptTime != nil && repo2.UpdateTime == nil)
+		assert.Equal(t, repo2.Error, "pgBackRest could not read the repo")
+		assert.Equal(t, repo2.Failures, int32(1))
+
+		// Nothing is due before the retry.
+		_, err = r.reconcileBackupCatalog(ctx, cluster, writable)
+		assert.NilError(t, err)
+		assert.Equal(t, calls, 1, "expected to wait")
+
+		// Each failure doubles the wait.
+		past := metav1.NewTime(repo2.AttemptTime.Add(-backupCatalogRetry))
+		repo2.AttemptTime = &past
+
+		next, err = r.reconcileBackupCatalog(ctx, cluster, writable)
+		assert.NilError(t, err)
+		assert.Equal(t, calls, 2)
+		assert.Equal(t, next, 2*backupCatalogRetry)
+		assert.Equal(t, cluster.Status.PGBackRest.Repos[1].Catalog.Failures, int32(2))
+	})
+
+	t.Run("ExecError", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+			Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: true,
+				Catalog: &v1beta1.RepoCatalog{Backups: 3}}},
+		}
+
+		r := *r
+		r.PodExec = func(_ context.Context, _, _, _ string,
+			_ io.Reader, _, stderr io.Writer, _ ...string) error {
+			_, _ = stderr.Write([]byte("ERROR: [039]: oops\n"))
+			return errors.New("exit status 39")
+		}
+
+		next, err := r.reconcileBackupCatalog(ctx, cluster, writable)
+		assert.NilError(t, err, "expected the error in status")
+		assert.Equal(t, next, backupCatalogRetry)
+
+		repo1 := cluster.Status.PGBackRest.Repos[0].Catalog
+		assert.Equal(t, repo1.Backups, int32(3), "expected the previous summary")
+		assert.Equal(t, repo1.Error, "exit status 39: ERROR: [039]: oops")
+		assert.Equal(t, repo1.Failures, int32(1))
+	})
+}
+
+func TestSetRecoveryCondition(t *testing.T) {
+	for _, tt := range []struct {
+		name                                string
+		completed, failed, targetNotReached bool
+		status                              metav1.ConditionStatus
+		reason                              string
+	}{
+		{name: "Running", status: metav1.ConditionUnknown, reason: "PGBackRestRestoreRunning"},
+		{name: "Completed", completed: true, status: metav1.ConditionTrue, reason: "RecoveryTargetReached"},
+		{name: "Failed", failed: true, status: metav1.ConditionFalse, reason: "PGBackRestRestoreFailed"},
+		{
+			name: "TargetNotReached", failed: true, targetNotReached: true,
+			status: metav1.ConditionFalse, reason: "RecoveryTargetNotReached",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &v1beta1.PostgresCluster{}
+			setRecoveryCondition(cluster, tt.completed, tt.failed, tt.targetNotReached)
+
+			condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionRecoveryTargetReached)
+			assert.Assert(t, condition != nil)
+			assert.Equal(t, condition.Status, tt.status)
+			assert.Equal(t, condition.Reason, tt.reason)
+		})
+	}
+
+	t.Run("CompletedWithReport", func(t *testing.T) {
+		cluster := &v1beta1.PostgresCluster{}
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+			Restore: &v1beta1.PGBackRestRestoreStatus{RecoveredLSN: "0/5000060", Timeline: 2},
+		}
+		setRecoveryCondition(cluster, true, false, false)
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionRecoveryTargetReached)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Message, "PostgreSQL finished recovery at 0/5000060 on timeline 2")
+	})
+}
+
 func TestPrepareForRestore(t *testing.T) {
 	ctx := context.Background()
 	_, tClient := setupKubernetes(t)
@@ -4916,3 +5488,77 @@ func TestGetCloudLogPath(t *testing.T) {
 		assert.Equal(t, getCloudLogPath(postgrescluster), "/volumes/test/log")
 	})
 }
+
+func TestSetDataSourceBackup(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Namespace, cluster.Name = "ns1", "hippo"
+
+	backup := &v1beta1.PGBackRestBackup{}
+	backup.Namespace, backup.Name = "ns2", "source-repo1-20240101-000000f"
+	backup.Spec.PostgresClusterName = "source"
+	backup.Spec.RepoName = "repo1"
+	backup.Spec.Label = "20240101-000000F"
+
+	recorder := events.NewRecorder(t, runtime.Scheme)
+	r := &Reconciler{
+		Reader:   fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(backup).Build(),
+		Recorder: recorder,
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		dataSource := &v1beta1.PostgresClusterDataSource{
+			RepoName:   "repo1",
+			BackupName: backup.Name,
+			Options:    []string{"--delta"},
+		}
+
+		result, err := r.setDataSourceBackup(ctx, cluster, dataSource, "ns2", "source")
+		assert.NilError(t, err)
+		assert.Assert(t, result != nil)
+		assert.DeepEqual(t, result.Options, []string{"--delta", "--set=20240101-000000F"})
+		assert.DeepEqual(t, dataSource.Options, []string{"--delta"})
+	})
+
+	for _, tt := range []struct {
+		name, namespace, cluster, repo string
+		options                        []string
+		message                        string
+	}{
+		{
+			name: "NotFound", namespace: "ns1", cluster: "source", repo: "repo1",
+			message: "does not exist",
+		},
+		{
+			name: "OtherCluster", namespace: "ns2", cluster: "other", repo: "repo1",
+			message: `is not a backup in repo "repo1" of PostgresCluster "other"`,
+		},
+		{
+			name: "OtherRepo", namespace: "ns2", cluster: "source", repo: "repo2",
+			message: `is not a backup in repo "repo2" of PostgresCluster "source"`,
+		},
+		{
+			name: "SetOption", namespace: "ns2", cluster: "source", repo: "repo1",
+			options: []string{"--set=20240102-000000F"},
+			message: `Option "--set=20240102-000000F" cannot be used`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder.Events = nil
+			dataSource := &v1beta1.PostgresClusterDataSource{
+				RepoName:   tt.repo,
+				BackupName: backup.Name,
+				Options:    tt.options,
+			}
+
+			result, err := r.setDataSourceBackup(ctx, cluster, dataSource, tt.namespace, tt.cluster)
+			assert.NilError(t, err)
+			assert.Assert(t, result == nil)
+
+			assert.Equal(t, len(recorder.Events), 1)
+			assert.Equal(t, recorder.Events[0].Reason, "InvalidDataSource")
+			assert.Assert(t, cmp.Contains(recorder.Events[0].Note, tt.message))
+		})
+	}
+}
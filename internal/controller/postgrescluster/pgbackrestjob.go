@@ -0,0 +1,352 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/pgbackrest"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// ConditionPGBackRestJobSuccessful is the type used in a condition to indicate
+// whether or not the command of a PGBackRestJob was successful. It is Unknown
+// until the command finishes.
+const ConditionPGBackRestJobSuccessful = "PGBackRestJobSuccessful"
+
+// pgBackRestJobFinished returns true when the command of request has finished
+// or cannot run.
+func pgBackRestJobFinished(request *v1beta1.PGBackRestJob) bool {
+	condition := meta.FindStatusCondition(request.Status.Conditions,
+		ConditionPGBackRestJobSuccessful)
+	return condition != nil && condition.Status != metav1.ConditionUnknown
+}
+
+// +kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="pgbackrestjobs",verbs={get,list,watch}
+// +kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="pgbackrestjobs/status",verbs={patch}
+
+// pgBackRestJobGracePeriod is how long after it is created that the Job of a
+// PGBackRestJob can be missing before it is considered deleted. A Job that was
+// just created may not be observed right away.
+const pgBackRestJobGracePeriod = time.Minute
+
+// reconcilePGBackRestJobs runs the commands of the PGBackRestJobs of cluster one
+// at a time, oldest first, and records the outcome in the status of each. The
+// jobs argument should contain the Jobs that run those commands. It returns
+// how long to wait for Jobs that have not been observed yet.
+func (r *Reconciler) reconcilePGBackRestJobs(ctx context.Context,
+	cluster *v1beta1.PostgresCluster, jobs []*batchv1.Job,
+	serviceAccount *corev1.ServiceAccount, instances *observedInstances,
+) (time.Duration, error) {
+	list := &v1beta1.PGBackRestJobList{}
+	if err := errors.WithStack(r.Reader.List(ctx, list,
+		client.InNamespace(cluster.Namespace),
+	)); err != nil {
+		return 0, err
+	}
+
+	var requests []*v1beta1.PGBackRestJob
+	for i := range list.Items {
+		if list.Items[i].Spec.PostgresClusterName == cluster.Name {
+			requests = append(requests, &list.Items[i])
+		}
+	}
+	slices.SortStableFunc(requests, comparePGBackRestJobs)
+
+	before := make([]*v1beta1.PGBackRestJob, len(requests))
+	for i := range requests {
+		before[i] = requests[i].DeepCopy()
+	}
+
+	jobsByRequest := make(map[string]*batchv1.Job, len(jobs))
+	for _, job := range jobs {
+		jobsByRequest[job.Labels[naming.LabelPGBackRestJob]] = job
+	}
+
+	// Record the progress of commands that have started. pgBackRest allows
+	// only one command at a time, so note when one is still running.
+	var running bool
+	var requeue time.Duration
+	now := time.Now()
+	for _, request := range requests {
+		if pgBackRestJobFinished(request) {
+			continue
+		}
+
+		// The Running condition is set when the Job is created; see
+		// [Reconciler.startPGBackRestJob].
+		var waiting time.Duration
+		if request.Status.Job != nil && request.Status.Job.StartTime == nil {
+			if condition := meta.FindStatusCondition(request.Status.Conditions,
+				ConditionPGBackRestJobSuccessful); condition != nil {
+				waiting = condition.LastTransitionTime.Add(pgBackRestJobGracePeriod).Sub(now)
+			}
+		}
+
+		switch job := jobsByRequest[request.Name]; {
+		case job != nil:
+			r.setPGBackRestJobStatus(request, job)
+			running = running || !pgBackRestJobFinished(request)
+
+		case request.Status.Job != nil && waiting > 0:
+			// The Job was created but has not been observed yet.
+			running = true
+			if requeue == 0 || waiting < requeue {
+				requeue = waiting
+			}
+
+		case request.Status.Job != nil:
+			// The Job is gone. Its outcome is unknown, and running it again
+			// could repeat the command.
+			request.Status.Job.Finished = true
+			request.Status.Job.Active = 0
+			setPGBackRestJobCondition(request, metav1.ConditionFalse, "JobDeleted",
+				fmt.Sprintf("Job %s was deleted before its outcome was recorded",
+					request.Status.Job.ID))
+			r.Recorder.Eventf(request, corev1.EventTypeWarning, "PGBackRestJobDeleted",
+				"Job %s of pgBackRest %s was deleted before its outcome was recorded",
+				request.Status.Job.ID, request.Spec.Operation)
+		}
+	}
+
+	// Start the oldest command that has not started. The status of each
+	// records whether or not it started, because its Job can be deleted.
+	var err error
+	for _, request := range requests {
+		if err != nil || pgBackRestJobFinished(request) ||
+			request.Status.Job != nil || jobsByRequest[request.Name] != nil {
+			continue
+		}
+		if running {
+			setPGBackRestJobCondition(request, metav1.ConditionUnknown, "Pending",
+				"Waiting for another PGBackRestJob to finish")
+			continue
+		}
+		running, err = r.startPGBackRestJob(ctx, cluster, request, serviceAccount, instances)
+	}
+
+	for i := range requests {
+		if err == nil && !equality.Semantic.DeepEqual(before[i].Status, requests[i].Status) {
+			err = errors.WithStack(
+				r.StatusWriter.Patch(ctx, requests[i], client.MergeFrom(before[i])))
+		}
+	}
+
+	return requeue, err
+}
+
+// comparePGBackRestJobs orders PGBackRestJobs by when they were created.
+func comparePGBackRestJobs(a, b *v1beta1.PGBackRestJob) int {
+	if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Name, b.Name)
+}
+
+// setPGBackRestJobCondition sets the condition that describes the outcome of request.
+func setPGBackRestJobCondition(request *v1beta1.PGBackRestJob,
+	status metav1.ConditionStatus, reason, message string,
+) {
+	meta.SetStatusCondition(&request.Status.Conditions, metav1.Condition{
+		ObservedGeneration: request.GetGeneration(),
+		Type:               ConditionPGBackRestJobSuccessful,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// setPGBackRestJobStatus records the progress of job in the status of request.
+func (r *Reconciler) setPGBackRestJobStatus(request *v1beta1.PGBackRestJob, job *batchv1.Job) {
+	completed, failed := jobCompleted(job), jobFailed(job)
+
+	request.Status.Job = &v1beta1.PGBackRestJobStatus{
+		ID:             job.Name,
+		Finished:       completed || failed,
+		StartTime:      job.Status.StartTime,
+		CompletionTime: job.Status.CompletionTime,
+		Active:         job.Status.Active,
+		Succeeded:      job.Status.Succeeded,
+		Failed:         job.Status.Failed,
+	}
+
+	switch {
+	case completed:
+		setPGBackRestJobCondition(request, metav1.ConditionTrue, "Succeeded",
+			fmt.Sprintf("pgBackRest %s completed successfully", request.Spec.Operation))
+		r.Recorder.Eventf(request, corev1.EventTypeNormal, "PGBackRestJobSucceeded",
+			"pgBackRest %s of repo %q completed successfully",
+			request.Spec.Operation, request.Spec.RepoName)
+	case failed:
+		setPGBackRestJobCondition(request, metav1.ConditionFalse, "Failed",
+			fmt.Sprintf("pgBackRest %s did not complete successfully: please check the logs of Job %s",
+				request.Spec.Operation, job.Name))
+		r.Recorder.Eventf(request, corev1.EventTypeWarning, "PGBackRestJobFailed",
+			"pgBackRest %s of repo %q did not complete successfully",
+			request.Spec.Operation, request.Spec.RepoName)
+	default:
+		setPGBackRestJobCondition(request, metav1.ConditionUnknown, "Running",
+			fmt.Sprintf("Running pgBackRest %s in Job %s", request.Spec.Operation, job.Name))
+	}
+}
+
+// +kubebuilder:rbac:groups="batch",resources="jobs",verbs={create,patch}
+
+// startPGBackRestJob creates the Job that runs the command of request when
+// cluster is ready for it. It returns true when the Job is created.
+func (r *Reconciler) startPGBackRestJob(ctx context.Context,
+	cluster *v1beta1.PostgresCluster, request *v1beta1.PGBackRestJob,
+	serviceAccount *corev1.ServiceAccount, instances *observedInstances,
+) (bool, error) {
+	repoName := request.Spec.RepoName
+
+	// Kubernetes adds the name of a Job to the labels of its Pods, and label
+	// values are limited to 63 characters. PGBackRestJobs created before their
+	// name was validated could be too long.
+	if name := naming.PGBackRestJobJob(request).Name; len(name) > 63 {
+		setPGBackRestJobCondition(request, metav1.ConditionFalse, "InvalidName",
+			fmt.Sprintf("The name of Job %q is longer than 63 characters: please use a shorter name.", name))
+		r.Recorder.Eventf(request, corev1.EventTypeWarning, "InvalidName",
+			"The name of Job %q is longer than 63 characters", name)
+		return false, nil
+	}
+
+	// Users specify the repo using the "repoName" field. The stanza is always
+	// the default. Since options can be set with or without an equals ('=')
+	// sign, check for both usage patterns.
+	for _, opt := range request.Spec.Options {
+		for _, name := range []string{"--repo", "--stanza"} {
+			if strings.Contains(opt, name+"=") || strings.Contains(opt, name+" ") {
+				setPGBackRestJobCondition(request, metav1.ConditionFalse, "InvalidOptions",
+					fmt.Sprintf("Option %q is not allowed: please use the 'repoName' field instead.", name))
+				r.Recorder.Eventf(request, corev1.EventTypeWarning, "InvalidOptions",
+					"Option %q is not allowed for repo %q", name, repoName)
+				return false, nil
+			}
+		}
+	}
+
+	var repo *v1beta1.PGBackRestRepo
+	for i := range cluster.Spec.Backups.PGBackRest.Repos {
+		if cluster.Spec.Backups.PGBackRest.Repos[i].Name == repoName {
+			repo = &cluster.Spec.Backups.PGBackRest.Repos[i]
+		}
+	}
+	if repo == nil {
+		setPGBackRestJobCondition(request, metav1.ConditionUnknown, "Pending",
+			fmt.Sprintf("PostgresCluster %q does not have a repo named %q defined",
+				cluster.Name, repoName))
+		return false, nil
+	}
+
+	var stanzaCreated bool
+	for _, status := range cluster.Status.PGBackRest.Repos {
+		if status.Name == repoName {
+			stanzaCreated = status.StanzaCreated
+		}
+	}
+
+	// pgBackRest connects to a PostgreSQL instance that is not in recovery to
+	// take a backup or upgrade a stanza.
+	var clusterWritable bool
+	for _, instance := range instances.forCluster {
+		if writable, known := instance.IsWritable(); writable && known {
+			clusterWritable = true
+		}
+	}
+
+	// Wait for the replica create backup, since only one command can run at a
+	// time, and for a dedicated repository host, if any.
+	replicaCreate := meta.FindStatusCondition(cluster.Status.Conditions, ConditionReplicaCreate)
+	repoHost := meta.FindStatusCondition(cluster.Status.Conditions, ConditionRepoHostReady)
+
+	switch {
+	case !stanzaCreated:
+		setPGBackRestJobCondition(request, metav1.ConditionUnknown, "Pending",
+			fmt.Sprintf("Waiting for the stanza of repo %q to be created", repoName))
+		return false, nil
+	case !clusterWritable && (request.Spec.Operation == "backup" ||
+		request.Spec.Operation == "stanza-upgrade"):
+		setPGBackRestJobCondition(request, metav1.ConditionUnknown, "Pending",
+			"Waiting for a writable PostgreSQL instance")
+		return false, nil
+	case replicaCreate == nil || replicaCreate.Status != metav1.ConditionTrue,
+		pgbackrest.RepoHostVolumeDefined(cluster) &&
+			(repoHost == nil || repoHost.Status != metav1.ConditionTrue):
+		setPGBackRestJobCondition(request, metav1.ConditionUnknown, "Pending",
+			"Waiting for pgBackRest to be ready")
+		return false, nil
+	}
+
+	job := r.generatePGBackRestJob(ctx, cluster, *repo, request, serviceAccount.GetName())
+	if err := r.setControllerReference(cluster, job); err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	// Delete the Job along with the PGBackRestJob that requested it.
+	if err := controllerutil.SetOwnerReference(request, job, runtime.Scheme); err != nil {
+		return false, errors.WithStack(err)
+	}
+	if err := r.apply(ctx, job); err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	// Replace any Pending condition so that the time of this one is when the
+	// Job was created.
+	request.Status.Job = &v1beta1.PGBackRestJobStatus{ID: job.Name}
+	meta.RemoveStatusCondition(&request.Status.Conditions, ConditionPGBackRestJobSuccessful)
+	setPGBackRestJobCondition(request, metav1.ConditionUnknown, "Running",
+		fmt.Sprintf("Running pgBackRest %s in Job %s", request.Spec.Operation, job.Name))
+	r.Recorder.Eventf(request, corev1.EventTypeNormal, "PGBackRestJobStarted",
+		"Started pgBackRest %s of repo %q in Job %s",
+		request.Spec.Operation, repoName, job.Name)
+
+	return true, nil
+}
+
+// generatePGBackRestJob returns the Job that runs the command of request
+// against repo of cluster.
+func (r *Reconciler) generatePGBackRestJob(ctx context.Context,
+	cluster *v1beta1.PostgresCluster, repo v1beta1.PGBackRestRepo,
+	request *v1beta1.PGBackRestJob, serviceAccountName string,
+) *batchv1.Job {
+	job := &batchv1.Job{ObjectMeta: naming.PGBackRestJobJob(request)}
+	job.SetGroupVersionKind(batchv1.SchemeGroupVersion.WithKind("Job"))
+
+	job.Labels = naming.Merge(cluster.Spec.Metadata.GetLabelsOrNil(),
+		cluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
+		naming.PGBackRestJobLabels(cluster.Name, repo.Name, request.Name))
+	job.Annotations = naming.Merge(cluster.Spec.Metadata.GetAnnotationsOrNil(),
+		cluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil())
+
+	if request.Spec.Operation == "backup" {
+		job.Spec = *r.generateBackupJobSpecIntent(ctx, cluster, repo,
+			serviceAccountName, job.Labels, job.Annotations, request.Spec.Options...)
+	} else {
+		job.Spec = *r.generateRepoJobSpecIntent(cluster, repo, request.Spec.Operation,
+			serviceAccountName, job.Labels, job.Annotations, request.Spec.Options...)
+	}
+
+	// Keep the Job until its outcome is recorded. It is deleted along with
+	// the PGBackRestJob that owns it.
+	job.Spec.TTLSecondsAfterFinished = nil
+
+	return job
+}
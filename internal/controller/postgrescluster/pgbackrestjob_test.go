@@ -0,0 +1,364 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/events"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestGeneratePGBackRestJob(t *testing.T) {
+	ctx := context.Background()
+	r := &Reconciler{}
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Namespace, cluster.Name = "ns1", "hippo"
+	repo := v1beta1.PGBackRestRepo{Name: "repo2", Volume: &v1beta1.RepoPVC{}}
+
+	request := &v1beta1.PGBackRestJob{}
+	request.Namespace, request.Name = "ns1", "adhoc"
+	request.Spec.RepoName = "repo2"
+
+	env := func(job *batchv1.Job) map[string]string {
+		values := map[string]string{}
+		for _, v := range job.Spec.Template.Spec.Containers[0].Env {
+			values[v.Name] = v.Value
+		}
+		return values
+	}
+
+	t.Run("Backup", func(t *testing.T) {
+		request := request.DeepCopy()
+		request.Spec.Operation = "backup"
+		request.Spec.Options = []string{"--type=full"}
+
+		job := r.generatePGBackRestJob(ctx, cluster, repo, request, "sa")
+		assert.Equal(t, job.Namespace, "ns1")
+		assert.Equal(t, job.Name, "adhoc-pgbackrest")
+		assert.DeepEqual(t, job.Labels, map[string]string{
+			naming.LabelCluster:        "hippo",
+			naming.LabelPGBackRest:     "",
+			naming.LabelPGBackRestRepo: "repo2",
+			naming.LabelPGBackRestJob:  "adhoc",
+		})
+		assert.DeepEqual(t, job.Spec.Template.Labels, job.Labels)
+		assert.Equal(t, job.Spec.Template.Spec.ServiceAccountName, "sa")
+
+		assert.Equal(t, env(job)["COMMAND"], "backup")
+		assert.Equal(t, env(job)["COMMAND_OPTS"], "--stanza=db --repo=2 --type=full")
+	})
+
+	t.Run("Expire", func(t *testing.T) {
+		request := request.DeepCopy()
+		request.Spec.Operation = "expire"
+
+		job := r.generatePGBackRestJob(ctx, cluster, repo, request, "sa")
+		assert.Equal(t, env(job)["COMMAND"], "expire")
+		assert.Equal(t, env(job)["COMMAND_OPTS"], "--stanza=db --repo=2")
+	})
+
+	t.Run("StanzaUpgrade", func(t *testing.T) {
+		request := request.DeepCopy()
+		request.Spec.Operation = "stanza-upgrade"
+
+		job := r.generatePGBackRestJob(ctx, cluster, repo, request, "sa")
+		assert.Equal(t, env(job)["COMMAND"], "stanza-upgrade")
+		assert.Equal(t, env(job)["COMMAND_OPTS"], "--stanza=db",
+			"expected no repo option")
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Jobs = &v1beta1.BackupJobs{
+			TTLSecondsAfterFinished: initialize.Int32(60),
+		}
+
+		for _, operation := range []string{"backup", "expire"} {
+			request := request.DeepCopy()
+			request.Spec.Operation = operation
+
+			job := r.generatePGBackRestJob(ctx, cluster, repo, request, "sa")
+			assert.Assert(t, job.Spec.TTLSecondsAfterFinished == nil,
+				"expected %s Job to remain until its outcome is recorded", operation)
+		}
+	})
+}
+
+func TestReconcilePGBackRestJobs(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Namespace, cluster.Name, cluster.UID = "ns1", "hippo", "hippo-uid"
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+		{Name: "repo1", Volume: &v1beta1.RepoPVC{}},
+	}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: true}},
+	}
+	cluster.Status.Conditions = []metav1.Condition{
+		{Type: ConditionReplicaCreate, Status: metav1.ConditionTrue},
+		{Type: ConditionRepoHostReady, Status: metav1.ConditionTrue},
+	}
+
+	writable := newObservedInstances(cluster, nil, []corev1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"status": `"role":"primary"`},
+			Labels: map[string]string{
+				naming.LabelCluster:  cluster.Name,
+				naming.LabelInstance: "hippo-abc",
+				naming.LabelRole:     naming.RolePatroniLeader,
+			},
+		},
+	}})
+
+	now := time.Now()
+	request := func(name, operation string, age time.Duration, options ...string) *v1beta1.PGBackRestJob {
+		request := &v1beta1.PGBackRestJob{}
+		request.Namespace, request.Name = "ns1", name
+		request.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		request.Spec.PostgresClusterName = cluster.Name
+		request.Spec.RepoName = "repo1"
+		request.Spec.Operation = operation
+		request.Spec.Options = options
+		return request
+	}
+
+	setup := func(t *testing.T, objects ...client.Object) (*Reconciler, client.Client, *events.Recorder) {
+		cc := fake.NewClientBuilder().WithScheme(runtime.Scheme).
+			WithStatusSubresource(&v1beta1.PGBackRestJob{}).
+			WithObjects(objects...).Build()
+		recorder := events.NewRecorder(t, runtime.Scheme)
+
+		return &Reconciler{
+			Reader:       cc,
+			Recorder:     recorder,
+			StatusWriter: client.WithFieldOwner(cc, t.Name()).Status(),
+			Writer:       client.WithFieldOwner(cc, t.Name()),
+		}, cc, recorder
+	}
+
+	condition := func(t testing.TB, cc client.Client, name string) *metav1.Condition {
+		request := &v1beta1.PGBackRestJob{}
+		assert.NilError(t, cc.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: name}, request))
+		return meta.FindStatusCondition(request.Status.Conditions, ConditionPGBackRestJobSuccessful)
+	}
+
+	reconcile := func(t testing.TB, r *Reconciler, cluster *v1beta1.PostgresCluster,
+		jobs []*batchv1.Job, sa *corev1.ServiceAccount, instances *observedInstances,
+	) time.Duration {
+		requeue, err := r.reconcilePGBackRestJobs(ctx, cluster, jobs, sa, instances)
+		assert.NilError(t, err)
+		return requeue
+	}
+
+	t.Run("OneAtATime", func(t *testing.T) {
+		r, cc, recorder := setup(t,
+			request("newer", "expire", time.Minute),
+			request("older", "backup", time.Hour, "--type=full"),
+			request("other", "backup", 2*time.Hour),
+		)
+
+		// PGBackRestJobs of other clusters are ignored.
+		other := &v1beta1.PGBackRestJob{}
+		assert.NilError(t, cc.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "other"}, other))
+		other.Spec.PostgresClusterName = "elephant"
+		assert.NilError(t, cc.Update(ctx, other))
+
+		sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "hippo-pgbackrest"}}
+		reconcile(t, r, cluster, nil, sa, writable)
+		assert.Assert(t, condition(t, cc, "other") == nil)
+
+		jobs := &batchv1.JobList{}
+		assert.NilError(t, cc.List(ctx, jobs))
+		assert.Equal(t, len(jobs.Items), 1, "expected only the oldest to start")
+
+		job := &jobs.Items[0]
+		assert.Equal(t, job.Name, "older-pgbackrest")
+		assert.Equal(t, job.Labels[naming.LabelPGBackRestJob], "older")
+		assert.Assert(t, metav1.IsControlledBy(job, cluster))
+		assert.Assert(t, cmp.Len(job.OwnerReferences, 2))
+		assert.Equal(t, job.OwnerReferences[1].Kind, "PGBackRestJob")
+		assert.Equal(t, job.OwnerReferences[1].Name, "older")
+
+		older := condition(t, cc, "older")
+		assert.Assert(t, older != nil)
+		assert.Equal(t, older.Status, metav1.ConditionUnknown)
+		assert.Equal(t, older.Reason, "Running")
+
+		newer := condition(t, cc, "newer")
+		assert.Assert(t, newer != nil)
+		assert.Equal(t, newer.Status, metav1.ConditionUnknown)
+		assert.Equal(t, newer.Reason, "Pending")
+
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "PGBackRestJobStarted")
+		assert.Equal(t, recorder.Events[0].Regarding.Name, "older")
+
+		// The Job is still running.
+		recorder.Events = nil
+		job.Status.Active = 1
+		reconcile(t, r, cluster, []*batchv1.Job{job}, sa, writable)
+		assert.NilError(t, cc.List(ctx, jobs))
+		assert.Equal(t, len(jobs.Items), 1)
+		assert.Equal(t, condition(t, cc, "newer").Reason, "Pending")
+		assert.Equal(t, len(recorder.Events), 0)
+
+		// The Job completes, and the next one starts.
+		job.Status.Active = 0
+		job.Status.Succeeded = 1
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type: batchv1.JobComplete, Status: corev1.ConditionTrue,
+		}}
+		reconcile(t, r, cluster, []*batchv1.Job{job}, sa, writable)
+
+		older = condition(t, cc, "older")
+		assert.Equal(t, older.Status, metav1.ConditionTrue)
+		assert.Equal(t, older.Reason, "Succeeded")
+
+		finished := &v1beta1.PGBackRestJob{}
+		assert.NilError(t, cc.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "older"}, finished))
+		assert.Assert(t, finished.Status.Job != nil)
+		assert.Equal(t, finished.Status.Job.ID, "older-pgbackrest")
+		assert.Assert(t, finished.Status.Job.Finished)
+		assert.Equal(t, finished.Status.Job.Succeeded, int32(1))
+
+		newer = condition(t, cc, "newer")
+		assert.Equal(t, newer.Reason, "Running")
+
+		assert.Equal(t, len(recorder.Events), 2)
+		assert.Equal(t, recorder.Events[0].Reason, "PGBackRestJobSucceeded")
+		assert.Equal(t, recorder.Events[1].Reason, "PGBackRestJobStarted")
+		assert.Equal(t, recorder.Events[1].Regarding.Name, "newer")
+
+		// The finished Job is deleted; nothing runs again.
+		recorder.Events = nil
+		second := &batchv1.Job{}
+		assert.NilError(t, cc.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "newer-pgbackrest"}, second))
+		assert.NilError(t, cc.Delete(ctx, job))
+		reconcile(t, r, cluster, []*batchv1.Job{second}, sa, writable)
+		assert.Equal(t, len(recorder.Events), 0)
+		assert.Equal(t, condition(t, cc, "older").Reason, "Succeeded")
+	})
+
+	t.Run("Deleted", func(t *testing.T) {
+		started := request("started", "backup", time.Hour)
+		started.Status.Job = &v1beta1.PGBackRestJobStatus{ID: "started-pgbackrest"}
+		setPGBackRestJobCondition(started, metav1.ConditionUnknown, "Running", "")
+
+		ran := request("ran", "expire", 2*time.Hour)
+		ran.Status.Job = &v1beta1.PGBackRestJobStatus{
+			ID: "ran-pgbackrest", StartTime: initialize.Pointer(metav1.NewTime(now)),
+		}
+		setPGBackRestJobCondition(ran, metav1.ConditionUnknown, "Running", "")
+
+		// This Job was created long ago but never observed.
+		lost := request("lost", "backup", 3*time.Hour)
+		lost.Status.Job = &v1beta1.PGBackRestJobStatus{ID: "lost-pgbackrest"}
+		setPGBackRestJobCondition(lost, metav1.ConditionUnknown, "Running", "")
+		lost.Status.Conditions[0].LastTransitionTime = metav1.NewTime(
+			time.Now().Add(-2 * pgBackRestJobGracePeriod))
+
+		r, cc, recorder := setup(t, started, ran, lost, request("waiting", "expire", time.Minute))
+		sa := &corev1.ServiceAccount{}
+
+		requeue := reconcile(t, r, cluster, nil, sa, writable)
+
+		jobs := &batchv1.JobList{}
+		assert.NilError(t, cc.List(ctx, jobs))
+		assert.Equal(t, len(jobs.Items), 0, "expected no Jobs to run again")
+
+		// The Job that ran is gone; its outcome is unknown.
+		assert.Equal(t, condition(t, cc, "ran").Status, metav1.ConditionFalse)
+		assert.Equal(t, condition(t, cc, "ran").Reason, "JobDeleted")
+		assert.Equal(t, len(recorder.Events), 2)
+		assert.Equal(t, recorder.Events[0].Reason, "PGBackRestJobDeleted")
+		assert.Equal(t, recorder.Events[1].Reason, "PGBackRestJobDeleted")
+
+		// The Job that was never observed is gone, too.
+		assert.Equal(t, condition(t, cc, "lost").Status, metav1.ConditionFalse)
+		assert.Equal(t, condition(t, cc, "lost").Reason, "JobDeleted")
+
+		// The Job that started has not been observed yet, so the next waits
+		// until the grace period passes.
+		assert.Equal(t, condition(t, cc, "started").Reason, "Running")
+		assert.Equal(t, condition(t, cc, "waiting").Reason, "Pending")
+		assert.Assert(t, requeue > 0 && requeue <= pgBackRestJobGracePeriod, "got %v", requeue)
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		long := strings.Repeat("x", 53)
+		r, cc, recorder := setup(t, request(long, "backup", time.Minute))
+		sa := &corev1.ServiceAccount{}
+
+		reconcile(t, r, cluster, nil, sa, writable)
+
+		invalid := condition(t, cc, long)
+		assert.Equal(t, invalid.Status, metav1.ConditionFalse)
+		assert.Equal(t, invalid.Reason, "InvalidName")
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "InvalidName")
+
+		jobs := &batchv1.JobList{}
+		assert.NilError(t, cc.List(ctx, jobs))
+		assert.Equal(t, len(jobs.Items), 0)
+	})
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		r, cc, recorder := setup(t, request("bad", "backup", time.Minute, "--repo=2"))
+		sa := &corev1.ServiceAccount{}
+
+		reconcile(t, r, cluster, nil, sa, writable)
+
+		bad := condition(t, cc, "bad")
+		assert.Equal(t, bad.Status, metav1.ConditionFalse)
+		assert.Equal(t, bad.Reason, "InvalidOptions")
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "InvalidOptions")
+
+		jobs := &batchv1.JobList{}
+		assert.NilError(t, cc.List(ctx, jobs))
+		assert.Equal(t, len(jobs.Items), 0)
+	})
+
+	t.Run("NotReady", func(t *testing.T) {
+		r, cc, _ := setup(t,
+			request("backup", "backup", time.Hour),
+			request("expire", "expire", time.Minute),
+		)
+		sa := &corev1.ServiceAccount{}
+
+		// A backup needs a writable instance; expire does not.
+		reconcile(t, r, cluster, nil, sa, &observedInstances{})
+		assert.Equal(t, condition(t, cc, "backup").Reason, "Pending")
+		assert.Assert(t, cmp.Contains(condition(t, cc, "backup").Message, "writable"))
+		assert.Equal(t, condition(t, cc, "expire").Reason, "Running")
+
+		// Nothing runs before the stanza exists.
+		r, cc, _ = setup(t, request("backup", "backup", time.Hour))
+		cluster := cluster.DeepCopy()
+		cluster.Status.PGBackRest.Repos[0].StanzaCreated = false
+
+		reconcile(t, r, cluster, nil, sa, writable)
+		assert.Equal(t, condition(t, cc, "backup").Reason, "Pending")
+		assert.Assert(t, cmp.Contains(condition(t, cc, "backup").Message, "stanza"))
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
@@ -29,10 +30,12 @@ import (
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
-// reconcilePGBouncer writes the objects necessary to run a PgBouncer Pod.
+// reconcilePGBouncer writes the objects necessary to run a PgBouncer Pod. The
+// issuedCertificate, when not nil, is used for clients unless there is a custom
+// TLS secret.
 func (r *Reconciler) reconcilePGBouncer(
 	ctx context.Context, cluster *v1beta1.PostgresCluster, instances *observedInstances,
-	primaryCertificate *corev1.SecretProjection,
+	primaryCertificate, issuedCertificate *corev1.SecretProjection,
 	root *pki.RootCertificateAuthority,
 ) error {
 	var (
@@ -40,9 +43,18 @@ func (r *Reconciler) reconcilePGBouncer(
 		secret    *corev1.Secret
 	)
 
+	frontendCertificate := issuedCertificate
+	if cluster.Spec.Proxy != nil && cluster.Spec.Proxy.PGBouncer != nil &&
+		cluster.Spec.Proxy.PGBouncer.CustomTLSSecret != nil {
+		frontendCertificate = cluster.Spec.Proxy.PGBouncer.CustomTLSSecret
+	}
+
 	service, err := r.reconcilePGBouncerService(ctx, cluster)
 	if err == nil {
-		secret, err = r.reconcilePGBouncerSecret(ctx, cluster, root, service)
+		_, err = r.reconcilePGBouncerReadOnlyService(ctx, cluster)
+	}
+	if err == nil {
+		secret, err = r.reconcilePGBouncerSecret(ctx, cluster, root, frontendCertificate, service)
 	}
 	logfile := setPGBouncerLogfile(cluster)
 	if err == nil {
@@ -50,7 +62,8 @@ func (r *Reconciler) reconcilePGBouncer(
 		configmap, err = r.reconcilePGBouncerConfigMap(ctx, cluster, config, logfile)
 	}
 	if err == nil {
-		err = r.reconcilePGBouncerDeployment(ctx, cluster, primaryCertificate, configmap, secret, logfile)
+		err = r.reconcilePGBouncerDeployment(ctx, cluster,
+			primaryCertificate, frontendCertificate, configmap, secret, logfile)
 	}
 	if err == nil {
 		err = r.reconcilePGBouncerPodDisruptionBudget(ctx, cluster)
@@ -226,7 +239,8 @@ func (r *Reconciler) reconcilePGBouncerInPostgreSQL(
 // reconcilePGBouncerSecret writes the Secret for a PgBouncer Pod.
 func (r *Reconciler) reconcilePGBouncerSecret(
 	ctx context.Context, cluster *v1beta1.PostgresCluster,
-	root *pki.RootCertificateAuthority, service *corev1.Service,
+	root *pki.RootCertificateAuthority, frontendCertificate *corev1.SecretProjection,
+	service *corev1.Service,
 ) (*corev1.Secret, error) {
 	existing := &corev1.Secret{ObjectMeta: naming.ClusterPGBouncer(cluster)}
 	err := errors.WithStack(
@@ -264,8 +278,25 @@ func (r *Reconciler) reconcilePGBouncerSecret(
 			naming.LabelRole:    naming.RolePGBouncer,
 		})
 
+	// Users of the admin console authenticate using the verifiers in their
+	// user Secrets. Skip any that do not exist yet.
+	verifiers := make(map[string]string)
+	if authn := cluster.Spec.Proxy.PGBouncer.Authentication; authn != nil && err == nil {
+		for _, username := range append(slices.Clone(authn.AdminUsers), authn.StatsUsers...) {
+			secret := &corev1.Secret{ObjectMeta: naming.PostgresUserSecret(cluster, username)}
+			err = errors.WithStack(client.IgnoreNotFound(
+				r.Reader.Get(ctx, client.ObjectKeyFromObject(secret), secret)))
+			if err != nil {
+				break
+			}
+			if v := secret.Data["verifier"]; len(v) > 0 {
+				verifiers[username] = string(v)
+			}
+		}
+	}
+
 	if err == nil {
-		err = pgbouncer.Secret(ctx, cluster, root, existing, service, intent)
+		err = pgbouncer.Secret(ctx, cluster, root, frontendCertificate, existing, service, verifiers, intent)
 	}
 	if err == nil {
 		err = errors.WithStack(r.apply(ctx, intent))
@@ -286,6 +317,43 @@ func (r *Reconciler) generatePGBouncerService(
 		return service, false, nil
 	}
 
+	if err := r.populatePGBouncerService(cluster, service, cluster.Spec.Proxy.PGBouncer.Service); err != nil {
+		return nil, true, err
+	}
+
+	err := errors.WithStack(r.setControllerReference(cluster, service))
+
+	return service, true, err
+}
+
+// generatePGBouncerReadOnlyService returns a v1.Service that exposes the
+// read-only pools of PgBouncer pods. The ServiceType comes from the read-only
+// section of the cluster proxy spec.
+func (r *Reconciler) generatePGBouncerReadOnlyService(
+	cluster *v1beta1.PostgresCluster) (*corev1.Service, bool, error,
+) {
+	service := &corev1.Service{ObjectMeta: naming.ClusterPGBouncerReadOnly(cluster)}
+	service.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Service"))
+
+	if cluster.Spec.Proxy == nil || cluster.Spec.Proxy.PGBouncer == nil ||
+		cluster.Spec.Proxy.PGBouncer.ReadOnly == nil {
+		return service, false, nil
+	}
+
+	if err := r.populatePGBouncerService(cluster, service, cluster.Spec.Proxy.PGBouncer.ReadOnly.Service); err != nil {
+		return nil, true, err
+	}
+
+	err := errors.WithStack(r.setControllerReference(cluster, service))
+
+	return service, true, err
+}
+
+// populatePGBouncerService fills service with the metadata and ports that
+// expose PgBouncer pods according to spec.
+func (r *Reconciler) populatePGBouncerService(
+	cluster *v1beta1.PostgresCluster, service *corev1.Service, spec *v1beta1.ServiceSpec,
+) error {
 	service.Annotations = naming.Merge(
 		cluster.Spec.Metadata.GetAnnotationsOrNil(),
 		cluster.Spec.Proxy.PGBouncer.Metadata.GetAnnotationsOrNil())
@@ -293,7 +361,7 @@ func (r *Reconciler) generatePGBouncerService(
 		cluster.Spec.Metadata.GetLabelsOrNil(),
 		cluster.Spec.Proxy.PGBouncer.Metadata.GetLabelsOrNil())
 
-	if spec := cluster.Spec.Proxy.PGBouncer.Service; spec != nil {
+	if spec != nil {
 		service.Annotations = naming.Merge(service.Annotations,
 			spec.Metadata.GetAnnotationsOrNil())
 		service.Labels = naming.Merge(service.Labels,
@@ -325,7 +393,7 @@ func (r *Reconciler) generatePGBouncerService(
 		TargetPort: intstr.FromString(naming.PortPGBouncer),
 	}
 
-	if spec := cluster.Spec.Proxy.PGBouncer.Service; spec == nil {
+	if spec == nil {
 		service.Spec.Type = corev1.ServiceTypeClusterIP
 	} else {
 		service.Spec.Type = corev1.ServiceType(spec.Type)
@@ -339,7 +407,7 @@ func (r *Reconciler) generatePGBouncerService(
 				// and event could potentially be removed in favor of that validation
 				r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "MisconfiguredClusterIP",
 					"NodePort cannot be set with type ClusterIP on Service %q", service.Name)
-				return nil, true, fmt.Errorf("NodePort cannot be set with type ClusterIP on Service %q", service.Name)
+				return fmt.Errorf("NodePort cannot be set with type ClusterIP on Service %q", service.Name)
 			}
 			servicePort.NodePort = *spec.NodePort
 		}
@@ -356,9 +424,7 @@ func (r *Reconciler) generatePGBouncerService(
 	}
 	service.Spec.Ports = []corev1.ServicePort{servicePort}
 
-	err := errors.WithStack(r.setControllerReference(cluster, service))
-
-	return service, true, err
+	return nil
 }
 
 // +kubebuilder:rbac:groups="",resources="services",verbs={get}
@@ -368,7 +434,23 @@ func (r *Reconciler) generatePGBouncerService(
 func (r *Reconciler) reconcilePGBouncerService(
 	ctx context.Context, cluster *v1beta1.PostgresCluster,
 ) (*corev1.Service, error) {
-	service, specified, err := r.generatePGBouncerService(cluster)
+	return r.reconcilePGBouncerServiceFrom(ctx, cluster, r.generatePGBouncerService)
+}
+
+// reconcilePGBouncerReadOnlyService writes the Service that resolves to the
+// read-only pools of PgBouncer.
+func (r *Reconciler) reconcilePGBouncerReadOnlyService(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) (*corev1.Service, error) {
+	return r.reconcilePGBouncerServiceFrom(ctx, cluster, r.generatePGBouncerReadOnlyService)
+}
+
+// reconcilePGBouncerServiceFrom writes or deletes the Service from generate.
+func (r *Reconciler) reconcilePGBouncerServiceFrom(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	generate func(*v1beta1.PostgresCluster) (*corev1.Service, bool, error),
+) (*corev1.Service, error) {
+	service, specified, err := generate(cluster)
 
 	if err == nil && !specified {
 		// PgBouncer is disabled; delete the Service if it exists. Check the client
@@ -390,7 +472,7 @@ func (r *Reconciler) reconcilePGBouncerService(
 // generatePGBouncerDeployment returns an appsv1.Deployment that runs PgBouncer pods.
 func (r *Reconciler) generatePGBouncerDeployment(
 	ctx context.Context, cluster *v1beta1.PostgresCluster,
-	primaryCertificate *corev1.SecretProjection,
+	primaryCertificate, frontendCertificate *corev1.SecretProjection,
 	configmap *corev1.ConfigMap, secret *corev1.Secret,
 	logfile string,
 ) (*appsv1.Deployment, bool, error) {
@@ -499,7 +581,8 @@ func (r *Reconciler) generatePGBouncerDeployment(
 	err := errors.WithStack(r.setControllerReference(cluster, deploy))
 
 	if err == nil {
-		pgbouncer.Pod(ctx, cluster, configmap, primaryCertificate, secret, &deploy.Spec.Template, logfile)
+		pgbouncer.Pod(ctx, cluster, configmap, primaryCertificate, frontendCertificate,
+			secret, &deploy.Spec.Template, logfile)
 	}
 
 	// Add tmp directory and volume for log files
@@ -524,12 +607,12 @@ func (r *Reconciler) generatePGBouncerDeployment(
 // reconcilePGBouncerDeployment writes the Deployment that runs PgBouncer.
 func (r *Reconciler) reconcilePGBouncerDeployment(
 	ctx context.Context, cluster *v1beta1.PostgresCluster,
-	primaryCertificate *corev1.SecretProjection,
+	primaryCertificate, frontendCertificate *corev1.SecretProjection,
 	configmap *corev1.ConfigMap, secret *corev1.Secret,
 	logfile string,
 ) error {
 	deploy, specified, err := r.generatePGBouncerDeployment(
-		ctx, cluster, primaryCertificate, configmap, secret, logfile)
+		ctx, cluster, primaryCertificate, frontendCertificate, configmap, secret, logfile)
 
 	// Set observations whether the deployment exists or not.
 	defer func() {
@@ -572,6 +655,17 @@ func (r *Reconciler) reconcilePGBouncerDeployment(
 		return client.IgnoreNotFound(err)
 	}
 
+	// Pause an existing Deployment outside of maintenance windows so changes
+	// to its Pod template wait to roll out. It still scales while paused.
+	// A new Deployment cannot start any Pods while paused.
+	// - https://docs.k8s.io/concepts/workloads/controllers/deployment/#pausing-and-resuming-a-deployment
+	if err == nil && !maintenanceAllowed(cluster) {
+		existing := &appsv1.Deployment{}
+		err = errors.WithStack(r.Reader.Get(ctx, client.ObjectKeyFromObject(deploy), existing))
+		deploy.Spec.Paused = err == nil
+		err = client.IgnoreNotFound(err)
+	}
+
 	if err == nil {
 		err = errors.WithStack(r.apply(ctx, deploy))
 	}
@@ -254,6 +254,74 @@ ownerReferences:
 	}
 }
 
+func TestGeneratePGBouncerReadOnlyService(t *testing.T) {
+	reconciler := &Reconciler{
+		Recorder: new(record.FakeRecorder),
+	}
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Namespace = "ns5"
+	cluster.Name = "pg7"
+	cluster.Spec.Proxy = &v1beta1.PostgresProxySpec{
+		PGBouncer: &v1beta1.PGBouncerPodSpec{
+			Port:    initialize.Int32(9651),
+			Service: &v1beta1.ServiceSpec{Type: "LoadBalancer"},
+		},
+	}
+
+	t.Run("Unspecified", func(t *testing.T) {
+		service, specified, err := reconciler.generatePGBouncerReadOnlyService(cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, !specified)
+		assert.Equal(t, service.Name, "pg7-pgbouncer-ro")
+	})
+
+	t.Run("Specified", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Proxy.PGBouncer.ReadOnly = &v1beta1.PGBouncerReadOnlySpec{
+			Service: &v1beta1.ServiceSpec{
+				Type:     "NodePort",
+				NodePort: initialize.Int32(32003),
+				Metadata: &v1beta1.Metadata{Labels: map[string]string{"some": "label"}},
+			},
+		}
+
+		service, specified, err := reconciler.generatePGBouncerReadOnlyService(cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, specified)
+
+		assert.Assert(t, cmp.MarshalMatches(service.ObjectMeta, `
+labels:
+  postgres-operator.crunchydata.com/cluster: pg7
+  postgres-operator.crunchydata.com/role: pgbouncer
+  some: label
+name: pg7-pgbouncer-ro
+namespace: ns5
+ownerReferences:
+- apiVersion: postgres-operator.crunchydata.com/v1beta1
+  blockOwnerDeletion: true
+  controller: true
+  kind: PostgresCluster
+  name: pg7
+  uid: ""
+		`))
+
+		// The Service type comes from the read-only section, not the other Service.
+		assert.Equal(t, service.Spec.Type, corev1.ServiceTypeNodePort)
+		assert.DeepEqual(t, service.Spec.Selector, map[string]string{
+			"postgres-operator.crunchydata.com/cluster": "pg7",
+			"postgres-operator.crunchydata.com/role":    "pgbouncer",
+		})
+		assert.Assert(t, cmp.MarshalMatches(service.Spec.Ports, `
+- name: pgbouncer
+  nodePort: 32003
+  port: 9651
+  protocol: TCP
+  targetPort: pgbouncer
+		`))
+	})
+}
+
 func TestReconcilePGBouncerService(t *testing.T) {
 	ctx := context.Background()
 	_, cc := setupKubernetes(t)
@@ -378,7 +446,7 @@ func TestGeneratePGBouncerDeployment(t *testing.T) {
 			cluster := cluster.DeepCopy()
 			cluster.Spec.Proxy = spec
 
-			deploy, specified, err := reconciler.generatePGBouncerDeployment(ctx, cluster, nil, nil, nil, "")
+			deploy, specified, err := reconciler.generatePGBouncerDeployment(ctx, cluster, nil, nil, nil, nil, "")
 			assert.NilError(t, err)
 			assert.Assert(t, !specified)
 
@@ -411,7 +479,7 @@ namespace: ns3
 		}
 
 		deploy, specified, err := reconciler.generatePGBouncerDeployment(
-			ctx, cluster, primary, configmap, secret, "")
+			ctx, cluster, primary, nil, configmap, secret, "")
 		assert.NilError(t, err)
 		assert.Assert(t, specified)
 
@@ -452,7 +520,7 @@ namespace: ns3
 
 	t.Run("PodSpec", func(t *testing.T) {
 		deploy, specified, err := reconciler.generatePGBouncerDeployment(
-			ctx, cluster, primary, configmap, secret, "")
+			ctx, cluster, primary, nil, configmap, secret, "")
 		assert.NilError(t, err)
 		assert.Assert(t, specified)
 
@@ -499,7 +567,7 @@ topologySpreadConstraints:
 			cluster.Spec.DisableDefaultPodScheduling = initialize.Bool(true)
 
 			deploy, specified, err := reconciler.generatePGBouncerDeployment(
-				ctx, cluster, primary, configmap, secret, "")
+				ctx, cluster, primary, nil, configmap, secret, "")
 			assert.NilError(t, err)
 			assert.Assert(t, specified)
 
@@ -517,7 +585,7 @@ topologySpreadConstraints:
 		}
 
 		deploy, specified, err := reconciler.generatePGBouncerDeployment(
-			ctx, cluster, primary, configmap, secret, "")
+			ctx, cluster, primary, nil, configmap, secret, "")
 
 		assert.NilError(t, err)
 		assert.Assert(t, specified)
@@ -6,14 +6,20 @@ package postgrescluster
 
 import (
 	"context"
+	"strings"
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/crunchydata/postgres-operator/internal/kubernetes"
 	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/pki"
+	"github.com/crunchydata/postgres-operator/internal/postgres"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
@@ -32,12 +38,19 @@ const (
 // to being expired, formatted incorrectly, etc.
 // If it is bad for some reason, a new root certificate is
 // generated for use.
+//
+// The root is rotated in stages according to the [naming.RootCertificateRotation]
+// annotation on the secret. Clients first trust a bundle of the current and
+// next roots, then certificates are signed by the next root while the
+// previous one is still trusted.
 func (r *Reconciler) reconcileRootCertificate(
 	ctx context.Context, cluster *v1beta1.PostgresCluster,
 ) (
 	*pki.RootCertificateAuthority, error,
 ) {
 	const keyCertificate, keyPrivateKey = "root.crt", "root.key"
+	const keyNextCertificate, keyNextPrivateKey = "next.crt", "next.key"
+	const keyPreviousCertificates = "previous.crt"
 
 	existing := &corev1.Secret{}
 	existing.Namespace, existing.Name = cluster.Namespace, naming.RootCertSecret
@@ -45,6 +58,8 @@ func (r *Reconciler) reconcileRootCertificate(
 		r.Reader.Get(ctx, client.ObjectKeyFromObject(existing), existing)))
 
 	root := &pki.RootCertificateAuthority{}
+	next := &pki.RootCertificateAuthority{}
+	previous := pki.Bundle{}
 
 	if err == nil {
 		// Unmarshal and validate the stored root. These first errors can
@@ -52,6 +67,9 @@ func (r *Reconciler) reconcileRootCertificate(
 		// correctly regenerated.
 		_ = root.Certificate.UnmarshalText(existing.Data[keyCertificate])
 		_ = root.PrivateKey.UnmarshalText(existing.Data[keyPrivateKey])
+		_ = next.Certificate.UnmarshalText(existing.Data[keyNextCertificate])
+		_ = next.PrivateKey.UnmarshalText(existing.Data[keyNextPrivateKey])
+		_ = previous.UnmarshalText(existing.Data[keyPreviousCertificates])
 
 		if !pki.RootIsValid(root) {
 			root, err = pki.NewRootCertificateAuthority()
@@ -59,6 +77,41 @@ func (r *Reconciler) reconcileRootCertificate(
 		}
 	}
 
+	switch existing.Annotations[naming.RootCertificateRotation] {
+	case "stage":
+		// Generate the next root and publish it alongside the current one.
+		// Nothing is signed by it until it is promoted.
+		if err == nil && !pki.RootIsValid(next) {
+			next, err = pki.NewRootCertificateAuthority()
+			err = errors.WithStack(err)
+
+			if err == nil {
+				r.Recorder.Event(cluster, corev1.EventTypeNormal, "RootCertificateStaged",
+					"Generated the next root certificate; it is trusted but not yet used")
+			}
+		}
+		if err == nil {
+			root.Trusted, previous = pki.Bundle{next.Certificate}, nil
+		}
+
+	case "promote":
+		// Sign with the next root and continue to trust the current one.
+		// Leaf certificates signed by the current root are regenerated.
+		if err == nil && pki.RootIsValid(next) {
+			previous = pki.Bundle{root.Certificate}
+			root = next
+
+			r.Recorder.Event(cluster, corev1.EventTypeNormal, "RootCertificatePromoted",
+				"Certificates are now signed by the next root certificate")
+		}
+		if err == nil {
+			root.Trusted, next = previous, nil
+		}
+
+	default:
+		next, previous = nil, nil
+	}
+
 	intent := &corev1.Secret{}
 	intent.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
 	intent.Namespace, intent.Name = cluster.Namespace, naming.RootCertSecret
@@ -85,19 +138,267 @@ func (r *Reconciler) reconcileRootCertificate(
 		intent.Data[keyPrivateKey], err = root.PrivateKey.MarshalText()
 		err = errors.WithStack(err)
 	}
+	if err == nil && next != nil {
+		intent.Data[keyNextCertificate], err = next.Certificate.MarshalText()
+		err = errors.WithStack(err)
+	}
+	if err == nil && next != nil {
+		intent.Data[keyNextPrivateKey], err = next.PrivateKey.MarshalText()
+		err = errors.WithStack(err)
+	}
+	if err == nil && len(previous) > 0 {
+		intent.Data[keyPreviousCertificates], err = previous.MarshalText()
+		err = errors.WithStack(err)
+	}
 	if err == nil {
 		err = errors.WithStack(r.apply(ctx, intent))
 	}
+	if err == nil {
+		recordCertificateExpiry(cluster, intent.Name, root.Certificate)
+	}
 
 	return root, err
 }
 
+// certManagerCertificate is the API of cert-manager Certificates.
+// - https://cert-manager.io/docs/reference/api-docs/#cert-manager.io/v1.Certificate
+var certManagerCertificate = kubernetes.API{
+	Group: "cert-manager.io", Version: "v1", Kind: "Certificate",
+}
+
+// +kubebuilder:rbac:groups="",resources="secrets",verbs={get}
+// +kubebuilder:rbac:groups="cert-manager.io",resources="certificates",verbs={get}
+// +kubebuilder:rbac:groups="cert-manager.io",resources="certificates",verbs={create,delete,patch}
+
+// issuedCertificates are the Secrets of cert-manager Certificates that have
+// been issued for a cluster. Each is nil until its Secret is issued.
+type issuedCertificates struct {
+	postgres, replication, pgbouncer *corev1.SecretProjection
+}
+
+// reconcileIssuedCertificates creates cert-manager Certificates for the
+// PostgreSQL server, replication client, and PgBouncer certificates of cluster
+// when it has a certificate issuer and cert-manager is installed. It returns
+// the Secrets that have been issued so they can be used in place of those the
+// operator generates. Issued Secrets are watched, so the cluster is reconciled
+// again when cert-manager writes them.
+func (r *Reconciler) reconcileIssuedCertificates(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) (issuedCertificates, error) {
+	var issued issuedCertificates
+	issuer := cluster.Spec.CertificateIssuer
+
+	// Clusters without cert-manager keep the certificates generated by the operator.
+	if !kubernetes.Has(ctx, certManagerCertificate) {
+		if issuer == nil {
+			meta.RemoveStatusCondition(&cluster.Status.Conditions, v1beta1.CertificatesIssued)
+		} else {
+			meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+				Type:    v1beta1.CertificatesIssued,
+				Status:  metav1.ConditionFalse,
+				Reason:  "CertManagerNotFound",
+				Message: "cert-manager is not installed; using certificates generated by the operator",
+
+				ObservedGeneration: cluster.GetGeneration(),
+			})
+		}
+		return issued, nil
+	}
+
+	// The server and replication certificates must be signed by the same
+	// authority, so they are issued only when neither is custom.
+	postgresEnabled := issuer != nil &&
+		cluster.Spec.CustomTLSSecret == nil &&
+		cluster.Spec.CustomReplicationClientTLSSecret == nil
+	pgbouncerEnabled := issuer != nil &&
+		cluster.Spec.Proxy != nil && cluster.Spec.Proxy.PGBouncer != nil &&
+		cluster.Spec.Proxy.PGBouncer.CustomTLSSecret == nil
+
+	serverNames := append(
+		naming.ServiceDNSNames(ctx, &corev1.Service{ObjectMeta: naming.ClusterPrimaryService(cluster)}),
+		naming.ServiceDNSNames(ctx, &corev1.Service{ObjectMeta: naming.ClusterReplicaService(cluster)})...)
+	serverNames = append(serverNames, instanceSetServiceDNSNames(ctx, cluster)...)
+
+	var pgbouncerFQDN string
+	var pgbouncerNames []string
+	if pgbouncerEnabled {
+		pgbouncerNames = naming.ServiceDNSNames(ctx, &corev1.Service{ObjectMeta: naming.ClusterPGBouncer(cluster)})
+
+		if cluster.Spec.Proxy.PGBouncer.ReadOnly != nil {
+			pgbouncerNames = append(pgbouncerNames, naming.ServiceDNSNames(ctx,
+				&corev1.Service{ObjectMeta: naming.ClusterPGBouncerReadOnly(cluster)})...)
+		}
+		pgbouncerFQDN = pgbouncerNames[0]
+	}
+
+	var err error
+	var enabled, pending []string
+
+	for _, certificate := range []struct {
+		enabled    bool
+		meta       metav1.ObjectMeta
+		label      string
+		commonName string
+		dnsNames   []string
+		usages     []any
+		issued     **corev1.SecretProjection
+	}{
+		{
+			enabled: postgresEnabled, meta: naming.IssuedPostgresTLSCertificate(cluster),
+			label: "postgres-tls", commonName: serverNames[0], dnsNames: serverNames,
+			usages: []any{"digital signature", "key encipherment", "server auth"},
+			issued: &issued.postgres,
+		},
+		{
+			// PostgreSQL maps the common name of a client certificate to a user.
+			enabled: postgresEnabled, meta: naming.IssuedReplicationClientCertificate(cluster),
+			label: "replication-client-tls", commonName: postgres.ReplicationUser,
+			usages: []any{"digital signature", "key encipherment", "client auth"},
+			issued: &issued.replication,
+		},
+		{
+			enabled: pgbouncerEnabled, meta: naming.IssuedPGBouncerCertificate(cluster),
+			label: "pgbouncer-tls", commonName: pgbouncerFQDN, dnsNames: pgbouncerNames,
+			usages: []any{"digital signature", "key encipherment", "server auth"},
+			issued: &issued.pgbouncer,
+		},
+	} {
+		if err != nil {
+			break
+		}
+
+		if !certificate.enabled {
+			existing := &unstructured.Unstructured{}
+			existing.SetGroupVersionKind(certManagerCertificate)
+			existing.SetNamespace(certificate.meta.Namespace)
+			existing.SetName(certificate.meta.Name)
+
+			err = errors.WithStack(client.IgnoreNotFound(
+				r.Reader.Get(ctx, client.ObjectKeyFromObject(existing), existing)))
+			if err == nil && existing.GetUID() != "" {
+				err = errors.WithStack(client.IgnoreNotFound(
+					r.deleteControlled(ctx, cluster, existing)))
+			}
+			continue
+		}
+
+		enabled = append(enabled, certificate.meta.Name)
+		intent := generateIssuedCertificate(cluster, certificate.meta, certificate.label,
+			certificate.commonName, certificate.dnsNames, certificate.usages)
+
+		err = errors.WithStack(r.setControllerReference(cluster, intent))
+		if err == nil {
+			err = errors.WithStack(r.apply(ctx, intent))
+		}
+
+		// Use the issued Secret once it has a certificate, key, and authority.
+		secret := &corev1.Secret{ObjectMeta: certificate.meta}
+		if err == nil {
+			err = errors.WithStack(client.IgnoreNotFound(
+				r.Reader.Get(ctx, client.ObjectKeyFromObject(secret), secret)))
+		}
+		if err == nil {
+			if len(secret.Data[clusterCertFile]) > 0 &&
+				len(secret.Data[clusterKeyFile]) > 0 &&
+				len(secret.Data[rootCertFile]) > 0 {
+				*certificate.issued = clusterCertSecretProjection(secret)
+
+				var issuedCertificate pki.Certificate
+				if issuedCertificate.UnmarshalText(secret.Data[clusterCertFile]) == nil {
+					recordCertificateExpiry(cluster, secret.Name, issuedCertificate)
+				}
+			} else {
+				pending = append(pending, certificate.meta.Name)
+			}
+		}
+	}
+
+	if err == nil {
+		condition := metav1.Condition{
+			Type:    v1beta1.CertificatesIssued,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Issued",
+			Message: "cert-manager issued " + strings.Join(enabled, ", "),
+
+			ObservedGeneration: cluster.GetGeneration(),
+		}
+		if len(pending) > 0 {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "Pending"
+			condition.Message = "Waiting for cert-manager to issue " + strings.Join(pending, ", ") +
+				"; using certificates generated by the operator"
+		}
+
+		if len(enabled) == 0 {
+			meta.RemoveStatusCondition(&cluster.Status.Conditions, condition.Type)
+		} else {
+			meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+		}
+	}
+
+	return issued, err
+}
+
+// generateIssuedCertificate returns a cert-manager Certificate that stores a
+// certificate for commonName and dnsNames in a Secret of the same name.
+func generateIssuedCertificate(
+	cluster *v1beta1.PostgresCluster, meta metav1.ObjectMeta, label string,
+	commonName string, dnsNames []string, usages []any,
+) *unstructured.Unstructured {
+	issuer := cluster.Spec.CertificateIssuer
+	labels := naming.Merge(
+		cluster.Spec.Metadata.GetLabelsOrNil(),
+		map[string]string{
+			naming.LabelCluster:            cluster.Name,
+			naming.LabelClusterCertificate: label,
+		})
+
+	// Unstructured objects contain only JSON types.
+	secretLabels := make(map[string]any, len(labels))
+	for k, v := range labels {
+		secretLabels[k] = v
+	}
+
+	spec := map[string]any{
+		"commonName": commonName,
+		"issuerRef": map[string]any{
+			"group": issuer.Group,
+			"kind":  issuer.Kind,
+			"name":  issuer.Name,
+		},
+		"secretName": meta.Name,
+		"secretTemplate": map[string]any{
+			"labels": secretLabels,
+		},
+		"usages": usages,
+	}
+	if len(dnsNames) > 0 {
+		names := make([]any, len(dnsNames))
+		for i := range dnsNames {
+			names[i] = dnsNames[i]
+		}
+		spec["dnsNames"] = names
+	}
+
+	certificate := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	certificate.SetGroupVersionKind(certManagerCertificate)
+	certificate.SetNamespace(meta.Namespace)
+	certificate.SetName(meta.Name)
+	certificate.SetLabels(labels)
+
+	if annotations := cluster.Spec.Metadata.GetAnnotationsOrNil(); len(annotations) > 0 {
+		certificate.SetAnnotations(naming.Merge(annotations))
+	}
+
+	return certificate
+}
+
 // +kubebuilder:rbac:groups="",resources="secrets",verbs={get}
 // +kubebuilder:rbac:groups="",resources="secrets",verbs={create,patch}
 
 // reconcileClusterCertificate first checks if a custom certificate
-// secret is configured. If so, that secret projection is returned.
-// Otherwise, a secret containing a generated leaf certificate, stored in
+// secret is configured. If so, that secret projection is returned. Next,
+// the issued projection is returned when it is not nil. Otherwise, a secret containing a generated leaf certificate, stored in
 // the relevant secret, has been created and is not 'bad' due to being
 // expired, formatted incorrectly, etc. If it is bad for any reason, a new
 // leaf certificate is generated using the current root certificate.
@@ -107,7 +408,7 @@ func (r *Reconciler) reconcileRootCertificate(
 func (r *Reconciler) reconcileClusterCertificate(
 	ctx context.Context, root *pki.RootCertificateAuthority,
 	cluster *v1beta1.PostgresCluster, primaryService *corev1.Service,
-	replicaService *corev1.Service,
+	replicaService *corev1.Service, issued *corev1.SecretProjection,
 ) (
 	*corev1.SecretProjection, error,
 ) {
@@ -116,6 +417,11 @@ func (r *Reconciler) reconcileClusterCertificate(
 		return cluster.Spec.CustomTLSSecret, nil
 	}
 
+	// if cert-manager issued a certificate, use that
+	if issued != nil {
+		return issued, nil
+	}
+
 	const keyCertificate, keyPrivateKey, rootCA = "tls.crt", "tls.key", "ca.crt"
 
 	existing := &corev1.Secret{ObjectMeta: naming.PostgresTLSSecret(cluster)}
@@ -124,6 +430,7 @@ func (r *Reconciler) reconcileClusterCertificate(
 
 	leaf := &pki.LeafCertificate{}
 	dnsNames := append(naming.ServiceDNSNames(ctx, primaryService), naming.ServiceDNSNames(ctx, replicaService)...)
+	dnsNames = append(dnsNames, instanceSetServiceDNSNames(ctx, cluster)...)
 	dnsFQDN := dnsNames[0]
 
 	if err == nil {
@@ -163,7 +470,7 @@ func (r *Reconciler) reconcileClusterCertificate(
 		err = errors.WithStack(err)
 	}
 	if err == nil {
-		intent.Data[rootCA], err = root.Certificate.MarshalText()
+		intent.Data[rootCA], err = root.Bundle().MarshalText()
 		err = errors.WithStack(err)
 	}
 
@@ -174,10 +481,26 @@ func (r *Reconciler) reconcileClusterCertificate(
 	if err == nil {
 		err = errors.WithStack(r.apply(ctx, intent))
 	}
+	if err == nil {
+		recordCertificateExpiry(cluster, intent.Name, leaf.Certificate)
+	}
 
 	return clusterCertSecretProjection(intent), err
 }
 
+// instanceSetServiceDNSNames returns the DNS names of the Services that expose
+// the replicas of instance sets in cluster.
+func instanceSetServiceDNSNames(ctx context.Context, cluster *v1beta1.PostgresCluster) []string {
+	var names []string
+	for i := range cluster.Spec.InstanceSets {
+		if set := &cluster.Spec.InstanceSets[i]; set.Service != nil {
+			names = append(names, naming.ServiceDNSNames(ctx,
+				&corev1.Service{ObjectMeta: naming.InstanceSetReplicaService(cluster, set)})...)
+		}
+	}
+	return names
+}
+
 // +kubebuilder:rbac:groups="",resources="secrets",verbs={get}
 // +kubebuilder:rbac:groups="",resources="secrets",verbs={create,patch}
 
@@ -6,6 +6,7 @@ package postgrescluster
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"reflect"
@@ -15,12 +16,20 @@ import (
 	"gotest.tools/v3/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/kubernetes"
 	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/pki"
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/events"
 	"github.com/crunchydata/postgres-operator/internal/testing/require"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
@@ -160,6 +169,69 @@ func TestReconcileCerts(t *testing.T) {
 			assert.DeepEqual(t, *fromSecret, returnedRoot.Certificate)
 		})
 
+		t.Run("root certificate rotates in stages", func(t *testing.T) {
+			recorder := events.NewRecorder(t, runtime.Scheme)
+			r := *r
+			r.Recorder = recorder
+
+			annotate := func(value *string) {
+				patch, err := json.Marshal(map[string]any{
+					"metadata": map[string]any{
+						"annotations": map[string]any{naming.RootCertificateRotation: value},
+					},
+				})
+				assert.NilError(t, err)
+				assert.NilError(t, tClient.Patch(ctx, rootSecret, client.RawPatch(types.MergePatchType, patch)))
+			}
+
+			current, err := r.reconcileRootCertificate(ctx, cluster1)
+			assert.NilError(t, err)
+			assert.Equal(t, len(current.Trusted), 0)
+
+			// The next root is published alongside the current one.
+			annotate(initialize.String("stage"))
+			staged, err := r.reconcileRootCertificate(ctx, cluster1)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, staged.Certificate, current.Certificate)
+			assert.Equal(t, len(staged.Trusted), 1)
+			assert.Assert(t, !staged.Trusted[0].Equal(current.Certificate))
+			assert.Equal(t, len(recorder.Events), 1)
+			assert.Equal(t, recorder.Events[0].Reason, "RootCertificateStaged")
+
+			next, err := getCertFromSecret(ctx, tClient, naming.RootCertSecret, namespace, "next.crt")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, *next, staged.Trusted[0])
+
+			// The same next root is published until it is promoted.
+			again, err := r.reconcileRootCertificate(ctx, cluster2)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, again.Bundle(), staged.Bundle())
+			assert.Equal(t, len(recorder.Events), 1)
+
+			// The next root signs while the previous one is still published.
+			annotate(initialize.String("promote"))
+			promoted, err := r.reconcileRootCertificate(ctx, cluster1)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, promoted.Certificate, *next)
+			assert.DeepEqual(t, promoted.Trusted, pki.Bundle{current.Certificate})
+			assert.Equal(t, len(recorder.Events), 2)
+			assert.Equal(t, recorder.Events[1].Reason, "RootCertificatePromoted")
+
+			assert.NilError(t, tClient.Get(ctx, client.ObjectKeyFromObject(rootSecret), rootSecret))
+			assert.Assert(t, rootSecret.Data["next.crt"] == nil)
+			assert.Assert(t, rootSecret.Data["previous.crt"] != nil)
+
+			// The previous root is dropped when rotation is done.
+			annotate(nil)
+			done, err := r.reconcileRootCertificate(ctx, cluster1)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, done.Certificate, *next)
+			assert.Equal(t, len(done.Trusted), 0)
+
+			assert.NilError(t, tClient.Get(ctx, client.ObjectKeyFromObject(rootSecret), rootSecret))
+			assert.Assert(t, rootSecret.Data["previous.crt"] == nil)
+		})
+
 	})
 
 	t.Run("check leaf certificate reconciliation", func(t *testing.T) {
@@ -283,16 +355,29 @@ func TestReconcileCerts(t *testing.T) {
 		assert.NilError(t, err)
 
 		t.Run("check standard secret projection", func(t *testing.T) {
-			secretCertProj, err := r.reconcileClusterCertificate(ctx, initialRoot, cluster1, primaryService, replicaService)
+			secretCertProj, err := r.reconcileClusterCertificate(ctx, initialRoot, cluster1, primaryService, replicaService, nil)
 			assert.NilError(t, err)
 
 			assert.DeepEqual(t, testSecretProjection, secretCertProj)
 		})
 
 		t.Run("check custom secret projection", func(t *testing.T) {
-			customSecretCertProj, err := r.reconcileClusterCertificate(ctx, initialRoot, cluster2, primaryService, replicaService)
+			customSecretCertProj, err := r.reconcileClusterCertificate(ctx, initialRoot, cluster2, primaryService, replicaService, nil)
+			assert.NilError(t, err)
+
+			assert.DeepEqual(t, customSecretProjection, customSecretCertProj)
+		})
+
+		t.Run("check issued secret projection", func(t *testing.T) {
+			issued := clusterCertSecretProjection(&corev1.Secret{ObjectMeta: naming.IssuedPostgresTLSCertificate(cluster1)})
+
+			issuedCertProj, err := r.reconcileClusterCertificate(ctx, initialRoot, cluster1, primaryService, replicaService, issued)
 			assert.NilError(t, err)
+			assert.DeepEqual(t, issued, issuedCertProj)
 
+			// A custom secret takes precedence.
+			customSecretCertProj, err := r.reconcileClusterCertificate(ctx, initialRoot, cluster2, primaryService, replicaService, issued)
+			assert.NilError(t, err)
 			assert.DeepEqual(t, customSecretProjection, customSecretCertProj)
 		})
 
@@ -307,7 +392,7 @@ func TestReconcileCerts(t *testing.T) {
 			testSecretProjection := clusterCertSecretProjection(testSecret)
 
 			// reconcile the secret project using the normal process
-			customSecretCertProj, err := r.reconcileClusterCertificate(ctx, initialRoot, cluster2, primaryService, replicaService)
+			customSecretCertProj, err := r.reconcileClusterCertificate(ctx, initialRoot, cluster2, primaryService, replicaService, nil)
 			assert.NilError(t, err)
 
 			// results should be the same
@@ -336,7 +421,7 @@ func TestReconcileCerts(t *testing.T) {
 			assert.NilError(t, err)
 
 			// pass in the new root, which should result in a new cluster cert
-			_, err = r.reconcileClusterCertificate(ctx, returnedRoot, cluster1, primaryService, replicaService)
+			_, err = r.reconcileClusterCertificate(ctx, returnedRoot, cluster1, primaryService, replicaService, nil)
 			assert.NilError(t, err)
 
 			// get the new cluster cert secret
@@ -396,3 +481,105 @@ func getCertFromSecret(
 	fromSecret := &pki.Certificate{}
 	return fromSecret, fromSecret.UnmarshalText(secretCRT)
 }
+
+func TestGenerateIssuedCertificate(t *testing.T) {
+	cluster := testCluster()
+	cluster.Namespace = "ns1"
+	cluster.Spec.Metadata = &v1beta1.Metadata{
+		Labels: map[string]string{"some": "label"},
+	}
+	cluster.Spec.CertificateIssuer = &v1beta1.CertificateIssuerReference{
+		Name: "some-issuer", Kind: "ClusterIssuer", Group: "cert-manager.io",
+	}
+
+	certificate := generateIssuedCertificate(cluster,
+		naming.IssuedPostgresTLSCertificate(cluster), "postgres-tls",
+		"some.fqdn", []string{"some.fqdn", "some"}, []any{"server auth"})
+
+	assert.Assert(t, cmp.MarshalMatches(certificate, `
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  labels:
+    postgres-operator.crunchydata.com/cluster: hippo
+    postgres-operator.crunchydata.com/cluster-certificate: postgres-tls
+    some: label
+  name: hippo-cluster-tls
+  namespace: ns1
+spec:
+  commonName: some.fqdn
+  dnsNames:
+  - some.fqdn
+  - some
+  issuerRef:
+    group: cert-manager.io
+    kind: ClusterIssuer
+    name: some-issuer
+  secretName: hippo-cluster-tls
+  secretTemplate:
+    labels:
+      postgres-operator.crunchydata.com/cluster: hippo
+      postgres-operator.crunchydata.com/cluster-certificate: postgres-tls
+      some: label
+  usages:
+  - server auth
+	`))
+
+	t.Run("NoDNSNames", func(t *testing.T) {
+		certificate := generateIssuedCertificate(cluster,
+			naming.IssuedReplicationClientCertificate(cluster), "replication-client-tls",
+			"_crunchyrepl", nil, []any{"client auth"})
+
+		_, found, err := unstructured.NestedSlice(certificate.Object, "spec", "dnsNames")
+		assert.NilError(t, err)
+		assert.Assert(t, !found)
+	})
+}
+
+func TestReconcileIssuedCertificates(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := testCluster()
+	cluster.Namespace = "ns1"
+	cluster.Spec.CertificateIssuer = &v1beta1.CertificateIssuerReference{Name: "some-issuer"}
+
+	t.Run("NotInstalled", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		r := &Reconciler{Recorder: recorder}
+
+		cluster := cluster.DeepCopy()
+		for range 2 {
+			issued, err := r.reconcileIssuedCertificates(ctx, cluster)
+			assert.NilError(t, err)
+			assert.Equal(t, issued, issuedCertificates{})
+		}
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, v1beta1.CertificatesIssued)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Equal(t, condition.Reason, "CertManagerNotFound")
+		assert.Equal(t, len(recorder.Events), 0, "expected a condition rather than events")
+	})
+
+	t.Run("NoIssuer", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		r := &Reconciler{
+			Reader:   fake.NewClientBuilder().WithScheme(runtime.Scheme).Build(),
+			Recorder: recorder,
+		}
+		ctx := kubernetes.NewAPIContext(ctx, kubernetes.NewAPISet(certManagerCertificate))
+
+		cluster := cluster.DeepCopy()
+		cluster.Spec.CertificateIssuer = nil
+		cluster.Status.Conditions = []metav1.Condition{{
+			Type: v1beta1.CertificatesIssued, Status: metav1.ConditionFalse, Reason: "CertManagerNotFound",
+		}}
+
+		issued, err := r.reconcileIssuedCertificates(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Equal(t, issued, issuedCertificates{})
+		assert.Equal(t, len(recorder.Events), 0)
+		assert.Assert(t, cluster.Spec.CustomTLSSecret == nil)
+		assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions, v1beta1.CertificatesIssued) == nil)
+	})
+}
@@ -13,12 +13,15 @@ import (
 	"net"
 	"net/url"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -217,6 +220,9 @@ func (r *Reconciler) generatePostgresUserSecret(
 		intent.Data["verifier"] = existing.Data["verifier"]
 	}
 
+	// Discard the existing password when it is time for a new one.
+	rotation := generatePostgresPasswordRotation(spec, existing, intent, time.Now())
+
 	// When password is unset, generate a new one according to the specified policy.
 	if len(intent.Data["password"]) == 0 {
 		// NOTE: The tests around ASCII passwords are lacking. When changing
@@ -312,7 +318,9 @@ func (r *Reconciler) generatePostgresUserSecret(
 		}
 	}
 
-	intent.Annotations = cluster.Spec.Metadata.GetAnnotationsOrNil()
+	intent.Annotations = naming.Merge(
+		cluster.Spec.Metadata.GetAnnotationsOrNil(),
+		rotation)
 	intent.Labels = naming.Merge(
 		cluster.Spec.Metadata.GetLabelsOrNil(),
 		map[string]string{
@@ -326,6 +334,108 @@ func (r *Reconciler) generatePostgresUserSecret(
 	return intent, err
 }
 
+// generatePostgresPasswordRotation clears the password in intent when spec
+// calls for a new one, moving it to the "previous-password" key. It carries
+// a previous password from existing until its grace period ends. It returns
+// the annotations that track rotation of the password in intent.
+//
+// PostgreSQL accepts only one password per role, so a previous password
+// belongs to another login role named in the "previous-user" key. See
+// [postgres.WritePreviousPasswordsInPostgreSQL].
+func generatePostgresPasswordRotation(
+	spec *v1beta1.PostgresUserSpec, existing, intent *corev1.Secret, now time.Time,
+) map[string]string {
+	if spec.Rotation == nil {
+		return nil
+	}
+
+	var annotations map[string]string
+	if existing != nil {
+		annotations = existing.Annotations
+	}
+
+	trigger := annotations[naming.PostgresPasswordRotate]
+	handled := annotations[naming.PostgresPasswordRotation]
+	rotatedAt, err := time.Parse(time.RFC3339, annotations[naming.PostgresPasswordRotatedAt])
+	if err != nil {
+		// Start the clock on passwords that have never been rotated.
+		rotatedAt = now
+	}
+
+	due := trigger != "" && trigger != handled
+	if interval := spec.Rotation.Interval; interval != nil && !due {
+		due = !now.Before(rotatedAt.Add(interval.AsDuration().Duration))
+	}
+
+	grace := spec.Rotation.GracePeriod
+
+	if len(intent.Data["password"]) == 0 {
+		// A password is about to be generated for the first time.
+		rotatedAt = now
+	} else if due {
+		intent.Data["previous-password"] = intent.Data["password"]
+		intent.Data["previous-verifier"] = intent.Data["verifier"]
+		intent.Data["password"] = nil
+		intent.Data["verifier"] = nil
+		handled, rotatedAt = trigger, now
+	} else if grace != nil && existing != nil &&
+		now.Before(rotatedAt.Add(grace.AsDuration().Duration)) {
+		intent.Data["previous-password"] = existing.Data["previous-password"]
+		intent.Data["previous-verifier"] = existing.Data["previous-verifier"]
+	}
+
+	if grace == nil || grace.AsDuration().Duration == 0 ||
+		len(intent.Data["previous-password"]) == 0 {
+		// Remove a previous password that has no grace period.
+		delete(intent.Data, "previous-password")
+		delete(intent.Data, "previous-verifier")
+	} else {
+		intent.Data["previous-user"] = []byte(postgres.PreviousPasswordUser(spec.Name))
+	}
+
+	result := map[string]string{
+		naming.PostgresPasswordRotatedAt: rotatedAt.UTC().Format(time.RFC3339),
+	}
+	if handled != "" {
+		result[naming.PostgresPasswordRotation] = handled
+	}
+	return result
+}
+
+// postgresPasswordRequeue returns how long until the password in secret should
+// be rotated or its previous password removed. It returns zero when neither
+// will happen.
+func postgresPasswordRequeue(
+	spec *v1beta1.PostgresUserSpec, secret *corev1.Secret, now time.Time,
+) time.Duration {
+	if spec.Rotation == nil || secret == nil {
+		return 0
+	}
+
+	rotatedAt, err := time.Parse(time.RFC3339, secret.Annotations[naming.PostgresPasswordRotatedAt])
+	if err != nil {
+		return 0
+	}
+
+	var deadlines []time.Time
+	if interval := spec.Rotation.Interval; interval != nil {
+		deadlines = append(deadlines, rotatedAt.Add(interval.AsDuration().Duration))
+	}
+	if grace := spec.Rotation.GracePeriod; grace != nil && len(secret.Data["previous-password"]) > 0 {
+		deadlines = append(deadlines, rotatedAt.Add(grace.AsDuration().Duration))
+	}
+
+	var result time.Duration
+	for _, deadline := range deadlines {
+		// Wait at least a second to avoid a hot loop.
+		d := max(deadline.Sub(now), time.Second)
+		if result == 0 || d < result {
+			result = d
+		}
+	}
+	return result
+}
+
 // reconcilePostgresDatabases creates databases inside of PostgreSQL.
 func (r *Reconciler) reconcilePostgresDatabases(
 	ctx context.Context, cluster *v1beta1.PostgresCluster, instances *observedInstances,
@@ -372,6 +482,12 @@ func (r *Reconciler) reconcilePostgresDatabases(
 		}
 	}
 
+	// Databases in spec.databases are created along with their attributes
+	// by reconcilePostgresDatabaseSpecs.
+	for i := range cluster.Spec.Databases {
+		databases.Delete(cluster.Spec.Databases[i].Name)
+	}
+
 	var pgAuditOK, postgisInstallOK bool
 	create := func(ctx context.Context, exec postgres.Executor) error {
 		if pgAuditOK = pgaudit.EnableInPostgreSQL(ctx, exec) == nil; !pgAuditOK {
@@ -434,16 +550,175 @@ func (r *Reconciler) reconcilePostgresDatabases(
 	return err
 }
 
+// databaseCheckInterval is how often a database in spec.databases is compared
+// to its spec even when that spec has not changed.
+const databaseCheckInterval = time.Hour
+
+// reconcilePostgresDatabaseSpecs creates and configures the databases in
+// spec.databases and grants users access to those listed in their spec. It
+// records the outcome for each database in status and returns how long to wait
+// before trying those that failed again or checking the others for drift.
+func (r *Reconciler) reconcilePostgresDatabaseSpecs(
+	ctx context.Context, cluster *v1beta1.PostgresCluster, instances *observedInstances,
+) (time.Duration, error) {
+	const container = naming.ContainerDatabase
+	var podExecutor postgres.Executor
+
+	// Forget the status of databases that are no longer specified.
+	statuses := make([]v1beta1.PostgresDatabaseStatus, 0, len(cluster.Spec.Databases))
+	for _, spec := range cluster.Spec.Databases {
+		status := v1beta1.PostgresDatabaseStatus{Name: spec.Name}
+		for _, existing := range cluster.Status.Databases {
+			if existing.Name == spec.Name {
+				status = existing
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	if len(statuses) == 0 {
+		statuses = nil
+	}
+	cluster.Status.Databases = statuses
+
+	// Find the PostgreSQL instance that can execute SQL that writes system
+	// catalogs. When there is none, return early.
+	pod, _ := instances.writablePod(container)
+	if pod == nil {
+		return 0, nil
+	}
+
+	ctx = logging.NewContext(ctx, logging.FromContext(ctx).WithValues("pod", pod.Name))
+	podExecutor = func(
+		ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+	) error {
+		return r.PodExec(ctx, pod.Namespace, pod.Name, container, stdin, stdout, stderr, command...)
+	}
+
+	// Users are granted access to these databases here rather than when the
+	// users are written so that a database that cannot be created does not
+	// prevent changes to users. Users that specify their own grants are not
+	// granted anything here. When users are unspecified, the one created for
+	// the cluster is granted access to its database; see
+	// [Reconciler.reconcilePostgresUserSecrets].
+	specUsers := cluster.Spec.Users
+	if specUsers == nil && len(cluster.Name) <= 63 &&
+		regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`).MatchString(cluster.Name) {
+		specUsers = []v1beta1.PostgresUserSpec{{
+			Name:      cluster.Name,
+			Databases: []string{cluster.Name},
+		}}
+	}
+	grantees := make(map[string][]string, len(cluster.Spec.Databases))
+	for _, user := range specUsers {
+		if user.Grants != nil && user.Name != "postgres" {
+			continue
+		}
+		for _, database := range user.Databases {
+			grantees[database] = append(grantees[database], user.Name)
+		}
+	}
+
+	var requeue time.Duration
+	next := func(d time.Duration) {
+		if d > 0 && (requeue == 0 || d < requeue) {
+			requeue = d
+		}
+	}
+
+	now := time.Now()
+	for i := range cluster.Spec.Databases {
+		spec := &cluster.Spec.Databases[i]
+		status := &cluster.Status.Databases[i]
+		users := grantees[spec.Name]
+
+		// Calculate a hash of the SQL that should be executed in PostgreSQL.
+		revision, err := safeHash32(func(hasher io.Writer) error {
+			// Discard log messages about executing SQL.
+			_, err := postgres.WriteDatabaseInPostgreSQL(
+				logging.NewContext(ctx, logging.Discard()), func(
+					_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+				) error {
+					_, err := fmt.Fprint(hasher, command)
+					if err == nil && stdin != nil {
+						_, err = io.Copy(hasher, stdin)
+					}
+					return err
+				}, spec, users)
+			return err
+		})
+		if err != nil {
+			return requeue, err
+		}
+
+		if revision == status.Revision && status.CheckTime != nil {
+			// The necessary SQL has already been applied. Check it again
+			// periodically in case the database changed outside of spec.
+			if due := status.CheckTime.Add(databaseCheckInterval).Sub(now); due > 0 {
+				next(due)
+				continue
+			}
+		}
+
+		// Apply the necessary SQL to each database separately so that one
+		// failure does not prevent the others. Include the hash in any log
+		// messages.
+		log := logging.FromContext(ctx).WithValues("database", spec.Name, "revision", revision)
+		drift, err := postgres.WriteDatabaseInPostgreSQL(logging.NewContext(ctx, log), podExecutor, spec, users)
+
+		condition := metav1.Condition{
+			ObservedGeneration: cluster.GetGeneration(),
+			Type:               v1beta1.PostgresDatabaseReady,
+			Status:             metav1.ConditionTrue,
+			Reason:             v1beta1.PostgresDatabaseReasonApplied,
+			Message:            "Database is configured",
+		}
+
+		switch {
+		case err != nil:
+			// The owner of a database may be a user that does not exist yet,
+			// so try again soon.
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = v1beta1.PostgresDatabaseReasonFailed
+			condition.Message = err.Error()
+			status.Revision = ""
+			status.CheckTime = nil
+			next(10 * time.Second)
+
+			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "DatabaseFailed",
+				"Unable to configure database %q: %v", spec.Name, err)
+
+		case len(drift) > 0:
+			// These attributes cannot change once the database exists.
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = v1beta1.PostgresDatabaseReasonDrifted
+			condition.Message = "Database differs from spec: " + strings.Join(drift, ", ")
+			status.Revision = revision
+			status.CheckTime = initialize.Pointer(metav1.NewTime(now))
+			next(databaseCheckInterval)
+
+		default:
+			status.Revision = revision
+			status.CheckTime = initialize.Pointer(metav1.NewTime(now))
+			next(databaseCheckInterval)
+		}
+
+		meta.SetStatusCondition(&status.Conditions, condition)
+	}
+
+	return requeue, nil
+}
+
 // reconcilePostgresUsers writes the objects necessary to manage users and their
-// passwords in PostgreSQL.
+// passwords in PostgreSQL. It returns how long until a password should rotate.
 func (r *Reconciler) reconcilePostgresUsers(
 	ctx context.Context, cluster *v1beta1.PostgresCluster, instances *observedInstances,
-) error {
+) (time.Duration, error) {
 	r.validatePostgresUsers(cluster)
 
-	users, secrets, err := r.reconcilePostgresUserSecrets(ctx, cluster)
+	var requeue time.Duration
+	users, secrets, removed, err := r.reconcilePostgresUserSecrets(ctx, cluster)
 	if err == nil {
-		err = r.reconcilePostgresUsersInPostgreSQL(ctx, cluster, instances, users, secrets)
+		requeue, err = r.reconcilePostgresUsersInPostgreSQL(ctx, cluster, instances, users, secrets, removed)
 	}
 	if err == nil {
 		// Copy PostgreSQL users and passwords into pgAdmin. This is here because
@@ -452,7 +727,15 @@ func (r *Reconciler) reconcilePostgresUsers(
 		// are available here, too.
 		err = r.reconcilePGAdminUsers(ctx, cluster, users, secrets)
 	}
-	return err
+
+	now := time.Now()
+	for i := range users {
+		d := postgresPasswordRequeue(&users[i], secrets[users[i].Name], now)
+		if d > 0 && (requeue == 0 || d < requeue) {
+			requeue = d
+		}
+	}
+	return requeue, err
 }
 
 // validatePostgresUsers emits warnings when cluster.Spec.Users contains values
@@ -495,11 +778,12 @@ func (r *Reconciler) validatePostgresUsers(cluster *v1beta1.PostgresCluster) {
 // reconcilePostgresUserSecrets writes Secrets for the PostgreSQL users
 // specified in cluster and deletes existing Secrets that are not specified.
 // It returns the user specifications it acted on (because defaults) and the
-// Secrets it wrote.
+// Secrets it wrote. Secrets of users that must be removed from PostgreSQL
+// are returned rather than deleted.
 func (r *Reconciler) reconcilePostgresUserSecrets(
 	ctx context.Context, cluster *v1beta1.PostgresCluster,
 ) (
-	[]v1beta1.PostgresUserSpec, map[string]*corev1.Secret, error,
+	[]v1beta1.PostgresUserSpec, map[string]*corev1.Secret, []*corev1.Secret, error,
 ) {
 	// When users are unspecified, create one user matching the cluster name if
 	// it is also a valid user name.
@@ -584,11 +868,13 @@ func (r *Reconciler) reconcilePostgresUserSecrets(
 
 	// Index secrets by PostgreSQL user name and delete any that are not in the
 	// cluster spec. Keep track of the deprecated default secret to migrate its
-	// contents when the current secret doesn't exist.
+	// contents when the current secret doesn't exist. Keep the secrets of users
+	// that must be removed from PostgreSQL until that is done.
 	var (
 		defaultSecret     *corev1.Secret
 		defaultSecretName = naming.DeprecatedPostgresUserSecret(cluster).Name
 		defaultUserName   string
+		removedSecrets    []*corev1.Secret
 		userSecrets       = make(map[string]*corev1.Secret, len(secrets.Items))
 	)
 	if err == nil {
@@ -603,6 +889,8 @@ func (r *Reconciler) reconcilePostgresUserSecrets(
 				} else {
 					userSecrets[secretUserName] = secret
 				}
+			} else if removePostgresUser(cluster, secretUserName) {
+				removedSecrets = append(removedSecrets, secret)
 			} else if err == nil {
 				err = errors.WithStack(r.deleteControlled(ctx, cluster, secret))
 			}
@@ -610,6 +898,7 @@ func (r *Reconciler) reconcilePostgresUserSecrets(
 	}
 
 	// Reconcile each PostgreSQL user in the cluster spec.
+	waiting := sets.New[string]()
 	for userName, user := range userSpecs {
 		secret := userSecrets[userName]
 
@@ -619,6 +908,16 @@ func (r *Reconciler) reconcilePostgresUserSecrets(
 			secret = defaultSecret
 		}
 
+		if err == nil {
+			secret, err = r.observePostgresUserPassword(ctx, cluster, user, secret)
+		}
+		if errors.Is(err, errMissingPasswordSecret) {
+			// This user has no password yet; leave it out of PostgreSQL
+			// until the referenced Secret exists.
+			waiting.Insert(userName)
+			err = nil
+			continue
+		}
 		if err == nil {
 			userSecrets[userName], err = r.generatePostgresUserSecret(cluster, user, secret)
 		}
@@ -627,15 +926,87 @@ func (r *Reconciler) reconcilePostgresUserSecrets(
 		}
 	}
 
-	return specUsers, userSecrets, err
+	if waiting.Len() > 0 {
+		specUsers = slices.DeleteFunc(slices.Clone(specUsers),
+			func(user v1beta1.PostgresUserSpec) bool { return waiting.Has(user.Name) })
+	}
+
+	return specUsers, userSecrets, removedSecrets, err
+}
+
+// errMissingPasswordSecret means a user references a password that does not
+// exist and has no other password to use in the meantime.
+var errMissingPasswordSecret = errors.New("password secret not found")
+
+// removePostgresUser returns true when the user, having been removed from the
+// cluster spec, should also be removed from PostgreSQL.
+func removePostgresUser(cluster *v1beta1.PostgresCluster, username string) bool {
+	policy := cluster.Spec.UserRemoval
+
+	// The "postgres" superuser and the role that takes ownership of dropped
+	// objects are never removed.
+	return policy != nil && policy.Policy != v1beta1.PostgresUserRemovalRetain &&
+		username != "" && username != "postgres" && username != policy.ReassignTo
+}
+
+// observePostgresUserPassword returns a copy of existing that holds the
+// password in the Secret referenced by spec. The verifier in existing is kept
+// only when it belongs to that password. It returns existing when spec does
+// not reference a Secret or when the referenced password cannot be found.
+// It returns errMissingPasswordSecret when neither password exists.
+func (r *Reconciler) observePostgresUserPassword(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	spec *v1beta1.PostgresUserSpec, existing *corev1.Secret,
+) (*corev1.Secret, error) {
+	if spec.Password == nil || spec.Password.SecretRef == nil {
+		return existing, nil
+	}
+
+	ref := spec.Password.SecretRef
+	source := &corev1.Secret{}
+	err := r.Reader.Get(ctx,
+		client.ObjectKey{Namespace: cluster.Namespace, Name: ref.Name}, source)
+
+	if client.IgnoreNotFound(err) != nil {
+		return existing, errors.WithStack(err)
+	}
+	if err != nil || len(source.Data[ref.Key]) == 0 {
+		// Keep any existing password until the referenced one is available.
+		// The Secret watch will reconcile again when it is created or changes.
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "MissingPasswordSecret",
+			"Secret %q has no %q key for the password of user %q",
+			ref.Name, ref.Key, spec.Name)
+
+		if existing == nil {
+			return nil, errMissingPasswordSecret
+		}
+		return existing, nil
+	}
+
+	result := &corev1.Secret{}
+	if existing != nil {
+		existing.DeepCopyInto(result)
+	}
+	initialize.Map(&result.Data)
+
+	if !bytes.Equal(result.Data["password"], source.Data[ref.Key]) {
+		result.Data["password"] = source.Data[ref.Key]
+		result.Data["verifier"] = nil
+	}
+
+	return result, nil
 }
 
 // reconcilePostgresUsersInPostgreSQL creates users inside of PostgreSQL and
-// sets their options and database access as specified.
+// sets their options and database access as specified. Users of removedSecrets
+// are removed according to the cluster policy, then their Secrets are deleted.
+// It returns how long to wait before granting privileges on databases and
+// schemas that do not exist yet.
 func (r *Reconciler) reconcilePostgresUsersInPostgreSQL(
 	ctx context.Context, cluster *v1beta1.PostgresCluster, instances *observedInstances,
 	specUsers []v1beta1.PostgresUserSpec, userSecrets map[string]*corev1.Secret,
-) error {
+	removedSecrets []*corev1.Secret,
+) (time.Duration, error) {
 	const container = naming.ContainerDatabase
 	var podExecutor postgres.Executor
 
@@ -663,18 +1034,64 @@ func (r *Reconciler) reconcilePostgresUsersInPostgreSQL(
 		}
 	}
 	if podExecutor == nil {
-		return nil
+		return 0, nil
 	}
 
 	// Calculate a hash of the SQL that should be executed in PostgreSQL.
 
 	verifiers := make(map[string]string, len(userSecrets))
+	previous := make(map[string]string)
 	for userName := range userSecrets {
 		verifiers[userName] = string(userSecrets[userName].Data["verifier"])
+		if v := userSecrets[userName].Data["previous-verifier"]; len(v) > 0 {
+			previous[userName] = string(v)
+		}
+	}
+
+	removed := sets.New[string]()
+	for _, secret := range removedSecrets {
+		removed.Insert(secret.Labels[naming.LabelPostgresUser])
+	}
+
+	// Any user could have a previous password that no longer works.
+	rotating := removed.Clone()
+	for i := range specUsers {
+		rotating.Insert(specUsers[i].Name)
+	}
+
+	// Access to databases in spec.databases is granted by
+	// reconcilePostgresDatabaseSpecs after those databases exist.
+	specDatabases := sets.New[string]()
+	for i := range cluster.Spec.Databases {
+		specDatabases.Insert(cluster.Spec.Databases[i].Name)
+	}
+	writeUsers := specUsers
+	if specDatabases.Len() > 0 {
+		writeUsers = make([]v1beta1.PostgresUserSpec, len(specUsers))
+		for i := range specUsers {
+			writeUsers[i] = *specUsers[i].DeepCopy()
+			writeUsers[i].Databases = slices.DeleteFunc(writeUsers[i].Databases,
+				func(database string) bool { return specDatabases.Has(database) })
+		}
 	}
 
+	// Databases and schemas in spec.databases are created after users, so
+	// privileges on them may not be granted until a later reconcile.
+	var missing []string
 	write := func(ctx context.Context, exec postgres.Executor) error {
-		return postgres.WriteUsersInPostgreSQL(ctx, cluster, exec, specUsers, verifiers)
+		err := postgres.WriteUsersInPostgreSQL(ctx, cluster, exec, writeUsers, verifiers)
+		if err == nil {
+			missing, err = postgres.WriteUserGrantsInPostgreSQL(ctx, exec, specUsers)
+		}
+		if err == nil {
+			err = postgres.WritePreviousPasswordsInPostgreSQL(ctx, exec,
+				sets.List(rotating), previous)
+		}
+		if err == nil {
+			err = postgres.RemoveUsersInPostgreSQL(ctx, exec,
+				cluster.Spec.UserRemoval, sets.List(removed))
+		}
+		return err
 	}
 
 	revision, err := safeHash32(func(hasher io.Writer) error {
@@ -690,26 +1107,34 @@ func (r *Reconciler) reconcilePostgresUsersInPostgreSQL(
 		})
 	})
 
-	if err == nil && revision == cluster.Status.UsersRevision {
-		// The necessary SQL has already been applied; there's nothing more to do.
-
-		// TODO(cbandy): Give the user a way to trigger execution regardless.
-		// The value of an annotation could influence the hash, for example.
-		return nil
-	}
-
 	// Apply the necessary SQL and record its hash in cluster.Status. Include
-	// the hash in any log messages.
+	// the hash in any log messages. When the hash matches, the necessary SQL
+	// has already been applied.
 
-	if err == nil {
+	// TODO(cbandy): Give the user a way to trigger execution regardless.
+	// The value of an annotation could influence the hash, for example.
+	var requeue time.Duration
+	if err == nil && revision != cluster.Status.UsersRevision {
 		log := logging.FromContext(ctx).WithValues("revision", revision)
 		err = errors.WithStack(write(logging.NewContext(ctx, log), podExecutor))
+
+		// Leave the revision unrecorded until every grant is in place.
+		if err == nil && len(missing) > 0 {
+			log.V(1).Info("waiting to grant privileges", "missing", missing)
+			requeue = 10 * time.Second
+		} else if err == nil {
+			cluster.Status.UsersRevision = revision
+		}
 	}
-	if err == nil {
-		cluster.Status.UsersRevision = revision
+
+	// Removed users are gone from PostgreSQL; delete their Secrets.
+	for _, secret := range removedSecrets {
+		if err == nil {
+			err = errors.WithStack(r.deleteControlled(ctx, cluster, secret))
+		}
 	}
 
-	return err
+	return requeue, err
 }
 
 // +kubebuilder:rbac:groups="",resources="persistentvolumeclaims",verbs={create,patch}
@@ -6,21 +6,28 @@ package postgrescluster
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp/cmpopts"
 	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	"golang.org/x/crypto/pbkdf2"
 	"gotest.tools/v3/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
 	"github.com/crunchydata/postgres-operator/internal/feature"
@@ -508,6 +515,362 @@ func TestGeneratePostgresUserSecret(t *testing.T) {
 	})
 }
 
+func TestObservePostgresUserPassword(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace = "ns1"
+	cluster.Name = "hippo"
+	cluster.Spec.Port = initialize.Int32(5432)
+
+	source := &corev1.Secret{}
+	source.Namespace = "ns1"
+	source.Name = "from-vault"
+	source.Data = map[string][]byte{"pw": []byte("external")}
+
+	existing := &corev1.Secret{Data: map[string][]byte{
+		"password": []byte("external"),
+		"verifier": []byte("external$verifier"),
+	}}
+
+	t.Run("NoReference", func(t *testing.T) {
+		reconciler := &Reconciler{}
+		spec := &v1beta1.PostgresUserSpec{Name: "u1"}
+
+		result, err := reconciler.observePostgresUserPassword(ctx, cluster, spec, existing)
+		assert.NilError(t, err)
+		assert.Equal(t, result, existing)
+	})
+
+	spec := &v1beta1.PostgresUserSpec{Name: "u1"}
+	require.UnmarshalInto(t, &spec.Password, `{
+		type: ASCII, secretRef: { name: from-vault, key: pw },
+	}`)
+
+	t.Run("Missing", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{
+			Reader:   fake.NewClientBuilder().Build(),
+			Recorder: recorder,
+		}
+
+		result, err := reconciler.observePostgresUserPassword(ctx, cluster, spec, existing)
+		assert.NilError(t, err)
+		assert.Equal(t, result, existing)
+
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "MissingPasswordSecret")
+		assert.Assert(t, cmp.Contains(recorder.Events[0].Note, `"from-vault"`))
+	})
+
+	t.Run("MissingKey", func(t *testing.T) {
+		source := source.DeepCopy()
+		source.Data = map[string][]byte{"other": []byte("value")}
+
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{
+			Reader:   fake.NewClientBuilder().WithObjects(source).Build(),
+			Recorder: recorder,
+		}
+
+		result, err := reconciler.observePostgresUserPassword(ctx, cluster, spec, nil)
+		assert.Assert(t, errors.Is(err, errMissingPasswordSecret), "got %v", err)
+		assert.Assert(t, result == nil)
+		assert.Equal(t, len(recorder.Events), 1)
+	})
+
+	t.Run("MissingWithoutExisting", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Users = []v1beta1.PostgresUserSpec{*spec}
+
+		cc := fake.NewClientBuilder().WithScheme(runtime.Scheme).Build()
+		reconciler := &Reconciler{
+			Reader: cc, Writer: cc,
+			Recorder: events.NewRecorder(t, runtime.Scheme),
+		}
+
+		// The user waits for its password rather than getting a random one.
+		users, secrets, _, err := reconciler.reconcilePostgresUserSecrets(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Equal(t, len(users), 0, "expected the user to wait")
+		assert.Equal(t, len(secrets), 0)
+
+		list := &corev1.SecretList{}
+		assert.NilError(t, cc.List(ctx, list))
+		assert.Equal(t, len(list.Items), 0, "expected no Secret")
+		assert.Equal(t, len(cluster.Spec.Users), 1, "expected no change to spec")
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		reconciler := &Reconciler{Reader: fake.NewClientBuilder().WithObjects(source).Build()}
+
+		result, err := reconciler.observePostgresUserPassword(ctx, cluster, spec, existing)
+		assert.NilError(t, err)
+		assert.Equal(t, string(result.Data["password"]), "external")
+		assert.Equal(t, string(result.Data["verifier"]), "external$verifier")
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		source := source.DeepCopy()
+		source.Data["pw"] = []byte("rotated")
+
+		reconciler := &Reconciler{Reader: fake.NewClientBuilder().WithObjects(source).Build()}
+
+		result, err := reconciler.observePostgresUserPassword(ctx, cluster, spec, existing)
+		assert.NilError(t, err)
+		assert.Equal(t, string(result.Data["password"]), "rotated")
+		assert.Assert(t, result.Data["verifier"] == nil, "expected a new verifier")
+		assert.Equal(t, string(existing.Data["password"]), "external", "expected no change to existing")
+
+		// The generated Secret has a verifier of the new password.
+		secret, err := reconciler.generatePostgresUserSecret(cluster, spec, result)
+		assert.NilError(t, err)
+		assert.Equal(t, string(secret.Data["password"]), "rotated")
+		assert.Assert(t, len(secret.Data["verifier"]) > 90, "got %v", len(secret.Data["verifier"]))
+	})
+}
+
+func TestGeneratePostgresPasswordRotation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	hourAgo := now.Add(-time.Hour).Format(time.RFC3339)
+
+	existing := func(annotations map[string]string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Data: map[string][]byte{
+				"password": []byte("current"),
+				"verifier": []byte("current$verifier"),
+			},
+		}
+	}
+	intent := func(existing *corev1.Secret) *corev1.Secret {
+		return &corev1.Secret{Data: map[string][]byte{
+			"password": existing.Data["password"],
+			"verifier": existing.Data["verifier"],
+		}}
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		spec := &v1beta1.PostgresUserSpec{Name: "u1"}
+		old := existing(nil)
+		next := intent(old)
+
+		assert.Assert(t, generatePostgresPasswordRotation(spec, old, next, now) == nil)
+		assert.Equal(t, string(next.Data["password"]), "current")
+	})
+
+	t.Run("StartsClock", func(t *testing.T) {
+		spec := &v1beta1.PostgresUserSpec{Name: "u1"}
+		require.UnmarshalInto(t, &spec.Rotation, `{ interval: 2h }`)
+
+		old := existing(nil)
+		next := intent(old)
+
+		assert.DeepEqual(t, generatePostgresPasswordRotation(spec, old, next, now), map[string]string{
+			"postgres-operator.crunchydata.com/password-rotated-at": "2025-06-01T12:00:00Z",
+		})
+		assert.Equal(t, string(next.Data["password"]), "current")
+	})
+
+	t.Run("Interval", func(t *testing.T) {
+		spec := &v1beta1.PostgresUserSpec{Name: "u1"}
+		require.UnmarshalInto(t, &spec.Rotation, `{ interval: 1h, gracePeriod: 10m }`)
+
+		old := existing(map[string]string{
+			"postgres-operator.crunchydata.com/password-rotated-at": hourAgo,
+		})
+		next := intent(old)
+
+		assert.DeepEqual(t, generatePostgresPasswordRotation(spec, old, next, now), map[string]string{
+			"postgres-operator.crunchydata.com/password-rotated-at": "2025-06-01T12:00:00Z",
+		})
+		assert.Assert(t, next.Data["password"] == nil, "expected a new password")
+		assert.Assert(t, next.Data["verifier"] == nil, "expected a new verifier")
+		assert.Equal(t, string(next.Data["previous-password"]), "current")
+		assert.Equal(t, string(next.Data["previous-verifier"]), "current$verifier")
+		assert.Equal(t, string(next.Data["previous-user"]), "u1_previous")
+	})
+
+	t.Run("Trigger", func(t *testing.T) {
+		spec := &v1beta1.PostgresUserSpec{Name: "u1"}
+		spec.Rotation = &v1beta1.PostgresPasswordRotationSpec{}
+
+		// Changes when the annotation differs from the last rotation.
+		old := existing(map[string]string{
+			"postgres-operator.crunchydata.com/password-rotated-at": hourAgo,
+			"postgres-operator.crunchydata.com/rotate-password":     "two",
+			"postgres-operator.crunchydata.com/password-rotation":   "one",
+		})
+		next := intent(old)
+
+		assert.DeepEqual(t, generatePostgresPasswordRotation(spec, old, next, now), map[string]string{
+			"postgres-operator.crunchydata.com/password-rotated-at": "2025-06-01T12:00:00Z",
+			"postgres-operator.crunchydata.com/password-rotation":   "two",
+		})
+		assert.Assert(t, next.Data["password"] == nil, "expected a new password")
+
+		// Unchanged when the annotation matches the last rotation.
+		old.Annotations["postgres-operator.crunchydata.com/password-rotation"] = "two"
+		next = intent(old)
+
+		assert.DeepEqual(t, generatePostgresPasswordRotation(spec, old, next, now), map[string]string{
+			"postgres-operator.crunchydata.com/password-rotated-at": hourAgo,
+			"postgres-operator.crunchydata.com/password-rotation":   "two",
+		})
+		assert.Equal(t, string(next.Data["password"]), "current")
+		assert.Assert(t, next.Data["previous-password"] == nil, "expected no grace period")
+	})
+
+	t.Run("GracePeriod", func(t *testing.T) {
+		spec := &v1beta1.PostgresUserSpec{Name: "u1"}
+		require.UnmarshalInto(t, &spec.Rotation, `{ gracePeriod: 2h }`)
+
+		old := existing(map[string]string{
+			"postgres-operator.crunchydata.com/password-rotated-at": hourAgo,
+		})
+		old.Data["previous-password"] = []byte("older")
+		old.Data["previous-verifier"] = []byte("older$verifier")
+		old.Data["previous-user"] = []byte("u1_previous")
+		next := intent(old)
+
+		generatePostgresPasswordRotation(spec, old, next, now)
+		assert.Equal(t, string(next.Data["previous-password"]), "older")
+		assert.Equal(t, string(next.Data["previous-verifier"]), "older$verifier")
+		assert.Equal(t, string(next.Data["previous-user"]), "u1_previous")
+
+		// Removed after the grace period.
+		spec.Rotation.GracePeriod, _ = v1beta1.NewDuration("30m")
+		next = intent(old)
+
+		generatePostgresPasswordRotation(spec, old, next, now)
+		assert.Assert(t, next.Data["previous-password"] == nil)
+		assert.Assert(t, next.Data["previous-verifier"] == nil)
+		assert.Assert(t, next.Data["previous-user"] == nil)
+	})
+}
+
+// scramAuthenticates returns true when password matches the SCRAM verifier
+// the same way PostgreSQL checks it.
+// - https://www.postgresql.org/docs/current/sasl-authentication.html
+func scramAuthenticates(t testing.TB, verifier, password string) bool {
+	t.Helper()
+
+	var iterations int
+	var salt, storedKey, serverKey string
+	_, err := fmt.Sscanf(strings.NewReplacer("$", " ", ":", " ").Replace(verifier),
+		"SCRAM-SHA-256 %d %s %s %s", &iterations, &salt, &storedKey, &serverKey)
+	assert.NilError(t, err)
+
+	decodedSalt, err := base64.StdEncoding.DecodeString(salt)
+	assert.NilError(t, err)
+
+	salted := pbkdf2.Key([]byte(password), decodedSalt, iterations, sha256.Size, sha256.New)
+	mac := hmac.New(sha256.New, salted)
+	_, _ = mac.Write([]byte("Client Key"))
+	stored := sha256.Sum256(mac.Sum(nil))
+
+	return base64.StdEncoding.EncodeToString(stored[:]) == storedKey
+}
+
+func TestPostgresPasswordGracePeriod(t *testing.T) {
+	ctx := context.Background()
+	reconciler := &Reconciler{}
+
+	cluster := testCluster()
+	cluster.Spec.Port = initialize.Int32(5432)
+	cluster.Spec.Proxy = nil
+
+	spec := &v1beta1.PostgresUserSpec{Name: "u1"}
+	require.UnmarshalInto(t, &spec.Rotation, `{ gracePeriod: 1h }`)
+
+	first, err := reconciler.generatePostgresUserSecret(cluster, spec, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, first.Data["previous-password"] == nil)
+
+	// Rotate the password.
+	first.Annotations[naming.PostgresPasswordRotate] = "now"
+	second, err := reconciler.generatePostgresUserSecret(cluster, spec, first)
+	assert.NilError(t, err)
+	assert.Assert(t, string(second.Data["password"]) != string(first.Data["password"]))
+	assert.Equal(t, string(second.Data["previous-password"]), string(first.Data["password"]))
+	assert.Equal(t, string(second.Data["previous-user"]), "u1_previous")
+
+	// PostgreSQL gets the new password for the user and the previous password
+	// for the role in "previous-user".
+	logins := map[string]string{}
+	exec := func(
+		_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+	) error {
+		b, err := io.ReadAll(stdin)
+		assert.NilError(t, err)
+		for _, line := range strings.Split(string(b), "\n") {
+			var user struct{ Username, Verifier string }
+			if json.Unmarshal([]byte(line), &user) == nil && user.Verifier != "" {
+				logins[user.Username] = user.Verifier
+			}
+		}
+		for _, arg := range command {
+			var roles []struct{ Role, Verifier string }
+			if _, value, ok := strings.Cut(arg, "users="); ok &&
+				json.Unmarshal([]byte(value), &roles) == nil {
+				for _, role := range roles {
+					logins[role.Role] = role.Verifier
+				}
+			}
+		}
+		return nil
+	}
+
+	assert.NilError(t, postgres.WriteUsersInPostgreSQL(ctx, cluster, exec,
+		[]v1beta1.PostgresUserSpec{*spec},
+		map[string]string{"u1": string(second.Data["verifier"])}))
+	assert.NilError(t, postgres.WritePreviousPasswordsInPostgreSQL(ctx, exec,
+		[]string{"u1"}, map[string]string{"u1": string(second.Data["previous-verifier"])}))
+
+	// Both passwords authenticate during the grace period.
+	assert.Assert(t, scramAuthenticates(t, logins["u1"], string(second.Data["password"])))
+	assert.Assert(t, !scramAuthenticates(t, logins["u1"], string(second.Data["previous-password"])))
+	assert.Assert(t, scramAuthenticates(t,
+		logins[string(second.Data["previous-user"])], string(second.Data["previous-password"])))
+
+	// Only the new password authenticates after the grace period.
+	second.Annotations[naming.PostgresPasswordRotatedAt] =
+		time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+	third, err := reconciler.generatePostgresUserSecret(cluster, spec, second)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, third.Data["password"], second.Data["password"])
+	assert.Assert(t, third.Data["previous-password"] == nil)
+	assert.Assert(t, third.Data["previous-user"] == nil)
+}
+
+func TestPostgresPasswordRequeue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	spec := &v1beta1.PostgresUserSpec{Name: "u1"}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			"postgres-operator.crunchydata.com/password-rotated-at": now.Add(-time.Hour).Format(time.RFC3339),
+		}},
+	}
+
+	assert.Equal(t, postgresPasswordRequeue(spec, secret, now), time.Duration(0))
+
+	require.UnmarshalInto(t, &spec.Rotation, `{ interval: 3h }`)
+	assert.Equal(t, postgresPasswordRequeue(spec, secret, now), 2*time.Hour)
+	assert.Equal(t, postgresPasswordRequeue(spec, nil, now), time.Duration(0))
+
+	secret.Data = map[string][]byte{"previous-password": []byte("older")}
+	require.UnmarshalInto(t, &spec.Rotation, `{ interval: 3h, gracePeriod: 90m }`)
+	assert.Equal(t, postgresPasswordRequeue(spec, secret, now), 30*time.Minute)
+
+	// Overdue deadlines wait a moment.
+	require.UnmarshalInto(t, &spec.Rotation, `{ interval: 1h }`)
+	assert.Equal(t, postgresPasswordRequeue(spec, secret, now), time.Second)
+}
+
 func TestReconcilePostgresVolumes(t *testing.T) {
 	ctx := context.Background()
 	_, tClient := setupKubernetes(t)
@@ -1199,3 +1562,279 @@ func TestValidatePostgresUsers(t *testing.T) {
 		reconciler.validatePostgresUsers(cluster)
 	})
 }
+
+func TestRemovePostgresUser(t *testing.T) {
+	t.Parallel()
+
+	cluster := v1beta1.NewPostgresCluster()
+	assert.Assert(t, !removePostgresUser(cluster, "app"), "expected retain by default")
+
+	cluster.Spec.UserRemoval = &v1beta1.PostgresUserRemovalSpec{Policy: "Retain"}
+	assert.Assert(t, !removePostgresUser(cluster, "app"))
+
+	cluster.Spec.UserRemoval = &v1beta1.PostgresUserRemovalSpec{Policy: "NoLogin"}
+	assert.Assert(t, removePostgresUser(cluster, "app"))
+	assert.Assert(t, !removePostgresUser(cluster, "postgres"))
+	assert.Assert(t, !removePostgresUser(cluster, ""))
+
+	cluster.Spec.UserRemoval = &v1beta1.PostgresUserRemovalSpec{Policy: "Drop", ReassignTo: "owner"}
+	assert.Assert(t, removePostgresUser(cluster, "app"))
+	assert.Assert(t, !removePostgresUser(cluster, "owner"))
+}
+
+func TestReconcilePostgresDatabaseSpecs(t *testing.T) {
+	ctx := context.Background()
+
+	instances := &observedInstances{forCluster: []*Instance{{
+		Name: "instance",
+		Pods: []*corev1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "ns1",
+				Name:        "pod",
+				Annotations: map[string]string{"status": `{"role":"primary"}`},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  naming.ContainerDatabase,
+					State: corev1.ContainerState{Running: new(corev1.ContainerStateRunning)},
+				}},
+			},
+		}},
+		Runner: &appsv1.StatefulSet{},
+	}}}
+
+	t.Run("NoPod", func(t *testing.T) {
+		reconciler := &Reconciler{}
+
+		cluster := v1beta1.NewPostgresCluster()
+		cluster.Spec.Databases = []v1beta1.PostgresDatabaseSpec{{Name: "one"}}
+		cluster.Status.Databases = []v1beta1.PostgresDatabaseStatus{
+			{Name: "gone", Revision: "abc"},
+			{Name: "one", Revision: "xyz"},
+		}
+
+		requeue, err := reconciler.reconcilePostgresDatabaseSpecs(ctx, cluster, nil)
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, time.Duration(0))
+		assert.DeepEqual(t, cluster.Status.Databases, []v1beta1.PostgresDatabaseStatus{
+			{Name: "one", Revision: "xyz"},
+		})
+	})
+
+	t.Run("Conditions", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+
+		cluster := v1beta1.NewPostgresCluster()
+		cluster.Generation = 3
+		cluster.Spec.Databases = []v1beta1.PostgresDatabaseSpec{
+			{Name: "broken", Owner: "nobody"},
+			{Name: "drifted", Encoding: "UTF8"},
+			{Name: "fine"},
+		}
+		cluster.Spec.Users = []v1beta1.PostgresUserSpec{
+			{Name: "app", Databases: []v1beta1.PostgresIdentifier{"fine", "other"}},
+		}
+
+		calls := map[string]int{}
+		reconciler.PodExec = func(
+			_ context.Context, _, _, _ string, _ io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			args := strings.Join(command, " ")
+			switch {
+			case strings.Contains(args, "--set=database=broken"):
+				calls["broken"]++
+				_, _ = stderr.Write([]byte(`ERROR:  role "nobody" does not exist`))
+				return errors.New("exit code 3")
+			case strings.Contains(args, "--set=database=drifted"):
+				calls["drifted"]++
+				_, _ = stdout.Write([]byte(`{"encoding":"SQL_ASCII","database":{}}`))
+			default:
+				calls["fine"]++
+				assert.Assert(t, cmp.Contains(args, `--set=grantees=["app"]`))
+			}
+			return nil
+		}
+
+		requeue, err := reconciler.reconcilePostgresDatabaseSpecs(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, 10*time.Second)
+		assert.DeepEqual(t, calls, map[string]int{"broken": 1, "drifted": 1, "fine": 1})
+
+		assert.Equal(t, len(cluster.Status.Databases), 3)
+		broken, drifted, fine := cluster.Status.Databases[0], cluster.Status.Databases[1], cluster.Status.Databases[2]
+
+		assert.Equal(t, broken.Revision, "")
+		assert.Equal(t, broken.Conditions[0].Reason, "Failed")
+		assert.Equal(t, broken.Conditions[0].Status, metav1.ConditionFalse)
+		assert.Assert(t, cmp.Contains(broken.Conditions[0].Message, `role "nobody" does not exist`))
+
+		assert.Assert(t, drifted.Revision != "")
+		assert.Equal(t, drifted.Conditions[0].Reason, "Drifted")
+		assert.Equal(t, drifted.Conditions[0].Message, `Database differs from spec: encoding is "SQL_ASCII"`)
+
+		assert.Assert(t, fine.Revision != "")
+		assert.Equal(t, fine.Conditions[0].Reason, "Applied")
+		assert.Equal(t, fine.Conditions[0].Status, metav1.ConditionTrue)
+		assert.Equal(t, fine.Conditions[0].ObservedGeneration, int64(3))
+
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "DatabaseFailed")
+
+		// Only the database that failed is tried again.
+		_, err = reconciler.reconcilePostgresDatabaseSpecs(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, calls, map[string]int{"broken": 2, "drifted": 1, "fine": 1})
+
+		// Databases are checked again after some time.
+		cluster.Status.Databases[2].CheckTime = initialize.Pointer(
+			metav1.NewTime(time.Now().Add(-2 * databaseCheckInterval)))
+
+		_, err = reconciler.reconcilePostgresDatabaseSpecs(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, calls, map[string]int{"broken": 3, "drifted": 1, "fine": 2})
+		assert.Assert(t, time.Since(cluster.Status.Databases[2].CheckTime.Time) < time.Minute)
+	})
+
+	t.Run("Grantees", func(t *testing.T) {
+		grantees := map[string]string{}
+		reconciler := &Reconciler{}
+		reconciler.PodExec = func(
+			_ context.Context, _, _, _ string, _ io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			var database, users string
+			for _, arg := range command {
+				if value, ok := strings.CutPrefix(arg, "--set=database="); ok {
+					database = value
+				}
+				if value, ok := strings.CutPrefix(arg, "--set=grantees="); ok {
+					users = value
+				}
+			}
+			grantees[database] = users
+			return nil
+		}
+
+		// Users that specify their own grants are not granted all privileges.
+		cluster := v1beta1.NewPostgresCluster()
+		cluster.Name = "hippo"
+		cluster.Spec.Databases = []v1beta1.PostgresDatabaseSpec{{Name: "one"}}
+		cluster.Spec.Users = []v1beta1.PostgresUserSpec{
+			{Name: "app", Databases: []v1beta1.PostgresIdentifier{"one"}},
+			{Name: "reader", Databases: []v1beta1.PostgresIdentifier{"one"},
+				Grants: &v1beta1.PostgresGrantsSpec{}},
+		}
+
+		_, err := reconciler.reconcilePostgresDatabaseSpecs(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.Equal(t, grantees["one"], `["app"]`)
+
+		// When users are unspecified, the default user is granted access to
+		// the database named after the cluster.
+		cluster = v1beta1.NewPostgresCluster()
+		cluster.Name = "hippo"
+		cluster.Spec.Databases = []v1beta1.PostgresDatabaseSpec{{Name: "hippo"}}
+
+		_, err = reconciler.reconcilePostgresDatabaseSpecs(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.Equal(t, grantees["hippo"], `["hippo"]`)
+	})
+
+	t.Run("Requeue", func(t *testing.T) {
+		reconciler := &Reconciler{}
+		reconciler.PodExec = func(
+			context.Context, string, string, string, io.Reader, io.Writer, io.Writer, ...string,
+		) error {
+			return nil
+		}
+
+		cluster := v1beta1.NewPostgresCluster()
+		cluster.Spec.Databases = []v1beta1.PostgresDatabaseSpec{{Name: "one"}}
+
+		requeue, err := reconciler.reconcilePostgresDatabaseSpecs(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, databaseCheckInterval)
+
+		requeue, err = reconciler.reconcilePostgresDatabaseSpecs(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.Assert(t, requeue > 0 && requeue <= databaseCheckInterval, "got %v", requeue)
+	})
+}
+
+func TestReconcilePostgresUserGrantsBeforeDatabases(t *testing.T) {
+	ctx := context.Background()
+
+	instances := &observedInstances{forCluster: []*Instance{{
+		Name: "instance",
+		Pods: []*corev1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "ns1",
+				Name:        "pod",
+				Annotations: map[string]string{"status": `{"role":"primary"}`},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  naming.ContainerDatabase,
+					State: corev1.ContainerState{Running: new(corev1.ContainerStateRunning)},
+				}},
+			},
+		}},
+		Runner: &appsv1.StatefulSet{},
+	}}}
+
+	cluster := v1beta1.NewPostgresCluster()
+	require.UnmarshalInto(t, &cluster.Spec, `{
+		databases: [{ name: db1 }],
+		users: [{
+			name: app,
+			grants: { databases: [{ name: db1, privileges: [CONNECT] }] },
+		}],
+	}`)
+	secrets := map[string]*corev1.Secret{"app": {Data: map[string][]byte{"verifier": []byte("x")}}}
+
+	// The database exists only after reconcilePostgresDatabaseSpecs creates it.
+	created, grants := false, 0
+	reconciler := &Reconciler{}
+	reconciler.PodExec = func(
+		_ context.Context, _, _, _ string, stdin io.Reader, stdout, _ io.Writer, command ...string,
+	) error {
+		if strings.Contains(strings.Join(command, " "), "--set=database=db1") {
+			created = true
+		}
+
+		b, err := io.ReadAll(stdin)
+		assert.NilError(t, err)
+		if strings.Contains(string(b), `pg_catalog.format('database %I', database)`) {
+			grants++
+			if !created {
+				_, _ = stdout.Write([]byte("database db1\n"))
+			}
+		}
+		return nil
+	}
+
+	requeue, err := reconciler.reconcilePostgresUsersInPostgreSQL(ctx, cluster, instances,
+		cluster.Spec.Users, secrets, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, grants, 1)
+	assert.Equal(t, requeue, 10*time.Second, "expected to grant again soon")
+	assert.Equal(t, cluster.Status.UsersRevision, "", "expected no revision while db1 is missing")
+
+	_, err = reconciler.reconcilePostgresDatabaseSpecs(ctx, cluster, instances)
+	assert.NilError(t, err)
+	assert.Assert(t, created)
+
+	// The grant is written again now that the database exists.
+	requeue, err = reconciler.reconcilePostgresUsersInPostgreSQL(ctx, cluster, instances,
+		cluster.Spec.Users, secrets, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, grants, 2)
+	assert.Equal(t, requeue, time.Duration(0))
+	assert.Assert(t, cluster.Status.UsersRevision != "")
+
+	// Nothing is written once the revision is recorded.
+	_, err = reconciler.reconcilePostgresUsersInPostgreSQL(ctx, cluster, instances,
+		cluster.Spec.Users, secrets, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, grants, 2)
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/patroni"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 // watchPods returns a handler.EventHandler for Pods.
@@ -89,3 +90,62 @@ func (*Reconciler) watchPods() handler.Funcs {
 		},
 	}
 }
+
+// +kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={list}
+
+// findPostgresClustersForSecret returns PostgresClusters that have a user
+// whose password is stored in the Secret or that use a certificate issued into
+// the Secret. Every PostgresCluster in the namespace uses the root certificate
+// Secret.
+func (r *Reconciler) findPostgresClustersForSecret(
+	ctx context.Context, secret client.ObjectKey,
+) []*v1beta1.PostgresCluster {
+	var matching []*v1beta1.PostgresCluster
+	var clusters v1beta1.PostgresClusterList
+
+	// NOTE: If this becomes slow due to a large number of PostgresClusters in
+	// a single namespace, we can configure the [manager.Manager] field indexer
+	// and pass a [fields.Selector] here.
+	// - https://book.kubebuilder.io/reference/watching-resources/externally-managed.html
+	if err := r.Reader.List(ctx, &clusters, &client.ListOptions{
+		Namespace: secret.Namespace,
+	}); err == nil {
+		for i := range clusters.Items {
+			if secret.Name == naming.RootCertSecret {
+				matching = append(matching, &clusters.Items[i])
+				continue
+			}
+			if clusters.Items[i].Spec.CertificateIssuer != nil &&
+				(secret.Name == naming.IssuedPostgresTLSCertificate(&clusters.Items[i]).Name ||
+					secret.Name == naming.IssuedReplicationClientCertificate(&clusters.Items[i]).Name ||
+					secret.Name == naming.IssuedPGBouncerCertificate(&clusters.Items[i]).Name) {
+				matching = append(matching, &clusters.Items[i])
+				continue
+			}
+			for _, user := range clusters.Items[i].Spec.Users {
+				if user.Password != nil && user.Password.SecretRef != nil &&
+					user.Password.SecretRef.Name == secret.Name {
+					matching = append(matching, &clusters.Items[i])
+					break
+				}
+			}
+		}
+	}
+	return matching
+}
+
+// watchPGBackRestJobs returns a handler.EventHandler for PGBackRestJobs. Their
+// specs cannot change, and the Jobs they create are already watched, so it
+// queues their PostgresCluster only when they are created.
+func (*Reconciler) watchPGBackRestJobs() handler.Funcs {
+	return handler.Funcs{
+		CreateFunc: func(ctx context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			if job, ok := e.Object.(*v1beta1.PGBackRestJob); ok {
+				q.Add(reconcile.Request{NamespacedName: client.ObjectKey{
+					Namespace: job.Namespace,
+					Name:      job.Spec.PostgresClusterName,
+				}})
+			}
+		},
+	}
+}
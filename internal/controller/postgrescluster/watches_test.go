@@ -12,9 +12,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 func TestWatchPodsUpdate(t *testing.T) {
@@ -227,3 +234,74 @@ func TestWatchPodsUpdate(t *testing.T) {
 	}
 
 }
+
+func TestWatchPGBackRestJobs(t *testing.T) {
+	ctx := context.Background()
+	queue := &controllertest.Queue{TypedInterface: workqueue.NewTyped[reconcile.Request]()}
+	reconciler := &Reconciler{}
+
+	handler := reconciler.watchPGBackRestJobs()
+	assert.Assert(t, handler.UpdateFunc == nil, "expected no reconcile on status changes")
+
+	job := &v1beta1.PGBackRestJob{}
+	job.Namespace, job.Name = "some-ns", "adhoc"
+	job.Spec.PostgresClusterName = "starfish"
+
+	handler.CreateFunc(ctx, event.CreateEvent{Object: job}, queue)
+	assert.Equal(t, queue.Len(), 1)
+
+	item, _ := queue.Get()
+	expected := reconcile.Request{}
+	expected.Namespace = "some-ns"
+	expected.Name = "starfish"
+	assert.Equal(t, item, expected)
+	queue.Done(item)
+}
+
+func TestFindPostgresClustersForSecret(t *testing.T) {
+	ctx := context.Background()
+
+	cluster1 := v1beta1.NewPostgresCluster()
+	cluster1.Namespace = "ns1"
+	cluster1.Name = "has-ref"
+	require.UnmarshalInto(t, &cluster1.Spec, `{
+		users: [
+			{ name: generated },
+			{ name: external, password: { type: ASCII, secretRef: { name: pw, key: value } } },
+		],
+	}`)
+
+	cluster2 := cluster1.DeepCopy()
+	cluster2.Name = "no-ref"
+	cluster2.Spec.Users = cluster2.Spec.Users[:1]
+	cluster2.Spec.CertificateIssuer = &v1beta1.CertificateIssuerReference{Name: "some-issuer"}
+
+	cluster3 := cluster1.DeepCopy()
+	cluster3.Namespace = "ns2"
+
+	reconciler := &Reconciler{
+		Reader: fake.NewClientBuilder().WithScheme(runtime.Scheme).
+			WithObjects(cluster1, cluster2, cluster3).Build(),
+	}
+
+	clusters := reconciler.findPostgresClustersForSecret(ctx, client.ObjectKey{Namespace: "ns1", Name: "pw"})
+	assert.Equal(t, len(clusters), 1)
+	assert.Equal(t, clusters[0].Name, "has-ref")
+
+	clusters = reconciler.findPostgresClustersForSecret(ctx, client.ObjectKey{Namespace: "ns1", Name: "other"})
+	assert.Equal(t, len(clusters), 0)
+
+	// Every cluster in the namespace uses the root certificate.
+	clusters = reconciler.findPostgresClustersForSecret(ctx, client.ObjectKey{Namespace: "ns1", Name: naming.RootCertSecret})
+	assert.Equal(t, len(clusters), 2)
+
+	// Clusters with an issuer use the certificates issued by cert-manager.
+	clusters = reconciler.findPostgresClustersForSecret(ctx, client.ObjectKey{Namespace: "ns1",
+		Name: naming.IssuedPGBouncerCertificate(cluster2).Name})
+	assert.Equal(t, len(clusters), 1)
+	assert.Equal(t, clusters[0].Name, "no-ref")
+
+	clusters = reconciler.findPostgresClustersForSecret(ctx, client.ObjectKey{Namespace: "ns1",
+		Name: naming.IssuedPGBouncerCertificate(cluster1).Name})
+	assert.Equal(t, len(clusters), 0)
+}
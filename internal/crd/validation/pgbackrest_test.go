@@ -6,6 +6,7 @@ package validation
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/crunchydata/postgres-operator/internal/testing/require"
 	v1 "github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 func TestV1PGBackRestLogging(t *testing.T) {
@@ -308,3 +310,158 @@ func TestV1PGBackRestLogging(t *testing.T) {
 		})
 	})
 }
+
+func TestPGBackRestRetention(t *testing.T) {
+	ctx := context.Background()
+	cc := require.Kubernetes(t)
+	t.Parallel()
+
+	namespace := require.Namespace(t, cc)
+
+	base := v1.NewPostgresCluster()
+	base.Namespace = namespace.Name
+	base.Name = "pgbackrest-retention"
+	// required fields
+	require.UnmarshalInto(t, &base.Spec, `{
+		postgresVersion: 16,
+		instances: [{
+			dataVolumeClaimSpec: {
+				accessModes: [ReadWriteOnce],
+				resources: { requests: { storage: 1Mi } },
+			},
+		}],
+		backups: {
+			pgbackrest: {
+				repos: [{
+					name: repo1,
+				}]
+			},
+		},
+	}`)
+
+	assert.NilError(t, cc.Create(ctx, base.DeepCopy(), client.DryRunAll),
+		"expected this base to be valid")
+
+	t.Run("Valid", func(t *testing.T) {
+		for _, tt := range []string{
+			`{}`,
+			`{ full: 2 }`,
+			`{ full: 30, fullType: time, differential: 7 }`,
+			`{ full: 4, archive: 2, archiveType: full }`,
+			`{ archive: 10, archiveType: incr, expireSchedule: "0 3 * * *" }`,
+		} {
+			tmp := base.DeepCopy()
+			require.UnmarshalInto(t, &tmp.Spec.Backups.PGBackRest.Repos[0].Retention, tt)
+
+			assert.NilError(t, cc.Create(ctx, tmp, client.DryRunAll), "%s", tt)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, tt := range []struct{ value, message string }{
+			{`{ full: 0 }`, "should be greater than or equal to 1"},
+			{`{ full: 2, fullType: days }`, "Unsupported value"},
+			{`{ fullType: time }`, "fullType requires full"},
+			{`{ archiveType: incr }`, `archive is required when archiveType is "incr"`},
+			{`{ expireSchedule: "@" }`, "should be at least 6 chars long"},
+		} {
+			tmp := base.DeepCopy()
+			require.UnmarshalInto(t, &tmp.Spec.Backups.PGBackRest.Repos[0].Retention, tt.value)
+
+			err := cc.Create(ctx, tmp, client.DryRunAll)
+			assert.Assert(t, apierrors.IsInvalid(err), "%s", tt.value)
+			assert.ErrorContains(t, err, tt.message, "%s", tt.value)
+		}
+	})
+}
+
+func TestPGBackRestBackupReadOnly(t *testing.T) {
+	ctx := context.Background()
+	cc := require.Kubernetes(t)
+	t.Parallel()
+
+	namespace := require.Namespace(t, cc)
+
+	backup := &v1beta1.PGBackRestBackup{}
+	backup.Namespace = namespace.Name
+	backup.Name = "pgbackrest-backup"
+	require.UnmarshalInto(t, &backup.Spec, `{
+		postgresClusterName: hippo,
+		repoName: repo1,
+		type: full,
+		label: 20240101-000000F,
+	}`)
+
+	assert.NilError(t, cc.Create(ctx, backup))
+
+	t.Run("Metadata", func(t *testing.T) {
+		changed := backup.DeepCopy()
+		changed.Labels = map[string]string{"some": "label"}
+		assert.NilError(t, cc.Update(ctx, changed, client.DryRunAll))
+	})
+
+	t.Run("Spec", func(t *testing.T) {
+		changed := backup.DeepCopy()
+		changed.Spec.Label = "20240102-000000F"
+
+		err := cc.Update(ctx, changed, client.DryRunAll)
+		assert.Assert(t, apierrors.IsInvalid(err))
+		assert.ErrorContains(t, err, "read-only")
+	})
+}
+
+func TestPGBackRestJobSpec(t *testing.T) {
+	ctx := context.Background()
+	cc := require.Kubernetes(t)
+	t.Parallel()
+
+	namespace := require.Namespace(t, cc)
+
+	base := &v1beta1.PGBackRestJob{}
+	base.Namespace = namespace.Name
+	base.Name = "pgbackrest-job"
+	require.UnmarshalInto(t, &base.Spec, `{
+		postgresClusterName: hippo,
+		repoName: repo1,
+		operation: backup,
+	}`)
+
+	assert.NilError(t, cc.Create(ctx, base.DeepCopy(), client.DryRunAll),
+		"expected this base to be valid")
+
+	t.Run("Operation", func(t *testing.T) {
+		for _, tt := range []string{"backup", "expire", "verify", "stanza-upgrade"} {
+			job := base.DeepCopy()
+			job.Spec.Operation = tt
+			assert.NilError(t, cc.Create(ctx, job, client.DryRunAll), "%s", tt)
+		}
+
+		job := base.DeepCopy()
+		job.Spec.Operation = "restore"
+
+		err := cc.Create(ctx, job, client.DryRunAll)
+		assert.Assert(t, apierrors.IsInvalid(err))
+		assert.ErrorContains(t, err, "spec.operation")
+	})
+
+	t.Run("Name", func(t *testing.T) {
+		job := base.DeepCopy()
+		job.Name = strings.Repeat("x", 52)
+		assert.NilError(t, cc.Create(ctx, job, client.DryRunAll))
+
+		job.Name = strings.Repeat("x", 53)
+		err := cc.Create(ctx, job, client.DryRunAll)
+		assert.Assert(t, apierrors.IsInvalid(err))
+		assert.ErrorContains(t, err, "52 characters")
+	})
+
+	t.Run("Immutable", func(t *testing.T) {
+		job := base.DeepCopy()
+		assert.NilError(t, cc.Create(ctx, job))
+
+		job.Spec.Options = []string{"--type=full"}
+		err := cc.Update(ctx, job, client.DryRunAll)
+		assert.Assert(t, apierrors.IsInvalid(err))
+		assert.ErrorContains(t, err, "immutable")
+	})
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	v1 "github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1"
+)
+
+func TestPatroniSynchronousV1(t *testing.T) {
+	ctx := t.Context()
+	cc := require.KubernetesAtLeast(t, "1.30")
+	t.Parallel()
+
+	namespace := require.Namespace(t, cc)
+	base := v1.NewPostgresCluster()
+
+	// required fields
+	require.UnmarshalInto(t, &base.Spec, `{
+		postgresVersion: 16,
+		instances: [{
+			name: one,
+			replicas: 2,
+			dataVolumeClaimSpec: {
+				accessModes: [ReadWriteOnce],
+				resources: { requests: { storage: 1Mi } },
+			},
+		}],
+	}`)
+
+	base.Namespace = namespace.Name
+	base.Name = "patroni-synchronous"
+
+	assert.NilError(t, cc.Create(ctx, base.DeepCopy(), client.DryRunAll),
+		"expected this base cluster to be valid")
+
+	var u unstructured.Unstructured
+	require.UnmarshalInto(t, &u, require.Value(yaml.Marshal(base)))
+	assert.Equal(t, u.GetAPIVersion(), "postgres-operator.crunchydata.com/v1")
+
+	t.Run("Valid", func(t *testing.T) {
+		for _, tt := range []string{
+			`{}`,
+			`{ mode: "Off", standbys: 5 }`,
+			`{ mode: Strict }`,
+			`{ mode: Quorum, standbys: 2, instanceSets: [one] }`,
+		} {
+			cluster := u.DeepCopy()
+			require.UnmarshalIntoField(t, cluster, tt, "spec", "patroni", "synchronous")
+
+			assert.NilError(t, cc.Create(ctx, cluster, client.DryRunAll), "%s", tt)
+		}
+	})
+
+	t.Run("NotEnoughReplicas", func(t *testing.T) {
+		cluster := u.DeepCopy()
+		require.UnmarshalIntoField(t, cluster,
+			`{ mode: Strict, standbys: 2 }`,
+			"spec", "patroni", "synchronous")
+
+		err := cc.Create(ctx, cluster, client.DryRunAll)
+		assert.Assert(t, apierrors.IsInvalid(err))
+
+		details := require.StatusErrorDetails(t, err)
+		assert.Assert(t, cmp.Len(details.Causes, 1))
+		assert.Equal(t, details.Causes[0].Field, "spec.patroni.synchronous.standbys")
+		assert.Assert(t, cmp.Contains(details.Causes[0].Message, "not enough eligible replicas"))
+	})
+
+	t.Run("MissingInstanceSet", func(t *testing.T) {
+		cluster := u.DeepCopy()
+		require.UnmarshalIntoField(t, cluster,
+			`{ instanceSets: [one, two] }`,
+			"spec", "patroni", "synchronous")
+
+		err := cc.Create(ctx, cluster, client.DryRunAll)
+		assert.Assert(t, apierrors.IsInvalid(err))
+
+		details := require.StatusErrorDetails(t, err)
+		assert.Assert(t, cmp.Len(details.Causes, 1))
+		assert.Equal(t, details.Causes[0].Field, "spec.patroni.synchronous.instanceSets")
+	})
+}
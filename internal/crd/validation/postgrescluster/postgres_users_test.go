@@ -6,6 +6,7 @@ package validation
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -151,6 +152,28 @@ func testPostgresUserOptionsCommon(t *testing.T, cc client.Client, base unstruct
 		assert.Equal(t, details.Causes[0].Field, "spec.users[0].options")
 	})
 
+	t.Run("GracePeriodName", func(t *testing.T) {
+		cluster := base.DeepCopy()
+		require.UnmarshalIntoField(t, cluster,
+			require.Value(yaml.Marshal([]map[string]any{
+				{"name": strings.Repeat("x", 54), "rotation": map[string]any{"gracePeriod": "1h"}},
+				{"name": strings.Repeat("y", 55), "rotation": map[string]any{"interval": "1d"}},
+			})),
+			"spec", "users")
+
+		assert.NilError(t, cc.Create(ctx, cluster, client.DryRunAll))
+
+		require.UnmarshalIntoField(t, cluster,
+			require.Value(yaml.Marshal([]map[string]any{
+				{"name": strings.Repeat("x", 55), "rotation": map[string]any{"gracePeriod": "1h"}},
+			})),
+			"spec", "users")
+
+		err := cc.Create(ctx, cluster, client.DryRunAll)
+		assert.Assert(t, apierrors.IsInvalid(err))
+		assert.ErrorContains(t, err, "54 characters")
+	})
+
 	t.Run("Valid", func(t *testing.T) {
 		cluster := base.DeepCopy()
 		require.UnmarshalIntoField(t, cluster,
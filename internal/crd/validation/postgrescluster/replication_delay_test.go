@@ -0,0 +1,125 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	v1 "github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1"
+)
+
+func TestReplicationDelayV1(t *testing.T) {
+	ctx := t.Context()
+	cc := require.KubernetesAtLeast(t, "1.30")
+	t.Parallel()
+
+	namespace := require.Namespace(t, cc)
+	base := v1.NewPostgresCluster()
+
+	// required fields
+	require.UnmarshalInto(t, &base.Spec, `{
+		postgresVersion: 16,
+		instances: [{
+			name: one,
+			dataVolumeClaimSpec: {
+				accessModes: [ReadWriteOnce],
+				resources: { requests: { storage: 1Mi } },
+			},
+		}],
+	}`)
+
+	base.Namespace = namespace.Name
+	base.Name = "replication-delay"
+
+	assert.NilError(t, cc.Create(ctx, base.DeepCopy(), client.DryRunAll),
+		"expected this base cluster to be valid")
+
+	var u unstructured.Unstructured
+	require.UnmarshalInto(t, &u, require.Value(yaml.Marshal(base)))
+	assert.Equal(t, u.GetAPIVersion(), "postgres-operator.crunchydata.com/v1")
+
+	// instances returns two instance sets with the replication delays in one and two.
+	instances := func(one, two string) string {
+		const volume = `dataVolumeClaimSpec: {
+			accessModes: [ReadWriteOnce], resources: { requests: { storage: 1Mi } },
+		}`
+		return fmt.Sprintf(`[
+			{ name: one, %s %s },
+			{ name: two, %s %s },
+		]`, one, volume, two, volume)
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		for _, tt := range []string{"1s", "90 min", "1h30m", "2d", "24 days"} {
+			cluster := u.DeepCopy()
+			require.UnmarshalIntoField(t, cluster,
+				instances("", `replicationDelay: "`+tt+`",`),
+				"spec", "instances")
+
+			assert.NilError(t, cc.Create(ctx, cluster, client.DryRunAll), "%q", tt)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, tt := range []string{"0", "500ms", "25d", "1.5h"} {
+			cluster := u.DeepCopy()
+			require.UnmarshalIntoField(t, cluster,
+				instances("", `replicationDelay: "`+tt+`",`),
+				"spec", "instances")
+
+			err := cc.Create(ctx, cluster, client.DryRunAll)
+			assert.Assert(t, apierrors.IsInvalid(err), "%q", tt)
+
+			details := require.StatusErrorDetails(t, err)
+			assert.Assert(t, len(details.Causes) > 0)
+			assert.Equal(t, details.Causes[0].Field, "spec.instances[1].replicationDelay")
+		}
+	})
+
+	t.Run("AllDelayed", func(t *testing.T) {
+		cluster := u.DeepCopy()
+		require.UnmarshalIntoField(t, cluster,
+			instances(`replicationDelay: 1h,`, `replicationDelay: 2h,`),
+			"spec", "instances")
+
+		err := cc.Create(ctx, cluster, client.DryRunAll)
+		assert.Assert(t, apierrors.IsInvalid(err))
+
+		details := require.StatusErrorDetails(t, err)
+		assert.Assert(t, cmp.Len(details.Causes, 1))
+		assert.Equal(t, details.Causes[0].Field, "spec.instances")
+		assert.Assert(t, cmp.Contains(details.Causes[0].Message, "able to become primary"))
+	})
+
+	t.Run("NoFailover", func(t *testing.T) {
+		cluster := u.DeepCopy()
+		require.UnmarshalIntoField(t, cluster,
+			instances(`replicationDelay: 1h,`, `patroni: { tags: { noFailover: true } },`),
+			"spec", "instances")
+
+		err := cc.Create(ctx, cluster, client.DryRunAll)
+		assert.Assert(t, apierrors.IsInvalid(err))
+
+		details := require.StatusErrorDetails(t, err)
+		assert.Assert(t, cmp.Len(details.Causes, 1))
+		assert.Equal(t, details.Causes[0].Field, "spec.instances")
+
+		cluster = u.DeepCopy()
+		require.UnmarshalIntoField(t, cluster,
+			instances(`patroni: { tags: { failoverPriority: 0 } },`, `patroni: { tags: { failoverPriority: 1 } },`),
+			"spec", "instances")
+
+		assert.NilError(t, cc.Create(ctx, cluster, client.DryRunAll))
+	})
+}
@@ -497,3 +497,66 @@ func TestPostgresClusterInstrumentation(t *testing.T) {
 		})
 	})
 }
+
+func TestPostgresClusterMaintenanceWindows(t *testing.T) {
+	ctx := context.Background()
+	cc := require.Kubernetes(t)
+	t.Parallel()
+
+	namespace := require.Namespace(t, cc)
+	base := v1beta1.NewPostgresCluster()
+	base.Namespace = namespace.Name
+	base.Name = "postgres-maintenance"
+	require.UnmarshalInto(t, &base.Spec, `{
+		postgresVersion: 16,
+		instances: [{
+			dataVolumeClaimSpec: {
+				accessModes: [ReadWriteOnce],
+				resources: { requests: { storage: 1Mi } },
+			},
+		}],
+	}`)
+
+	assert.NilError(t, cc.Create(ctx, base.DeepCopy(), client.DryRunAll),
+		"expected this base to be valid")
+
+	for _, tt := range []struct {
+		window string
+		valid  bool
+		field  string
+	}{
+		{valid: true, window: `schedule: "0 2 * * 6,0", duration: 4h`},
+		{valid: true, window: `schedule: "*/30 * * * *", duration: 10m, timeZone: Europe/Berlin`},
+		{valid: true, window: `schedule: "0 0 1 * *", duration: 7d`},
+
+		{valid: true, window: `schedule: "30 2 * JAN-JUN SAT,SUN", duration: 4h`},
+		{valid: true, window: `schedule: "@daily", duration: 1h`},
+
+		{valid: false, field: "schedule", window: `schedule: "@reboot", duration: 1h`},
+		{valid: false, field: "schedule", window: `schedule: "@daily 5", duration: 1h`},
+		{valid: false, field: "schedule", window: `schedule: "0 2 * *", duration: 1h`},
+		{valid: false, field: "duration", window: `schedule: "0 2 * * *", duration: 30s`},
+		{valid: false, field: "duration", window: `schedule: "0 2 * * *", duration: 8d`},
+		{valid: false, field: "duration", window: `schedule: "0 2 * * *", duration: 1.5h`},
+		{valid: false, field: "duration", window: `schedule: "0 2 * * *"`},
+	} {
+		cluster := base.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			maintenanceWindows: [{ `+tt.window+` }],
+		}`)
+
+		err := cc.Create(ctx, cluster, client.DryRunAll)
+		if tt.valid {
+			assert.NilError(t, err, "window: %s", tt.window)
+		} else {
+			assert.Assert(t, apierrors.IsInvalid(err), "window: %s", tt.window)
+
+			details := require.StatusErrorDetails(t, err)
+			assert.Assert(t, len(details.Causes) > 0)
+
+			for _, cause := range details.Causes {
+				assert.Equal(t, cause.Field, "spec.maintenanceWindows[0]."+tt.field)
+			}
+		}
+	}
+}
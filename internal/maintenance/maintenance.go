@@ -0,0 +1,82 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package maintenance decides when PostgreSQL Operator may disrupt a
+// PostgresCluster by recreating Pods, restarting PostgreSQL, and the like.
+package maintenance
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	// Load time zones from the operator binary rather than its image.
+	_ "time/tzdata"
+
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// Allowed returns whether PostgreSQL Operator may disrupt cluster at now.
+// When it may not, next is when the earliest maintenance window opens, or
+// zero when none will. Windows that are not valid never open; err describes
+// each of them.
+func Allowed(cluster *v1beta1.PostgresCluster, now time.Time) (
+	allowed bool, next time.Time, err error,
+) {
+	if len(cluster.Spec.MaintenanceWindows) == 0 {
+		return true, time.Time{}, nil
+	}
+	if _, ignore := cluster.Annotations[naming.IgnoreMaintenanceWindows]; ignore {
+		return true, time.Time{}, nil
+	}
+
+	var errs []error
+	for i := range cluster.Spec.MaintenanceWindows {
+		open, opens, err := evaluate(cluster.Spec.MaintenanceWindows[i], now)
+
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("maintenanceWindows[%d]: %w", i, err))
+		case open:
+			allowed = true
+		case !opens.IsZero() && (next.IsZero() || opens.Before(next)):
+			next = opens
+		}
+	}
+
+	if allowed {
+		next = time.Time{}
+	}
+	return allowed, next, errors.Join(errs...)
+}
+
+// evaluate returns whether window is open at now. When it is not, opens is
+// the next time it does.
+func evaluate(window v1beta1.MaintenanceWindow, now time.Time) (
+	open bool, opens time.Time, err error,
+) {
+	location, err := time.LoadLocation(window.TimeZone)
+	if err != nil {
+		return false, opens, err
+	}
+
+	schedule, err := ParseSchedule(window.Schedule)
+	if err != nil {
+		return false, opens, err
+	}
+
+	duration := window.Duration.AsDuration().Duration
+	if duration <= 0 {
+		return false, opens, errors.New("duration must be positive")
+	}
+
+	// The window is open when it last opened less than duration ago.
+	now = now.In(location)
+	if start := schedule.Next(now.Add(-duration).Add(time.Nanosecond)); !start.IsZero() && !start.After(now) {
+		return true, opens, nil
+	}
+
+	return false, schedule.Next(now), nil
+}
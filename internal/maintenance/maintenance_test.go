@@ -0,0 +1,107 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestAllowed(t *testing.T) {
+	window := func(t testing.TB, schedule, duration, zone string) v1beta1.MaintenanceWindow {
+		d, err := v1beta1.NewDuration(duration)
+		assert.NilError(t, err)
+		return v1beta1.MaintenanceWindow{Schedule: schedule, Duration: *d, TimeZone: zone}
+	}
+
+	// Saturday, 2026-10-17 14:00:00 UTC
+	now := time.Date(2026, time.October, 17, 14, 0, 0, 0, time.UTC)
+
+	t.Run("NoWindows", func(t *testing.T) {
+		allowed, next, err := Allowed(new(v1beta1.PostgresCluster), now)
+		assert.NilError(t, err)
+		assert.Assert(t, allowed)
+		assert.Assert(t, next.IsZero())
+	})
+
+	t.Run("Open", func(t *testing.T) {
+		cluster := new(v1beta1.PostgresCluster)
+		cluster.Spec.MaintenanceWindows = []v1beta1.MaintenanceWindow{
+			window(t, "0 13 * * *", "1h", ""),
+		}
+
+		allowed, _, err := Allowed(cluster, now.Add(-time.Second))
+		assert.NilError(t, err)
+		assert.Assert(t, allowed)
+
+		allowed, next, err := Allowed(cluster, now)
+		assert.NilError(t, err)
+		assert.Assert(t, !allowed, "expected the window to close after one hour")
+		assert.Equal(t, next, now.Add(23*time.Hour))
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		cluster := new(v1beta1.PostgresCluster)
+		cluster.Spec.MaintenanceWindows = []v1beta1.MaintenanceWindow{
+			window(t, "0 2 * * 0", "4h", "America/New_York"),
+			window(t, "0 22 * * *", "30m", ""),
+		}
+
+		allowed, next, err := Allowed(cluster, now)
+		assert.NilError(t, err)
+		assert.Assert(t, !allowed)
+		assert.Equal(t, next, now.Add(8*time.Hour), "expected the earliest window")
+
+		allowed, _, err = Allowed(cluster, time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC))
+		assert.NilError(t, err)
+		assert.Assert(t, allowed, "expected 5 AM in New York")
+	})
+
+	t.Run("Overlapping", func(t *testing.T) {
+		cluster := new(v1beta1.PostgresCluster)
+		cluster.Spec.MaintenanceWindows = []v1beta1.MaintenanceWindow{
+			window(t, "0 * * * *", "2h", ""),
+		}
+
+		allowed, _, err := Allowed(cluster, now)
+		assert.NilError(t, err)
+		assert.Assert(t, allowed)
+	})
+
+	t.Run("Ignored", func(t *testing.T) {
+		cluster := new(v1beta1.PostgresCluster)
+		cluster.Annotations = map[string]string{naming.IgnoreMaintenanceWindows: ""}
+		cluster.Spec.MaintenanceWindows = []v1beta1.MaintenanceWindow{
+			window(t, "0 22 * * *", "30m", ""),
+		}
+
+		allowed, next, err := Allowed(cluster, now)
+		assert.NilError(t, err)
+		assert.Assert(t, allowed)
+		assert.Assert(t, next.IsZero())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		cluster := new(v1beta1.PostgresCluster)
+		cluster.Spec.MaintenanceWindows = []v1beta1.MaintenanceWindow{
+			window(t, "* * * * *", "1h", "Mars/Olympus_Mons"),
+			window(t, "* * * * 9", "1h", ""),
+			window(t, "* * * * *", "0", ""),
+			window(t, "0 22 * * *", "30m", ""),
+		}
+
+		allowed, next, err := Allowed(cluster, now)
+		assert.ErrorContains(t, err, "maintenanceWindows[0]: unknown time zone Mars/Olympus_Mons")
+		assert.ErrorContains(t, err, "maintenanceWindows[1]: invalid day of week")
+		assert.ErrorContains(t, err, "maintenanceWindows[2]: duration must be positive")
+		assert.Assert(t, !allowed, "expected invalid windows to never open")
+		assert.Equal(t, next, now.Add(8*time.Hour))
+	})
+}
@@ -0,0 +1,206 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maintenance
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed Cron expression with five fields: minute, hour, day of
+// the month, month, and day of the week. Each field is a set of bits.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// When either day field is restricted, a day matches when either one does.
+	// - https://man7.org/linux/man-pages/man5/crontab.5.html
+	domAny, dowAny bool
+}
+
+// scheduleField describes the values allowed in one field of a Schedule.
+type scheduleField struct {
+	name     string
+	min, max int
+
+	// names are case-insensitive aliases of the values starting at min.
+	names []string
+}
+
+var scheduleFields = [5]scheduleField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{
+		"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec",
+	}},
+	{name: "day of week", min: 0, max: 7, names: []string{
+		"sun", "mon", "tue", "wed", "thu", "fri", "sat",
+	}},
+}
+
+// scheduleMacros are the Cron expressions that can be written in one word.
+var scheduleMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSchedule parses a Cron expression, such as "30 2 * * 6,0". Each field
+// is "*" or a comma-separated list of numbers and ranges, optionally followed
+// by a step, e.g. "1-5", "*/15", or "0-30/10". Sunday is 0 or 7. Months and
+// days of the week can be names, e.g. "JAN" or "MON-FRI". The expression can
+// also be one of "@yearly", "@annually", "@monthly", "@weekly", "@daily",
+// "@midnight", or "@hourly".
+func ParseSchedule(spec string) (*Schedule, error) {
+	if strings.HasPrefix(spec, "@") {
+		expanded, ok := scheduleMacros[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("unknown macro: %q", spec)
+		}
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(scheduleFields) {
+		return nil, fmt.Errorf("expected %d fields, found %d: %q",
+			len(scheduleFields), len(fields), spec)
+	}
+
+	var bits [5]uint64
+	for i := range fields {
+		var err error
+		if bits[i], err = parseScheduleField(fields[i], scheduleFields[i]); err != nil {
+			return nil, fmt.Errorf("invalid %s in %q: %w", scheduleFields[i].name, spec, err)
+		}
+	}
+
+	// Sunday is both 0 and 7.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1 << 0
+	}
+
+	return &Schedule{
+		minute: bits[0], hour: bits[1], dom: bits[2], month: bits[3], dow: bits[4],
+		domAny: fields[2] == "*", dowAny: fields[4] == "*",
+	}, nil
+}
+
+// parseScheduleField returns the values of one Cron field as a set of bits.
+func parseScheduleField(value string, field scheduleField) (uint64, error) {
+	var bits uint64
+
+	for part := range strings.SplitSeq(value, ",") {
+		first, last, step := field.min, field.max, 1
+
+		rng, stepText, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("step %q must be a positive number", stepText)
+			}
+			step = n
+		}
+
+		if rng != "*" {
+			lo, hi, isRange := strings.Cut(rng, "-")
+
+			n, err := field.parse(lo)
+			if err != nil {
+				return 0, err
+			}
+			first, last = n, n
+
+			if isRange {
+				if n, err = field.parse(hi); err != nil {
+					return 0, err
+				}
+				last = n
+			} else if hasStep {
+				// A single value with a step repeats until the maximum, like "5/15".
+				last = field.max
+			}
+		}
+
+		if first < field.min || last > field.max || first > last {
+			return 0, fmt.Errorf("%q is outside %d-%d", part, field.min, field.max)
+		}
+		for n := first; n <= last; n += step {
+			bits |= 1 << n
+		}
+	}
+
+	return bits, nil
+}
+
+// parse returns the number of one value in field, which is a number or a name.
+func (field scheduleField) parse(value string) (int, error) {
+	if i := slices.Index(field.names, strings.ToLower(value)); i >= 0 {
+		return field.min + i, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", value)
+	}
+	return n, nil
+}
+
+// Next returns the earliest minute at or after t that matches s, in the
+// location of t. It returns the zero time when nothing matches within five
+// years, e.g. "0 0 31 2 *".
+func (s *Schedule) Next(t time.Time) time.Time {
+	if truncated := t.Truncate(time.Minute); !truncated.Equal(t) {
+		t = truncated.Add(time.Minute)
+	}
+
+	loc := t.Location()
+	limit := t.Year() + 5
+
+	for t.Year() <= limit {
+		if s.month&(1<<int(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<t.Hour()) == 0 {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+
+			// Skip ahead when a daylight saving transition repeats an hour.
+			if !next.After(t) {
+				next = t.Truncate(time.Hour).Add(time.Hour)
+			}
+			t = next
+			continue
+		}
+		if s.minute&(1<<t.Minute()) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchesDay returns whether the date of t matches either day field of s.
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dom := s.dom&(1<<t.Day()) != 0
+	dow := s.dow&(1<<int(t.Weekday())) != 0
+
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
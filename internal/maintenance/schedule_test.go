@@ -0,0 +1,147 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseSchedule(t *testing.T) {
+	t.Run("Invalid", func(t *testing.T) {
+		for _, tt := range []struct{ spec, message string }{
+			{spec: "", message: "expected 5 fields, found 0"},
+			{spec: "* * * *", message: "expected 5 fields, found 4"},
+			{spec: "* * * * * *", message: "expected 5 fields, found 6"},
+			{spec: "60 * * * *", message: `invalid minute in "60 * * * *": "60" is outside 0-59`},
+			{spec: "* 24 * * *", message: `invalid hour`},
+			{spec: "* * 0 * *", message: `invalid day of month`},
+			{spec: "* * * 13 *", message: `invalid month`},
+			{spec: "* * * * 8", message: `invalid day of week`},
+			{spec: "x * * * *", message: `"x" is not a number`},
+			{spec: "5-x * * * *", message: `"x" is not a number`},
+			{spec: "5-1 * * * *", message: `"5-1" is outside 0-59`},
+			{spec: "*/0 * * * *", message: `step "0" must be a positive number`},
+			{spec: "*/x * * * *", message: `step "x" must be a positive number`},
+			{spec: "1,,2 * * * *", message: `"" is not a number`},
+			{spec: "* * * * SUNDAY", message: `"SUNDAY" is not a number`},
+			{spec: "* * * MON *", message: `"MON" is not a number`},
+			{spec: "@reboot", message: `unknown macro: "@reboot"`},
+			{spec: "@daily 5", message: `unknown macro`},
+		} {
+			_, err := ParseSchedule(tt.spec)
+			assert.ErrorContains(t, err, tt.message, "spec: %q", tt.spec)
+		}
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		s, err := ParseSchedule("*/15 1-3,22 5/10 * 7")
+		assert.NilError(t, err)
+
+		assert.Equal(t, s.minute, uint64(1<<0|1<<15|1<<30|1<<45))
+		assert.Equal(t, s.hour, uint64(1<<1|1<<2|1<<3|1<<22))
+		assert.Equal(t, s.dom, uint64(1<<5|1<<15|1<<25))
+		assert.Equal(t, s.month, uint64(0b1111111111110))
+		assert.Equal(t, s.dow, uint64(1<<0|1<<7), "expected Sunday to be both 0 and 7")
+		assert.Assert(t, !s.domAny)
+		assert.Assert(t, !s.dowAny)
+	})
+
+	t.Run("Names", func(t *testing.T) {
+		s, err := ParseSchedule("0 2 * jan,Jul-DEC/2 MON-FRI,sun")
+		assert.NilError(t, err)
+
+		assert.Equal(t, s.month, uint64(1<<1|1<<7|1<<9|1<<11))
+		assert.Equal(t, s.dow, uint64(0b0111111))
+
+		s, err = ParseSchedule("0 2 * * SAT")
+		assert.NilError(t, err)
+		assert.Equal(t, s.dow, uint64(1<<6))
+	})
+
+	t.Run("Macros", func(t *testing.T) {
+		for macro, spec := range map[string]string{
+			"@yearly":   "0 0 1 1 *",
+			"@ANNUALLY": "0 0 1 1 *",
+			"@monthly":  "0 0 1 * *",
+			"@weekly":   "0 0 * * 0",
+			"@daily":    "0 0 * * *",
+			"@midnight": "0 0 * * *",
+			"@hourly":   "0 * * * *",
+		} {
+			actual, err := ParseSchedule(macro)
+			assert.NilError(t, err, "macro: %q", macro)
+
+			expected, err := ParseSchedule(spec)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, actual, expected, cmp.AllowUnexported(Schedule{}))
+		}
+	})
+}
+
+func TestScheduleNext(t *testing.T) {
+	parse := func(t testing.TB, spec string) *Schedule {
+		s, err := ParseSchedule(spec)
+		assert.NilError(t, err)
+		return s
+	}
+
+	// Saturday, 2026-10-17 14:00:00 UTC
+	now := time.Date(2026, time.October, 17, 14, 0, 0, 0, time.UTC)
+
+	t.Run("Inclusive", func(t *testing.T) {
+		assert.Equal(t, parse(t, "0 14 * * *").Next(now), now)
+		assert.Equal(t, parse(t, "0 14 * * *").Next(now.Add(time.Second)),
+			now.AddDate(0, 0, 1), "expected the next day")
+	})
+
+	t.Run("Minutes", func(t *testing.T) {
+		assert.Equal(t, parse(t, "*/25 * * * *").Next(now.Add(time.Minute)),
+			now.Add(25*time.Minute))
+	})
+
+	t.Run("Weekdays", func(t *testing.T) {
+		assert.Equal(t, parse(t, "30 2 * * 1-5").Next(now),
+			time.Date(2026, time.October, 19, 2, 30, 0, 0, time.UTC))
+	})
+
+	t.Run("EitherDay", func(t *testing.T) {
+		// Either the 1st of the month or a Tuesday.
+		assert.Equal(t, parse(t, "0 0 1 * 2").Next(now),
+			time.Date(2026, time.October, 20, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, parse(t, "0 0 1 * 2").Next(now.AddDate(0, 0, 11)),
+			time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC))
+	})
+
+	t.Run("Months", func(t *testing.T) {
+		assert.Equal(t, parse(t, "0 3 29 2 *").Next(now),
+			time.Date(2028, time.February, 29, 3, 0, 0, 0, time.UTC))
+	})
+
+	t.Run("Never", func(t *testing.T) {
+		assert.Assert(t, parse(t, "0 0 31 2 *").Next(now).IsZero())
+	})
+
+	t.Run("Location", func(t *testing.T) {
+		newYork, err := time.LoadLocation("America/New_York")
+		assert.NilError(t, err)
+
+		next := parse(t, "0 2 * * *").Next(now.In(newYork))
+		assert.Equal(t, next.Location(), newYork)
+		assert.Equal(t, next.UTC(), time.Date(2026, time.October, 18, 6, 0, 0, 0, time.UTC))
+
+		// There is no 2:30 AM when daylight saving time starts.
+		start := time.Date(2027, time.March, 13, 12, 0, 0, 0, newYork)
+		assert.Equal(t, parse(t, "30 2 * * *").Next(start),
+			time.Date(2027, time.March, 15, 2, 30, 0, 0, newYork))
+
+		// There are two 1:30 AMs when daylight saving time ends.
+		end := time.Date(2026, time.November, 1, 1, 45, 0, 0, newYork)
+		assert.Equal(t, parse(t, "30 * * * *").Next(end).Sub(end), 45*time.Minute)
+	})
+}
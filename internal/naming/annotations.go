@@ -58,6 +58,34 @@ const (
 	// should be mounted to cloud repo backup jobs so that the backup logs can be persisted.
 	PGBackRestCloudLogVolume = annotationPrefix + "pgbackrest-cloud-log-volume"
 
+	// PostgresPasswordRotate is an annotation that is added to the Secret of a
+	// PostgreSQL user to generate a new password for that user. A new password
+	// is generated each time the value of the annotation changes.
+	PostgresPasswordRotate = annotationPrefix + "rotate-password"
+
+	// PostgresPasswordRotatedAt is an annotation on the Secret of a PostgreSQL
+	// user that holds an RFC3339 formatted timestamp of when its password was
+	// last generated.
+	PostgresPasswordRotatedAt = annotationPrefix + "password-rotated-at"
+
+	// PostgresPasswordRotation is an annotation on the Secret of a PostgreSQL
+	// user that holds the last value of [PostgresPasswordRotate] that caused
+	// a new password to be generated.
+	PostgresPasswordRotation = annotationPrefix + "password-rotation"
+
+	// RootCertificateRotation is an annotation on the root certificate Secret
+	// that rotates the root certificate authority in stages. The value "stage"
+	// generates a new root and publishes it alongside the current one. The
+	// value "promote" then signs certificates with the new root while the old
+	// one is still published. Removing the annotation stops publishing the
+	// old root.
+	RootCertificateRotation = annotationPrefix + "root-certificate-rotation"
+
+	// IgnoreMaintenanceWindows is an annotation on a PostgresCluster that lets
+	// PostgreSQL Operator disrupt the cluster outside its maintenance windows.
+	// Its value is ignored. Remove it to honor the windows again.
+	IgnoreMaintenanceWindows = annotationPrefix + "ignore-maintenance-windows"
+
 	// PostgresExporterCollectorsAnnotation is an annotation used to allow users to control whether or
 	// not postgres_exporter default metrics, settings, and collectors are enabled. The value "None"
 	// disables all postgres_exporter defaults. Disabling the defaults may cause errors in dashboards.
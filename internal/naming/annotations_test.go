@@ -15,6 +15,7 @@ func TestAnnotationsValid(t *testing.T) {
 	assert.Assert(t, nil == validation.IsQualifiedName(AuthorizeBackupRemovalAnnotation))
 	assert.Assert(t, nil == validation.IsQualifiedName(AutoCreateUserSchemaAnnotation))
 	assert.Assert(t, nil == validation.IsQualifiedName(Finalizer))
+	assert.Assert(t, nil == validation.IsQualifiedName(IgnoreMaintenanceWindows))
 	assert.Assert(t, nil == validation.IsQualifiedName(PatroniSwitchover))
 	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestBackup))
 	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestBackupJobCompletion))
@@ -24,4 +25,8 @@ func TestAnnotationsValid(t *testing.T) {
 	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestCloudLogVolume))
 	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestRestore))
 	assert.Assert(t, nil == validation.IsQualifiedName(PostgresExporterCollectorsAnnotation))
+	assert.Assert(t, nil == validation.IsQualifiedName(PostgresPasswordRotate))
+	assert.Assert(t, nil == validation.IsQualifiedName(PostgresPasswordRotatedAt))
+	assert.Assert(t, nil == validation.IsQualifiedName(PostgresPasswordRotation))
+	assert.Assert(t, nil == validation.IsQualifiedName(RootCertificateRotation))
 }
@@ -67,6 +67,10 @@ const (
 
 	LabelPGBackRestCronJob = labelPrefix + "pgbackrest-cronjob"
 
+	// LabelPGBackRestJob is used to indicate that a Job or Pod runs the command
+	// of the PGBackRestJob with this name
+	LabelPGBackRestJob = labelPrefix + "pgbackrest-job"
+
 	// LabelPGBackRestRestore is used to indicate that a Job or Pod is for a pgBackRest restore
 	LabelPGBackRestRestore = labelPrefix + "pgbackrest-restore"
 
@@ -84,6 +88,16 @@ const (
 	// LabelStartupInstance is used to indicate the startup instance associated with a resource
 	LabelStartupInstance = labelPrefix + "startup-instance"
 
+	// LabelLoadBalance is used to indicate whether or not an instance Pod should
+	// receive traffic from the replica Service. It is "true" or "false" on every
+	// instance Pod of a cluster that excludes any instance sets from that Service.
+	LabelLoadBalance = labelPrefix + "load-balance"
+
+	// LabelLagging is used to indicate whether or not an instance Pod is too far
+	// behind the primary to receive traffic from the replica Service. It is
+	// "true" or "false" on instance Pods of a cluster that limits replica lag.
+	LabelLagging = labelPrefix + "lagging"
+
 	RolePrimary = "primary"
 	RoleReplica = "replica"
 
@@ -188,6 +202,16 @@ func PGBackRestBackupJobLabels(clusterName, repoName string,
 	return labels.Merge(jobLabels, repoLabels)
 }
 
+// PGBackRestJobLabels provides labels for the Jobs that run PGBackRestJobs.
+func PGBackRestJobLabels(clusterName, repoName, jobName string) labels.Set {
+	commonLabels := PGBackRestLabels(clusterName)
+	jobLabels := map[string]string{
+		LabelPGBackRestRepo: repoName,
+		LabelPGBackRestJob:  jobName,
+	}
+	return labels.Merge(jobLabels, commonLabels)
+}
+
 // PGBackRestBackupJobSelector provides a selector for querying all pgBackRest
 // resources
 func PGBackRestBackupJobSelector(clusterName, repoName string,
@@ -27,11 +27,14 @@ func TestLabelsValid(t *testing.T) {
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestBackup))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestConfig))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestDedicated))
+	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestJob))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestRepo))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestRepoVolume))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestRestore))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestRestoreConfig))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGMonitorDiscovery))
+	assert.Assert(t, nil == validation.IsQualifiedName(LabelLoadBalance))
+	assert.Assert(t, nil == validation.IsQualifiedName(LabelLagging))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPostgresUser))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelStandalonePGAdmin))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelStartupInstance))
@@ -184,6 +187,14 @@ func TestPGBackRestLabelFuncs(t *testing.T) {
 	assert.Equal(t, pgBackRestCronJobLabels.Get(LabelPGBackRestRepo), repoName)
 	assert.Equal(t, pgBackRestCronJobLabels.Get(LabelPGBackRestBackup), string(BackupScheduled))
 
+	// verify the labels that identify the Jobs of PGBackRestJobs
+	pgBackRestJobLabels := PGBackRestJobLabels(clusterName, repoName, "testJob")
+	assert.Equal(t, pgBackRestJobLabels.Get(LabelCluster), clusterName)
+	assert.Check(t, pgBackRestJobLabels.Has(LabelPGBackRest))
+	assert.Equal(t, pgBackRestJobLabels.Get(LabelPGBackRestRepo), repoName)
+	assert.Equal(t, pgBackRestJobLabels.Get(LabelPGBackRestJob), "testJob")
+	assert.Check(t, !pgBackRestJobLabels.Has(LabelPGBackRestBackup))
+
 	// verify the labels that identify pgBackRest dedicated repository host resources
 	pgBackRestDedicatedLabels := PGBackRestDedicatedLabels(clusterName)
 	assert.Equal(t, pgBackRestDedicatedLabels.Get(LabelCluster), clusterName)
@@ -7,6 +7,7 @@ package naming
 import (
 	"fmt"
 	"hash/fnv"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -216,6 +217,16 @@ func ClusterPGBouncer(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	}
 }
 
+// ClusterPGBouncerReadOnly returns the ObjectMeta necessary to lookup the
+// Service that exposes the read-only connection pools of cluster's PgBouncer
+// proxy.
+func ClusterPGBouncerReadOnly(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name + "-pgbouncer-ro",
+	}
+}
+
 // ClusterPodService returns the ObjectMeta necessary to lookup the Service
 // that is responsible for the network identity of Pods.
 func ClusterPodService(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
@@ -247,6 +258,17 @@ func ClusterReplicaService(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	}
 }
 
+// InstanceSetReplicaService returns the ObjectMeta necessary to lookup the
+// Service that exposes the PostgreSQL replicas of set.
+func InstanceSetReplicaService(
+	cluster *v1beta1.PostgresCluster, set *v1beta1.PostgresInstanceSetSpec,
+) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name + "-" + set.Name + "-replicas",
+	}
+}
+
 // ClusterDedicatedSnapshotVolume returns the ObjectMeta for the dedicated Snapshot
 // volume for a cluster.
 func ClusterDedicatedSnapshotVolume(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
@@ -442,6 +464,16 @@ func PatroniTrigger(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	}
 }
 
+// PatroniSynchronousState returns the ObjectMeta necessary to lookup the
+// ConfigMap or Endpoints Patroni creates for cluster to track its synchronous
+// standbys. See Patroni DCS "sync_path".
+func PatroniSynchronousState(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: cluster.Namespace,
+		Name:      PatroniScope(cluster) + "-sync",
+	}
+}
+
 // PGBackRestConfig returns the ObjectMeta for a pgBackRest ConfigMap
 func PGBackRestConfig(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	return metav1.ObjectMeta{
@@ -459,6 +491,18 @@ func PGBackRestBackupJob(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	}
 }
 
+// PGBackRestRepoBackup returns the ObjectMeta for the PGBackRestBackup that
+// describes the backup with label in repoName of cluster.
+func PGBackRestRepoBackup(cluster *v1beta1.PostgresCluster, repoName, label string) metav1.ObjectMeta {
+	// Backup labels look like "20240101-000000F_20240102-000000I".
+	label = strings.ReplaceAll(strings.ToLower(label), "_", "-")
+
+	return metav1.ObjectMeta{
+		Namespace: cluster.GetNamespace(),
+		Name:      cluster.GetName() + "-" + repoName + "-" + label,
+	}
+}
+
 // PGBackRestCronJob returns the ObjectMeta for a pgBackRest CronJob
 func PGBackRestCronJob(cluster *v1beta1.PostgresCluster, backuptype, repoName string) metav1.ObjectMeta {
 	return metav1.ObjectMeta{
@@ -467,6 +511,15 @@ func PGBackRestCronJob(cluster *v1beta1.PostgresCluster, backuptype, repoName st
 	}
 }
 
+// PGBackRestJobJob returns the ObjectMeta for the Job that runs the command of
+// a PGBackRestJob.
+func PGBackRestJobJob(job *v1beta1.PGBackRestJob) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: job.GetNamespace(),
+		Name:      job.GetName() + "-pgbackrest",
+	}
+}
+
 // PGBackRestRestoreJob returns the ObjectMeta for a pgBackRest restore Job
 func PGBackRestRestoreJob(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	return metav1.ObjectMeta{
@@ -538,6 +591,34 @@ func PostgresTLSSecret(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	}
 }
 
+// IssuedPostgresTLSCertificate returns the ObjectMeta necessary to lookup the
+// cert-manager Certificate and Secret of the Postgres server certificate.
+func IssuedPostgresTLSCertificate(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name + "-cluster-tls",
+	}
+}
+
+// IssuedReplicationClientCertificate returns the ObjectMeta necessary to
+// lookup the cert-manager Certificate and Secret of the Patroni client
+// authentication certificate.
+func IssuedReplicationClientCertificate(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name + "-replication-tls",
+	}
+}
+
+// IssuedPGBouncerCertificate returns the ObjectMeta necessary to lookup the
+// cert-manager Certificate and Secret of the PgBouncer server certificate.
+func IssuedPGBouncerCertificate(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name + "-pgbouncer-tls",
+	}
+}
+
 // MovePGDataDirJob returns the ObjectMeta for a pgData directory move Job
 func MovePGDataDirJob(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	return metav1.ObjectMeta{
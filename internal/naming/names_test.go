@@ -86,6 +86,7 @@ func TestClusterNamesUniqueAndValid(t *testing.T) {
 			{"ClusterPGBouncer", ClusterPGBouncer(cluster)},
 			{"PatroniDistributedConfiguration", PatroniDistributedConfiguration(cluster)},
 			{"PatroniLeaderConfigMap", PatroniLeaderConfigMap(cluster)},
+			{"PatroniSynchronousState", PatroniSynchronousState(cluster)},
 			{"PatroniTrigger", PatroniTrigger(cluster)},
 			{"PGBackRestConfig", PGBackRestConfig(cluster)},
 		})
@@ -109,10 +110,21 @@ func TestClusterNamesUniqueAndValid(t *testing.T) {
 	t.Run("Jobs", func(t *testing.T) {
 		testUniqueAndValid(t, []test{
 			{"PGBackRestBackupJob", PGBackRestBackupJob(cluster)},
+			{"PGBackRestJobJob", PGBackRestJobJob(&v1beta1.PGBackRestJob{
+				ObjectMeta: metav1.ObjectMeta{Namespace: cluster.Namespace, Name: "adhoc"},
+			})},
 			{"PGBackRestRestoreJob", PGBackRestRestoreJob(cluster)},
 		})
 	})
 
+	t.Run("PGBackRestBackups", func(t *testing.T) {
+		testUniqueAndValid(t, []test{
+			{"PGBackRestRepoBackup", PGBackRestRepoBackup(cluster, "repo1", "20240101-000000F")},
+			{"PGBackRestRepoBackup", PGBackRestRepoBackup(cluster, "repo1", "20240101-000000F_20240102-000000I")},
+			{"PGBackRestRepoBackup", PGBackRestRepoBackup(cluster, "repo2", "20240101-000000F")},
+		})
+	})
+
 	t.Run("PodDisruptionBudgets", func(t *testing.T) {
 		testUniqueAndValid(t, []test{
 			{"InstanceSetPDB", InstanceSet(cluster, instanceSet)},
@@ -140,6 +152,9 @@ func TestClusterNamesUniqueAndValid(t *testing.T) {
 			{"DeprecatedPostgresUserSecret", DeprecatedPostgresUserSecret(cluster)},
 			{"PostgresTLSSecret", PostgresTLSSecret(cluster)},
 			{"ReplicationClientCertSecret", ReplicationClientCertSecret(cluster)},
+			{"IssuedPostgresTLSCertificate", IssuedPostgresTLSCertificate(cluster)},
+			{"IssuedReplicationClientCertificate", IssuedReplicationClientCertificate(cluster)},
+			{"IssuedPGBouncerCertificate", IssuedPGBouncerCertificate(cluster)},
 			{"MonitoringUserSecret", MonitoringUserSecret(cluster)},
 		})
 
@@ -173,13 +188,16 @@ func TestClusterNamesUniqueAndValid(t *testing.T) {
 	t.Run("Services", func(t *testing.T) {
 		testUniqueAndValid(t, []test{
 			{"ClusterPGBouncer", ClusterPGBouncer(cluster)},
+			{"ClusterPGBouncerReadOnly", ClusterPGBouncerReadOnly(cluster)},
 			{"ClusterPGAdmin", ClusterPGAdmin(cluster)},
 			{"ClusterPodService", ClusterPodService(cluster)},
 			{"ClusterPrimaryService", ClusterPrimaryService(cluster)},
 			{"ClusterReplicaService", ClusterReplicaService(cluster)},
+			{"InstanceSetReplicaService", InstanceSetReplicaService(cluster, instanceSet)},
 			// Patroni can use Endpoints which relate directly to a Service.
 			{"PatroniDistributedConfiguration", PatroniDistributedConfiguration(cluster)},
 			{"PatroniLeaderEndpoints", PatroniLeaderEndpoints(cluster)},
+			{"PatroniSynchronousState", PatroniSynchronousState(cluster)},
 			{"PatroniTrigger", PatroniTrigger(cluster)},
 		})
 	})
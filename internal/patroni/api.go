@@ -10,7 +10,9 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/crunchydata/postgres-operator/internal/logging"
 )
@@ -206,3 +208,81 @@ func (exec Executor) GetTimeline(ctx context.Context) (int64, error) {
 
 	return 0, err
 }
+
+// MemberStatus is what the Patroni REST API reports about one member.
+type MemberStatus struct {
+	Role  string
+	State string
+
+	// Location is the latest location in the write-ahead log written by the leader.
+	Location *int64
+
+	// ReceivedLocation is the latest location in the write-ahead log received
+	// by a replica; ReplayedLocation is the latest it replayed.
+	ReceivedLocation *int64
+	ReplayedLocation *int64
+
+	// ReplayedTimestamp is when the last transaction replayed by a replica
+	// committed on the leader. It is nil when a replica has not replayed any
+	// transactions since it started.
+	ReplayedTimestamp *time.Time
+}
+
+// Leader returns true when m is the leader of its Patroni cluster.
+func (m MemberStatus) Leader() bool {
+	return m.Role == "primary" || m.Role == "master" || m.Role == "standby_leader"
+}
+
+// GetMemberStatus calls the Patroni REST API of the member where exec runs
+// and returns what it reports about that member.
+func (exec Executor) GetMemberStatus(ctx context.Context) (MemberStatus, error) {
+	var stdout, stderr bytes.Buffer
+
+	// The "GET /patroni" endpoint does not require a client certificate. Patroni
+	// listens on every interface, so connect to it locally while verifying its
+	// certificate by the name it advertises to other members.
+	// - https://patroni.readthedocs.io/en/latest/rest_api.html#monitoring-endpoint
+	err := exec(ctx, nil, &stdout, &stderr, "bash", "-ceu", "--", `
+exec curl --silent --show-error --fail --cacert "$1" \
+  --connect-to "::localhost:" "https://${PATRONI_RESTAPI_CONNECT_ADDRESS}/patroni"
+`, "-", path.Join(configDirectory, certAuthorityConfigPath))
+	if err != nil {
+		return MemberStatus{}, err
+	}
+
+	if stderr.String() != "" {
+		return MemberStatus{}, errors.New(stderr.String())
+	}
+
+	var status struct {
+		Role  string `json:"role"`
+		State string `json:"state"`
+		XLog  struct {
+			Location          *int64  `json:"location"`
+			ReceivedLocation  *int64  `json:"received_location"`
+			ReplayedLocation  *int64  `json:"replayed_location"`
+			ReplayedTimestamp *string `json:"replayed_timestamp"`
+		} `json:"xlog"`
+	}
+	if err = json.Unmarshal(stdout.Bytes(), &status); err != nil {
+		return MemberStatus{}, err
+	}
+
+	result := MemberStatus{
+		Role:             status.Role,
+		State:            status.State,
+		Location:         status.XLog.Location,
+		ReceivedLocation: status.XLog.ReceivedLocation,
+		ReplayedLocation: status.XLog.ReplayedLocation,
+	}
+
+	// Patroni formats this timestamp the way Python does.
+	if status.XLog.ReplayedTimestamp != nil {
+		if t, err := time.Parse("2006-01-02 15:04:05.999999999-07:00",
+			*status.XLog.ReplayedTimestamp); err == nil {
+			result.ReplayedTimestamp = &t
+		}
+	}
+
+	return result, nil
+}
@@ -12,6 +12,7 @@ import (
 	"os/exec"
 	"strings"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 )
@@ -287,3 +288,87 @@ func TestExecutorGetTimeline(t *testing.T) {
 		assert.Equal(t, tl, int64(4))
 	})
 }
+
+func TestExecutorGetMemberStatus(t *testing.T) {
+	t.Run("Error", func(t *testing.T) {
+		expected := errors.New("bang")
+		_, actual := Executor(func(
+			context.Context, io.Reader, io.Writer, io.Writer, ...string,
+		) error {
+			return expected
+		}).GetMemberStatus(context.Background())
+
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("Stderr", func(t *testing.T) {
+		_, actual := Executor(func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			_, _ = stderr.Write([]byte(`no luck`))
+			return nil
+		}).GetMemberStatus(context.Background())
+
+		assert.Error(t, actual, "no luck")
+	})
+
+	t.Run("BadJSON", func(t *testing.T) {
+		_, actual := Executor(func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			_, _ = stdout.Write([]byte(`no luck`))
+			return nil
+		}).GetMemberStatus(context.Background())
+
+		assert.Error(t, actual, "invalid character 'o' in literal null (expecting 'u')")
+	})
+
+	t.Run("Leader", func(t *testing.T) {
+		status, actual := Executor(func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			assert.DeepEqual(t, command[:3], []string{"bash", "-ceu", "--"})
+			assert.Assert(t, strings.Contains(command[3], "/patroni"))
+			assert.DeepEqual(t, command[4:], []string{"-", "/etc/patroni/~postgres-operator/patroni.ca-roots"})
+
+			_, _ = stdout.Write([]byte(`{"state": "running", "role": "primary", "server_version": 160004, "xlog": {"location": 83886432}, "timeline": 2}`))
+			return nil
+		}).GetMemberStatus(context.Background())
+
+		assert.NilError(t, actual)
+		assert.Assert(t, status.Leader())
+		assert.Equal(t, status.State, "running")
+		assert.Assert(t, status.Location != nil)
+		assert.Equal(t, *status.Location, int64(83886432))
+		assert.Assert(t, status.ReplayedLocation == nil)
+	})
+
+	t.Run("Replica", func(t *testing.T) {
+		status, actual := Executor(func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			_, _ = stdout.Write([]byte(`{"state": "running", "role": "replica", "xlog": {"received_location": 83886432, "replayed_location": 67109096, "replayed_timestamp": "2024-11-14 22:13:20.123456+00:00", "paused": false}, "timeline": 2}`))
+			return nil
+		}).GetMemberStatus(context.Background())
+
+		assert.NilError(t, actual)
+		assert.Assert(t, !status.Leader())
+		assert.Assert(t, status.Location == nil)
+		assert.Equal(t, *status.ReceivedLocation, int64(83886432))
+		assert.Equal(t, *status.ReplayedLocation, int64(67109096))
+		assert.Assert(t, status.ReplayedTimestamp != nil)
+		assert.Equal(t, status.ReplayedTimestamp.UTC().Format(time.RFC3339Nano), "2024-11-14T22:13:20.123456Z")
+	})
+
+	t.Run("NoTransactions", func(t *testing.T) {
+		status, actual := Executor(func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			_, _ = stdout.Write([]byte(`{"state": "running", "role": "replica", "xlog": {"received_location": 83886432, "replayed_location": 83886432, "replayed_timestamp": null, "paused": false}}`))
+			return nil
+		}).GetMemberStatus(context.Background())
+
+		assert.NilError(t, actual)
+		assert.Assert(t, status.ReplayedTimestamp == nil)
+	})
+}
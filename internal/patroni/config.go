@@ -222,6 +222,21 @@ func DynamicConfiguration(
 	root["ttl"] = *spec.Patroni.LeaderLeaseDurationSeconds
 	root["loop_wait"] = *spec.Patroni.SyncPeriodSeconds
 
+	// Synchronous replication settings replace any in the configuration above.
+	// - https://patroni.readthedocs.io/en/latest/replication_modes.html
+	if sync := spec.Patroni.Synchronous; sync != nil {
+		switch sync.Mode {
+		case v1beta1.PatroniSynchronousModeOff:
+			root["synchronous_mode"] = false
+		case v1beta1.PatroniSynchronousModeQuorum:
+			root["synchronous_mode"] = "quorum"
+		default:
+			root["synchronous_mode"] = true
+		}
+		root["synchronous_mode_strict"] = sync.Mode == v1beta1.PatroniSynchronousModeStrict
+		root["synchronous_node_count"] = max(1, initialize.FromPointer(sync.Standbys))
+	}
+
 	postgresql := map[string]any{
 		// TODO(cbandy): explain this. requires an archive, perhaps.
 		"use_slots": false,
@@ -440,10 +455,19 @@ func instanceConfigFiles(cluster, instance *corev1.ConfigMap) []corev1.VolumePro
 	}
 }
 
-// instanceYAML returns Patroni settings that apply to instance.
+// LoadBalanced returns whether or not instances of instance should receive
+// traffic from the replica Service.
+func LoadBalanced(instance *v1beta1.PostgresInstanceSetSpec) bool {
+	return instance.ReplicationDelay == nil &&
+		(instance.Patroni == nil || instance.Patroni.Tags == nil || !instance.Patroni.Tags.NoLoadBalance)
+}
+
+// instanceYAML returns Patroni settings that apply to instance. When
+// replicateFrom is not empty, instance replicates from that member rather
+// than the leader.
 func instanceYAML(
 	cluster *v1beta1.PostgresCluster, instance *v1beta1.PostgresInstanceSetSpec,
-	pgbackrestReplicaCreateCommand []string,
+	replicateFrom string, pgbackrestReplicaCreateCommand []string,
 ) (string, error) {
 	root := map[string]any{
 		// Missing here is "name" which cannot be known until the instance Pod is
@@ -468,10 +492,44 @@ func instanceYAML(
 			// See the PATRONI_RESTAPI_LISTEN environment variable.
 		},
 
-		"tags": map[string]any{
-			// TODO(cbandy): "nofailover"
-			// TODO(cbandy): "nosync"
-		},
+		"tags": map[string]any{},
+	}
+
+	// Use the tags of the instance set. Patroni expects "replicatefrom" to be
+	// the name of a member, so the caller chooses one from the named set.
+	// - https://patroni.readthedocs.io/en/latest/yaml_configuration.html#tags
+	tags := root["tags"].(map[string]any)
+	if spec := instance.Patroni; spec != nil && spec.Tags != nil {
+		if spec.Tags.NoFailover {
+			tags["nofailover"] = true
+		}
+		if spec.Tags.FailoverPriority != nil {
+			tags["failover_priority"] = *spec.Tags.FailoverPriority
+		}
+		if spec.Tags.NoLoadBalance {
+			tags["noloadbalance"] = true
+		}
+		if spec.Tags.NoSync {
+			tags["nosync"] = true
+		}
+	}
+	if replicateFrom != "" {
+		tags["replicatefrom"] = replicateFrom
+	}
+
+	// Instances that are not eligible to be synchronous standbys are tagged
+	// so that Patroni does not choose them.
+	if spec := cluster.Spec.Patroni; spec != nil &&
+		spec.Synchronous.Enabled() && !spec.Synchronous.Eligible(instance.Name) {
+		tags["nosync"] = true
+	}
+
+	// Delayed instances are never promoted, never synchronous, and should not
+	// receive load balanced traffic.
+	if instance.ReplicationDelay != nil {
+		tags["nofailover"] = true
+		tags["noloadbalance"] = true
+		tags["nosync"] = true
 	}
 
 	//nolint:gosec // G101: "pgpass" is a Patroni configuration key for a file path, not a credential.
@@ -522,6 +580,15 @@ func instanceYAML(
 	// method? This is a list and cannot be merged.
 	postgresql["create_replica_methods"] = methods
 
+	// Patroni applies these parameters on top of those in the DCS. PostgreSQL
+	// stores the delay in milliseconds.
+	if instance.ReplicationDelay != nil {
+		delay := instance.ReplicationDelay.AsDuration()
+		postgresql["parameters"] = map[string]any{
+			"recovery_min_apply_delay": fmt.Sprintf("%dms", delay.Milliseconds()),
+		}
+	}
+
 	if !ClusterBootstrapped(cluster) {
 		isRestore := (cluster.Status.PGBackRest != nil && cluster.Status.PGBackRest.Restore != nil)
 		isDataSource := (cluster.Spec.DataSource != nil && cluster.Spec.DataSource.Volumes != nil &&
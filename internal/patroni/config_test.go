@@ -291,6 +291,70 @@ func TestDynamicConfiguration(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "synchronous: spec overrides input",
+			spec: `{
+				patroni: {
+					dynamicConfiguration: {
+						synchronous_mode: false,
+						synchronous_node_count: 5,
+					},
+					synchronous: { mode: Strict, standbys: 2 },
+				},
+			}`,
+			expected: map[string]any{
+				"loop_wait":               int32(10),
+				"ttl":                     int32(30),
+				"synchronous_mode":        true,
+				"synchronous_mode_strict": true,
+				"synchronous_node_count":  int32(2),
+				"postgresql": map[string]any{
+					"use_pg_rewind": true,
+					"use_slots":     false,
+				},
+			},
+		},
+		{
+			name: "synchronous: modes",
+			spec: `{
+				patroni: {
+					synchronous: { mode: Quorum },
+				},
+			}`,
+			expected: map[string]any{
+				"loop_wait":               int32(10),
+				"ttl":                     int32(30),
+				"synchronous_mode":        "quorum",
+				"synchronous_mode_strict": false,
+				"synchronous_node_count":  int32(1),
+				"postgresql": map[string]any{
+					"use_pg_rewind": true,
+					"use_slots":     false,
+				},
+			},
+		},
+		{
+			name: "synchronous: off",
+			spec: `{
+				patroni: {
+					dynamicConfiguration: {
+						synchronous_mode: true,
+					},
+					synchronous: { mode: "Off" },
+				},
+			}`,
+			expected: map[string]any{
+				"loop_wait":               int32(10),
+				"ttl":                     int32(30),
+				"synchronous_mode":        false,
+				"synchronous_mode_strict": false,
+				"synchronous_node_count":  int32(1),
+				"postgresql": map[string]any{
+					"use_pg_rewind": true,
+					"use_slots":     false,
+				},
+			},
+		},
 		{
 			name: "postgresql: wrong-type is ignored",
 			spec: `{
@@ -653,7 +717,7 @@ func TestInstanceYAML(t *testing.T) {
 	cluster := &v1beta1.PostgresCluster{Spec: v1beta1.PostgresClusterSpec{PostgresVersion: 12}}
 	instance := new(v1beta1.PostgresInstanceSetSpec)
 
-	data, err := instanceYAML(cluster, instance, nil)
+	data, err := instanceYAML(cluster, instance, "", nil)
 	assert.NilError(t, err)
 	assert.Equal(t, data, strings.Trim(`
 # Generated by postgres-operator. DO NOT EDIT.
@@ -677,7 +741,7 @@ restapi: {}
 tags: {}
 	`, "\t\n")+"\n")
 
-	dataWithReplicaCreate, err := instanceYAML(cluster, instance, []string{"some", "backrest", "cmd"})
+	dataWithReplicaCreate, err := instanceYAML(cluster, instance, "", []string{"some", "backrest", "cmd"})
 	assert.NilError(t, err)
 	assert.Equal(t, dataWithReplicaCreate, strings.Trim(`
 # Generated by postgres-operator. DO NOT EDIT.
@@ -707,6 +771,98 @@ restapi: {}
 tags: {}
 	`, "\t\n")+"\n")
 
+	t.Run("Synchronous", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			postgresVersion: 12,
+			patroni: { synchronous: { instanceSets: [near] } },
+		}`)
+
+		instance := &v1beta1.PostgresInstanceSetSpec{Name: "near"}
+		data, err := instanceYAML(cluster, instance, "", nil)
+		assert.NilError(t, err)
+		assert.Assert(t, cmp.Contains(data, "\ntags: {}\n"))
+
+		instance = &v1beta1.PostgresInstanceSetSpec{Name: "far"}
+		data, err = instanceYAML(cluster, instance, "", nil)
+		assert.NilError(t, err)
+		assert.Assert(t, cmp.Contains(data, "\ntags:\n  nosync: true\n"))
+
+		cluster.Spec.Patroni.Synchronous.Mode = "Off"
+		data, err = instanceYAML(cluster, instance, "", nil)
+		assert.NilError(t, err)
+		assert.Assert(t, cmp.Contains(data, "\ntags: {}\n"))
+	})
+
+	t.Run("ReplicationDelay", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.PostgresVersion = 12
+
+		instance := new(v1beta1.PostgresInstanceSetSpec)
+		require.UnmarshalInto(t, instance, `{ name: delayed, replicationDelay: 2h }`)
+
+		data, err := instanceYAML(cluster, instance, "", nil)
+		assert.NilError(t, err)
+		assert.Assert(t, cmp.Contains(data, `
+  parameters:
+    recovery_min_apply_delay: 7200000ms
+`))
+		assert.Assert(t, cmp.Contains(data, `
+tags:
+  nofailover: true
+  noloadbalance: true
+  nosync: true
+`))
+	})
+
+	t.Run("Tags", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.PostgresVersion = 12
+
+		instance := new(v1beta1.PostgresInstanceSetSpec)
+		require.UnmarshalInto(t, instance, `{
+			name: dr,
+			patroni: { tags: {
+				failoverPriority: 0, noLoadBalance: true, noSync: true, replicateFrom: near,
+			} },
+		}`)
+
+		data, err := instanceYAML(cluster, instance, "", nil)
+		assert.NilError(t, err)
+		assert.Assert(t, cmp.Contains(data, `
+tags:
+  failover_priority: 0
+  noloadbalance: true
+  nosync: true
+`))
+
+		// The caller resolves an instance set to a member.
+		data, err = instanceYAML(cluster, instance, "some-member-0", nil)
+		assert.NilError(t, err)
+		assert.Assert(t, cmp.Contains(data, "\n  replicatefrom: some-member-0\n"))
+
+		instance.Patroni.Tags = &v1beta1.PatroniTags{NoFailover: true}
+		data, err = instanceYAML(cluster, instance, "", nil)
+		assert.NilError(t, err)
+		assert.Assert(t, cmp.Contains(data, "\ntags:\n  nofailover: true\n"))
+	})
+}
+
+func TestLoadBalanced(t *testing.T) {
+	t.Parallel()
+
+	instance := new(v1beta1.PostgresInstanceSetSpec)
+	assert.Assert(t, LoadBalanced(instance))
+
+	instance.Patroni = &v1beta1.PatroniInstanceSetSpec{}
+	assert.Assert(t, LoadBalanced(instance))
+
+	instance.Patroni.Tags = &v1beta1.PatroniTags{NoLoadBalance: true}
+	assert.Assert(t, !LoadBalanced(instance))
+
+	instance.Patroni = nil
+	instance.ReplicationDelay = require.Value(v1beta1.NewDuration("1h"))
+	assert.Assert(t, !LoadBalanced(instance))
 }
 
 func TestPGBackRestCreateReplicaCommand(t *testing.T) {
@@ -716,7 +872,7 @@ func TestPGBackRestCreateReplicaCommand(t *testing.T) {
 	cluster := new(v1beta1.PostgresCluster)
 	instance := new(v1beta1.PostgresInstanceSetSpec)
 
-	data, err := instanceYAML(cluster, instance, []string{"some", "backrest", "cmd"})
+	data, err := instanceYAML(cluster, instance, "", []string{"some", "backrest", "cmd"})
 	assert.NilError(t, err)
 
 	var parsed struct {
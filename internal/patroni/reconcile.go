@@ -45,9 +45,11 @@ func ClusterConfigMap(ctx context.Context,
 }
 
 // InstanceConfigMap populates the shared ConfigMap with fields needed to run Patroni.
+// When inReplicateFrom is not empty, the instance replicates from that member.
 func InstanceConfigMap(ctx context.Context,
 	inCluster *v1beta1.PostgresCluster,
 	inInstanceSpec *v1beta1.PostgresInstanceSetSpec,
+	inReplicateFrom string,
 	outInstanceConfigMap *corev1.ConfigMap,
 ) error {
 	var err error
@@ -57,14 +59,14 @@ func InstanceConfigMap(ctx context.Context,
 	command := pgbackrest.ReplicaCreateCommand(inCluster, inInstanceSpec)
 
 	outInstanceConfigMap.Data[configMapFileKey], err = instanceYAML(
-		inCluster, inInstanceSpec, command)
+		inCluster, inInstanceSpec, inReplicateFrom, command)
 
 	return err
 }
 
 // InstanceCertificates populates the shared Secret with certificates needed to run Patroni.
 func InstanceCertificates(ctx context.Context,
-	inRoot pki.Certificate, inDNS pki.Certificate,
+	inRoot pki.Bundle, inDNS pki.Certificate,
 	inDNSKey pki.PrivateKey, outInstanceCertificates *corev1.Secret,
 ) error {
 	initialize.Map(&outInstanceCertificates.Data)
@@ -47,7 +47,7 @@ func TestReconcileInstanceCertificates(t *testing.T) {
 	leaf, err := root.GenerateLeafCertificate("any", nil)
 	assert.NilError(t, err, "bug in test")
 
-	dataCA, _ := certFile(root.Certificate)
+	dataCA, _ := certFile(root.Bundle())
 	assert.Assert(t,
 		cmp.Regexp(`^`+
 			`-----BEGIN CERTIFICATE-----\n`+
@@ -76,7 +76,7 @@ func TestReconcileInstanceCertificates(t *testing.T) {
 	secret := new(corev1.Secret)
 
 	assert.NilError(t, InstanceCertificates(ctx,
-		root.Certificate, leaf.Certificate, leaf.PrivateKey, secret))
+		root.Bundle(), leaf.Certificate, leaf.PrivateKey, secret))
 
 	assert.DeepEqual(t, secret.Data["patroni.ca-roots"], dataCA)
 	assert.DeepEqual(t, secret.Data["patroni.crt-combined"], dataCert)
@@ -84,8 +84,23 @@ func TestReconcileInstanceCertificates(t *testing.T) {
 	// No change when called again.
 	before := secret.DeepCopy()
 	assert.NilError(t, InstanceCertificates(ctx,
-		root.Certificate, leaf.Certificate, leaf.PrivateKey, secret))
+		root.Bundle(), leaf.Certificate, leaf.PrivateKey, secret))
 	assert.DeepEqual(t, secret, before)
+
+	t.Run("RootRotation", func(t *testing.T) {
+		previous, err := pki.NewRootCertificateAuthority()
+		assert.NilError(t, err, "bug in test")
+
+		// Patroni trusts every certificate in the bundle while the root rotates.
+		rotating := *root
+		rotating.Trusted = pki.Bundle{previous.Certificate}
+
+		assert.NilError(t, InstanceCertificates(ctx,
+			rotating.Bundle(), leaf.Certificate, leaf.PrivateKey, secret))
+
+		bundle, _ := certFile(root.Certificate, previous.Certificate)
+		assert.DeepEqual(t, secret.Data["patroni.ca-roots"], bundle)
+	})
 }
 
 func TestInstanceConfigMap(t *testing.T) {
@@ -95,15 +110,15 @@ func TestInstanceConfigMap(t *testing.T) {
 	cluster := new(v1beta1.PostgresCluster)
 	instance := new(v1beta1.PostgresInstanceSetSpec)
 	config := new(corev1.ConfigMap)
-	data, _ := instanceYAML(cluster, instance, nil)
+	data, _ := instanceYAML(cluster, instance, "", nil)
 
-	assert.NilError(t, InstanceConfigMap(ctx, cluster, instance, config))
+	assert.NilError(t, InstanceConfigMap(ctx, cluster, instance, "", config))
 
 	assert.DeepEqual(t, config.Data["patroni.yaml"], data)
 
 	// No change when called again.
 	before := config.DeepCopy()
-	assert.NilError(t, InstanceConfigMap(ctx, cluster, instance, config))
+	assert.NilError(t, InstanceConfigMap(ctx, cluster, instance, "", config))
 	assert.DeepEqual(t, config, before)
 }
 
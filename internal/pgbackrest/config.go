@@ -310,15 +310,64 @@ func RestoreCommand(postgresVersion int32, pgdata string, params *postgres.Param
 		`  END" && sleep 1) ||:`,
 		`done`,
 
-		// Replay is done. Stop Postgres gracefully and move the data directory
-		// into position for our Patroni bootstrap method.
+		// Replay is done. Note the last WAL record and transaction that were
+		// replayed then stop Postgres gracefully. The timeline and identifier
+		// of the restored data are in its control file after the shutdown
+		// checkpoint. The apostrophe in one of its labels is matched by "?".
+		`replayed=$(psql -AtF ' ' -c "SELECT`,
+		`  pg_catalog.pg_last_wal_replay_lsn(),`,
+		`  pg_catalog.date_part('epoch', pg_catalog.pg_last_xact_replay_timestamp())::bigint")`,
+		`read -r lsn epoch <<< "${replayed}"`,
 		`pg_ctl stop --silent --wait`,
+		`control=$(LC_ALL=C pg_controldata)`,
+		`read -r timeline <<< "${control##*Latest checkpoint?s TimeLineID:}"`,
+		`read -r system <<< "${control##*Database system identifier:}"`,
+
+		// Report where recovery ended in the termination message of the Pod.
+		// - https://docs.k8s.io/tasks/debug/debug-application/determine-reason-pod-failure/
+		`printf >> /dev/termination-log '%s=%s\n' \`,
+		`  lsn "${lsn}" time "${epoch}" timeline "${timeline}" system-identifier "${system}"`,
+
+		// Move the data directory into position for our Patroni bootstrap method.
 		`mv "${PGDATA}" "${PGDATA}_bootstrap"`,
 	}, "\n")
 
 	return append([]string{"bash", "-ceu", "--", script, "-", pgdata}, args...)
 }
 
+// VerifyCommand returns the command for verifying the backups and WAL in a
+// pgBackRest repository. The script prints the result of "pgbackrest verify"
+// and fails when it finds any problem. When restore is not empty, it is run
+// after a successful verification; see [RestoreCommand]. When everything
+// succeeds, the label of the latest valid backup is written to the termination
+// message of the Pod as "verified=<label>".
+func VerifyCommand(opts string, restore ...string) []string {
+	script := strings.Join([]string{
+		`declare -r opts="$1"; shift`,
+
+		// Run the verification and print its arguments and results.
+		`output=$(bash -xc "pgbackrest verify --output=text --verbose ${opts}") || failed=$?`,
+		`printf '%s\n' "${output}"`,
+		`if [[ -n "${failed-}" || "${output}" == *'status: error'* ]]; then exit "${failed:-1}"; fi`,
+
+		// Backup labels sort by the time they were taken, so the greatest valid
+		// label is the latest backup that can be restored.
+		`verified=''`,
+		`while read -r line; do`,
+		`  if [[ "${line}" == 'backup: '*', status: valid'* ]]; then`,
+		`    line="${line#backup: }" && line="${line%%,*}"`,
+		`    if [[ "${line}" > "${verified}" ]]; then verified="${line}"; fi`,
+		`  fi`,
+		`done <<< "${output}"`,
+
+		// Run the restore test, if any. It reports where recovery ended.
+		`if [[ "$#" -gt 0 ]]; then "$@"; fi`,
+		`printf >> /dev/termination-log 'verified=%s\n' "${verified}"`,
+	}, "\n")
+
+	return append([]string{"bash", "-ceu", "--", script, "-", opts}, restore...)
+}
+
 // DedicatedSnapshotVolumeRestoreCommand returns the command for performing a pgBackRest delta restore
 // into a dedicated snapshot volume. In addition to calling the pgBackRest restore command with any
 // pgBackRest options provided, the script also removes the patroni.dynamic.json file if present. This
@@ -440,6 +489,10 @@ func populateRepoHostConfigurationMap(
 				global.Set(option, val)
 			}
 		}
+
+		for option, val := range getRepoRetentionConfigs(repo) {
+			global.Set(option, val)
+		}
 	}
 
 	// If no log path was provided, don't log because the default path is not writable.
@@ -497,6 +550,9 @@ func populateCloudRepoConfigurationMap(
 		for option, val := range getExternalRepoConfigs(repo) {
 			global.Set(option, val)
 		}
+		for option, val := range getRepoRetentionConfigs(repo) {
+			global.Set(option, val)
+		}
 	}
 
 	// If we are given a log path, set it in the config. Otherwise, turn off logging to file.
@@ -556,6 +612,33 @@ func getExternalRepoConfigs(repo v1beta1.PGBackRestRepo) map[string]string {
 	return repoConfigs
 }
 
+// getRepoRetentionConfigs returns a map containing the retention settings for a pgBackRest
+// repository as defined in the PostgresCluster spec
+func getRepoRetentionConfigs(repo v1beta1.PGBackRestRepo) map[string]string {
+
+	repoConfigs := make(map[string]string)
+
+	if retention := repo.Retention; retention != nil {
+		if retention.Full != nil {
+			repoConfigs[repo.Name+"-retention-full"] = fmt.Sprint(*retention.Full)
+		}
+		if retention.FullType != "" {
+			repoConfigs[repo.Name+"-retention-full-type"] = retention.FullType
+		}
+		if retention.Differential != nil {
+			repoConfigs[repo.Name+"-retention-diff"] = fmt.Sprint(*retention.Differential)
+		}
+		if retention.Archive != nil {
+			repoConfigs[repo.Name+"-retention-archive"] = fmt.Sprint(*retention.Archive)
+		}
+		if retention.ArchiveType != "" {
+			repoConfigs[repo.Name+"-retention-archive-type"] = retention.ArchiveType
+		}
+	}
+
+	return repoConfigs
+}
+
 // reloadCommand returns an entrypoint that convinces the pgBackRest TLS server
 // to reload its options and certificate files when they change. The process
 // will appear as name in `ps` and `top`.
@@ -452,6 +452,45 @@ pg1-socket-path = /tmp/postgres
 		`, "\t\n")+"\n")
 	})
 
+	t.Run("Retention", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Global = map[string]string{
+			"repo2-retention-diff": "9",
+		}
+		require.UnmarshalInto(t, &cluster.Spec.Backups.PGBackRest.Repos, `[
+			{ name: repo1, volume: { volumeClaimSpec: {} },
+			  retention: { full: 2, archive: 1, archiveType: diff } },
+			{ name: repo2, s3: { bucket: s-bucket, endpoint: endpoint-s, region: earth },
+			  retention: { full: 14, fullType: time, differential: 3 } },
+		]`)
+
+		configmap, err := CreatePGBackRestConfigMapIntent(context.Background(), cluster,
+			"repo-hostname", "abcde12345", "pod-service-name", "test-ns", "",
+			[]string{"some-instance"})
+		assert.NilError(t, err)
+
+		repoConfig := configmap.Data["pgbackrest_repo.conf"]
+		assert.Assert(t, cmp.Contains(repoConfig, `
+repo1-path = /pgbackrest/repo1
+repo1-retention-archive = 1
+repo1-retention-archive-type = diff
+repo1-retention-full = 2
+repo2-path = /pgbackrest/repo2
+repo2-retention-diff = 9
+repo2-retention-full = 14
+repo2-retention-full-type = time
+`), "expected retention of each repo, overridden by global")
+
+		cloudConfig := configmap.Data["pgbackrest_cloud.conf"]
+		assert.Assert(t, !strings.Contains(cloudConfig, "repo1-retention"),
+			"expected only cloud repos")
+		assert.Assert(t, cmp.Contains(cloudConfig, `
+repo2-retention-diff = 9
+repo2-retention-full = 14
+repo2-retention-full-type = time
+`))
+	})
+
 	t.Run("LoggingToAdditionalVolume", func(t *testing.T) {
 		cluster := cluster.DeepCopy()
 		cluster.UID = "guitar"
@@ -747,6 +786,8 @@ func TestRestoreCommand(t *testing.T) {
 
 	assert.Assert(t, cmp.Contains(command[3], "/usr/pgsql-19/bin"),
 		"expected path to PostgreSQL binaries")
+	assert.Assert(t, cmp.Contains(command[3], "/dev/termination-log"),
+		"expected a report of where recovery ended")
 
 	dir := t.TempDir()
 	file := filepath.Join(dir, "script.bash")
@@ -766,6 +807,43 @@ func TestRestoreCommandPrettyYAML(t *testing.T) {
 		"expected literal block scalar")
 }
 
+func TestVerifyCommand(t *testing.T) {
+	shellcheck := require.ShellCheck(t)
+
+	t.Run("Verify", func(t *testing.T) {
+		command := VerifyCommand("--stanza=db --repo=1")
+
+		assert.DeepEqual(t, command[:3], []string{"bash", "-ceu", "--"})
+		assert.DeepEqual(t, command[4:], []string{"-", "--stanza=db --repo=1"})
+
+		assert.Assert(t, cmp.Contains(command[3], "pgbackrest verify"))
+		assert.Assert(t, cmp.Contains(command[3], "/dev/termination-log"),
+			"expected a report of the verified backup")
+
+		dir := t.TempDir()
+		file := filepath.Join(dir, "script.bash")
+		assert.NilError(t, os.WriteFile(file, []byte(command[3]), 0o600))
+
+		cmd := exec.CommandContext(t.Context(), shellcheck, "--enable=all", file)
+		output, err := cmd.CombinedOutput()
+		assert.NilError(t, err, "%q\n%s", cmd.Args, output)
+	})
+
+	t.Run("RestoreTest", func(t *testing.T) {
+		restore := RestoreCommand(17, "/pgdata/pg17", postgres.NewParameterSet(), "--repo=1")
+		command := VerifyCommand("--repo=1", restore...)
+
+		assert.DeepEqual(t, command[4:6], []string{"-", "--repo=1"})
+		assert.DeepEqual(t, command[6:], restore)
+	})
+}
+
+func TestVerifyCommandPrettyYAML(t *testing.T) {
+	assert.Assert(t,
+		cmp.MarshalContains(VerifyCommand("--options"), "\n- |"),
+		"expected literal block scalar")
+}
+
 func TestDedicatedSnapshotVolumeRestoreCommand(t *testing.T) {
 	shellcheck := require.ShellCheck(t)
 
@@ -7,10 +7,14 @@ package pgbackrest
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
@@ -107,3 +111,169 @@ fi
 
 	return false, nil
 }
+
+// infoStanza is the part of the output of "pgbackrest info --output=json"
+// that describes one stanza.
+// - https://pgbackrest.org/command.html#command-info
+type infoStanza struct {
+	Name string `json:"name"`
+
+	Archive []struct {
+		Database infoDatabase `json:"database"`
+		Max      string       `json:"max"`
+		Min      string       `json:"min"`
+	} `json:"archive"`
+
+	Backup []struct {
+		Database infoDatabase `json:"database"`
+		Error    bool         `json:"error"`
+		Label    string       `json:"label"`
+		Prior    string       `json:"prior"`
+		Type     string       `json:"type"`
+
+		Info struct {
+			Size       int64 `json:"size"`
+			Repository struct {
+				Size int64 `json:"size"`
+			} `json:"repository"`
+		} `json:"info"`
+
+		LSN struct {
+			Start string `json:"start"`
+			Stop  string `json:"stop"`
+		} `json:"lsn"`
+
+		Timestamp struct {
+			Start int64 `json:"start"`
+			Stop  int64 `json:"stop"`
+		} `json:"timestamp"`
+	} `json:"backup"`
+
+	Repo []struct {
+		Key    int `json:"key"`
+		Status struct {
+			Code int `json:"code"`
+		} `json:"status"`
+	} `json:"repo"`
+}
+
+type infoDatabase struct {
+	RepoKey int `json:"repo-key"`
+}
+
+// Catalog describes the backups and WAL in one pgBackRest repository.
+type Catalog struct {
+	// Backups that completed without error, from oldest to newest. These
+	// do not include the name of any PostgresCluster.
+	Backups []v1beta1.PGBackRestBackupSpec
+
+	// The oldest and newest WAL segments of the current database.
+	WALMin, WALMax string
+}
+
+// Summary returns the number of backups in c and the latest of each type.
+func (c *Catalog) Summary() *v1beta1.RepoCatalog {
+	summary := &v1beta1.RepoCatalog{
+		Backups: int32(len(c.Backups)),
+		WALMin:  c.WALMin,
+		WALMax:  c.WALMax,
+	}
+	for i := range c.Backups {
+		backup := c.Backups[i].RepoBackup.DeepCopy()
+
+		switch c.Backups[i].Type {
+		case "full":
+			summary.LatestFull = backup
+		case "diff":
+			summary.LatestDifferential = backup
+		case "incr":
+			summary.LatestIncremental = backup
+		}
+	}
+	return summary
+}
+
+// Catalogs runs the pgBackRest "info" command and returns the backups and WAL
+// in each repository of the default stanza. The result is keyed by repository
+// name, e.g. "repo1". Repositories that pgBackRest cannot read are omitted.
+func (exec Executor) Catalogs(ctx context.Context) (map[string]*Catalog, error) {
+	var stdout, stderr bytes.Buffer
+
+	// Send any log messages to stderr so they do not interfere with the JSON.
+	if err := exec(ctx, nil, &stdout, &stderr, "pgbackrest", "info",
+		"--stanza="+DefaultStanzaName, "--output=json",
+		"--log-level-console=off", "--log-level-stderr=warn",
+	); err != nil {
+		return nil, errors.WithStack(fmt.Errorf("%w: %v", err, stderr.String()))
+	}
+
+	return parseCatalogs(stdout.Bytes())
+}
+
+// parseCatalogs interprets the output of "pgbackrest info --output=json".
+func parseCatalogs(output []byte) (map[string]*Catalog, error) {
+	var stanzas []infoStanza
+	if err := json.Unmarshal(output, &stanzas); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	catalogs := make(map[string]*Catalog)
+	for _, stanza := range stanzas {
+		if stanza.Name != DefaultStanzaName {
+			continue
+		}
+
+		// Status code 2 means the repository has no valid backups; it is
+		// otherwise readable.
+		for _, repo := range stanza.Repo {
+			if repo.Status.Code == 0 || repo.Status.Code == 2 {
+				catalogs["repo"+strconv.Itoa(repo.Key)] = &Catalog{}
+			}
+		}
+
+		// pgBackRest lists backups from oldest to newest.
+		for _, backup := range stanza.Backup {
+			repoName := "repo" + strconv.Itoa(backup.Database.RepoKey)
+			catalog := catalogs[repoName]
+			if catalog == nil || backup.Error {
+				continue
+			}
+
+			spec := v1beta1.PGBackRestBackupSpec{
+				RepoName: repoName,
+				Type:     backup.Type,
+				Prior:    backup.Prior,
+				RepoBackup: v1beta1.RepoBackup{
+					Label:        backup.Label,
+					StartLSN:     backup.LSN.Start,
+					StopLSN:      backup.LSN.Stop,
+					DatabaseSize: backup.Info.Size,
+					RepoSize:     backup.Info.Repository.Size,
+				},
+			}
+			if backup.Timestamp.Start > 0 {
+				spec.StartTime = timeFromEpoch(backup.Timestamp.Start)
+			}
+			if backup.Timestamp.Stop > 0 {
+				spec.StopTime = timeFromEpoch(backup.Timestamp.Stop)
+			}
+
+			catalog.Backups = append(catalog.Backups, spec)
+		}
+
+		// pgBackRest lists archives from oldest to newest database. Report the
+		// WAL of the current database.
+		for _, archive := range stanza.Archive {
+			if catalog := catalogs["repo"+strconv.Itoa(archive.Database.RepoKey)]; catalog != nil {
+				catalog.WALMin, catalog.WALMax = archive.Min, archive.Max
+			}
+		}
+	}
+
+	return catalogs, nil
+}
+
+func timeFromEpoch(seconds int64) *metav1.Time {
+	t := metav1.NewTime(time.Unix(seconds, 0).UTC())
+	return &t
+}
@@ -6,11 +6,13 @@ package pgbackrest
 
 import (
 	"context"
+	"errors"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -98,3 +100,103 @@ fi
 	output, err := cmd.CombinedOutput()
 	assert.NilError(t, err, "%q\n%s", cmd.Args, output)
 }
+
+func TestCatalogs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Error", func(t *testing.T) {
+		exec := func(_ context.Context, _ io.Reader, _, stderr io.Writer, _ ...string) error {
+			_, _ = stderr.Write([]byte("ERROR: [055]: unable to load info file"))
+			return errors.New("exit status 55")
+		}
+
+		_, err := Executor(exec).Catalogs(ctx)
+		assert.ErrorContains(t, err, "exit status 55: ERROR: [055]")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		exec := func(_ context.Context, _ io.Reader, stdout, _ io.Writer, command ...string) error {
+			assert.DeepEqual(t, command, []string{"pgbackrest", "info",
+				"--stanza=db", "--output=json",
+				"--log-level-console=off", "--log-level-stderr=warn"})
+
+			_, _ = stdout.Write([]byte(`[{
+				"archive": [
+					{"database": {"id": 1, "repo-key": 1}, "id": "16-1", "min": "000000010000000000000001", "max": "000000010000000000000009"},
+					{"database": {"id": 2, "repo-key": 1}, "id": "17-2", "min": "00000002000000000000000A", "max": "00000002000000000000000F"},
+					{"database": {"id": 1, "repo-key": 2}, "id": "17-1", "min": null, "max": null}
+				],
+				"backup": [{
+					"database": {"id": 2, "repo-key": 1}, "error": false,
+					"info": {"size": 31000000, "delta": 31000000, "repository": {"size": 4000000, "delta": 4000000}},
+					"label": "20240101-000000F", "prior": null,
+					"lsn": {"start": "0/A000028", "stop": "0/A000100"},
+					"timestamp": {"start": 1704067200, "stop": 1704067260},
+					"type": "full"
+				}, {
+					"database": {"id": 2, "repo-key": 1}, "error": true,
+					"info": {"size": 31000000, "repository": {"size": 100}},
+					"label": "20240101-000000F_20240102-000000I",
+					"lsn": {"start": "0/C000028", "stop": "0/C000100"},
+					"timestamp": {"start": 1704153600, "stop": 1704153660},
+					"type": "incr"
+				}, {
+					"database": {"id": 2, "repo-key": 1}, "error": false,
+					"info": {"size": 32000000, "repository": {"size": 200000}},
+					"label": "20240101-000000F_20240103-000000D", "prior": "20240101-000000F",
+					"lsn": {"start": "0/E000028", "stop": "0/E000100"},
+					"timestamp": {"start": 1704240000, "stop": 1704240060},
+					"type": "diff"
+				}],
+				"name": "db",
+				"repo": [
+					{"cipher": "none", "key": 1, "status": {"code": 0, "message": "ok"}},
+					{"cipher": "none", "key": 2, "status": {"code": 2, "message": "no valid backups"}},
+					{"cipher": "none", "key": 3, "status": {"code": 99, "message": "other"}}
+				],
+				"status": {"code": 2, "message": "no valid backups"}
+			}]`))
+			return nil
+		}
+
+		catalogs, err := Executor(exec).Catalogs(ctx)
+		assert.NilError(t, err)
+
+		assert.Equal(t, len(catalogs), 2, "expected only readable repos")
+		assert.DeepEqual(t, catalogs["repo2"], &Catalog{})
+		assert.DeepEqual(t, catalogs["repo2"].Summary(), &v1beta1.RepoCatalog{})
+
+		catalog := catalogs["repo1"]
+		assert.Equal(t, len(catalog.Backups), 2, "expected backups without errors")
+		assert.Equal(t, catalog.WALMin, "00000002000000000000000A")
+		assert.Equal(t, catalog.WALMax, "00000002000000000000000F")
+
+		full := catalog.Backups[0]
+		assert.Equal(t, full.RepoName, "repo1")
+		assert.Equal(t, full.Type, "full")
+		assert.Equal(t, full.Prior, "")
+		assert.Equal(t, full.Label, "20240101-000000F")
+		assert.Equal(t, full.StartLSN, "0/A000028")
+		assert.Equal(t, full.StopLSN, "0/A000100")
+		assert.Equal(t, full.StartTime.Format(time.RFC3339), "2024-01-01T00:00:00Z")
+		assert.Equal(t, full.StopTime.Format(time.RFC3339), "2024-01-01T00:01:00Z")
+		assert.Equal(t, full.DatabaseSize, int64(31000000))
+		assert.Equal(t, full.RepoSize, int64(4000000))
+
+		diff := catalog.Backups[1]
+		assert.Equal(t, diff.Type, "diff")
+		assert.Equal(t, diff.Prior, "20240101-000000F")
+
+		summary := catalog.Summary()
+		assert.Equal(t, summary.Backups, int32(2))
+		assert.Equal(t, summary.WALMin, "00000002000000000000000A")
+		assert.Equal(t, summary.WALMax, "00000002000000000000000F")
+		assert.Assert(t, summary.LatestIncremental == nil)
+
+		assert.Assert(t, summary.LatestFull != nil)
+		assert.DeepEqual(t, *summary.LatestFull, full.RepoBackup)
+
+		assert.Assert(t, summary.LatestDifferential != nil)
+		assert.Equal(t, summary.LatestDifferential.Label, "20240101-000000F_20240103-000000D")
+	})
+}
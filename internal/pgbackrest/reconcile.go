@@ -428,7 +428,7 @@ func AddServerToRepoPod(
 // InstanceCertificates populates the shared Secret with certificates needed to run pgBackRest.
 func InstanceCertificates(ctx context.Context,
 	inCluster *v1beta1.PostgresCluster,
-	inRoot pki.Certificate,
+	inRoot pki.Bundle,
 	inDNS pki.Certificate, inDNSKey pki.PrivateKey,
 	outInstanceCertificates *corev1.Secret,
 ) error {
@@ -559,7 +559,7 @@ func Secret(ctx context.Context,
 	}
 
 	if err == nil {
-		outSecret.Data[certAuthoritySecretKey], err = certFile(inRoot.Certificate)
+		outSecret.Data[certAuthoritySecretKey], err = certFile(inRoot.Bundle())
 	}
 	if err == nil {
 		outSecret.Data[certClientPrivateKeySecretKey], err = certFile(leaf.PrivateKey)
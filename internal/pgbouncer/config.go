@@ -5,9 +5,12 @@
 package pgbouncer
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"maps"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
@@ -23,16 +26,19 @@ const (
 
 	authFileAbsolutePath  = configDirectory + "/" + authFileProjectionPath
 	emptyFileAbsolutePath = configDirectory + "/" + emptyFileProjectionPath
+	hbaFileAbsolutePath   = configDirectory + "/" + hbaFileProjectionPath
 	iniFileAbsolutePath   = configDirectory + "/" + iniFileProjectionPath
 
 	authFileProjectionPath  = "~postgres-operator/users.txt"
 	emptyFileProjectionPath = "pgbouncer.ini"
+	hbaFileProjectionPath   = "~postgres-operator/hba.conf"
 	iniFileProjectionPath   = "~postgres-operator.ini"
 
 	authFileSecretKey   = "pgbouncer-users.txt" // #nosec G101 this is a name, not a credential
 	passwordSecretKey   = "pgbouncer-password"  // #nosec G101 this is a name, not a credential
 	verifierSecretKey   = "pgbouncer-verifier"  // #nosec G101 this is a name, not a credential
 	emptyConfigMapKey   = "pgbouncer-empty"
+	hbaFileConfigMapKey = "pgbouncer-hba.conf"
 	iniFileConfigMapKey = "pgbouncer.ini"
 )
 
@@ -63,18 +69,62 @@ func (vs iniValueSet) String() string {
 	return b.String()
 }
 
-// authFileContents returns a PgBouncer user database.
-func authFileContents(password string) []byte {
+// quote returns s surrounded by double quotes with any double quotes inside
+// doubled. PgBouncer reads this in its authentication and HBA files.
+func quote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// authFileContents returns a PgBouncer user database. It contains the password
+// of the PgBouncer user followed by the SCRAM verifiers of any other users.
+func authFileContents(password string, verifiers map[string]string) []byte {
 	// > There should be at least 2 fields, surrounded by double quotes.
 	// > Double quotes in a field value can be escaped by writing two double quotes.
 	// - https://www.pgbouncer.org/config.html#authentication-file-format
-	quote := func(s string) string {
-		return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+	user1 := quote(PostgresqlUser) + " " + quote(password) + "\n"
+
+	others := make([]string, 0, len(verifiers))
+	for _, name := range slices.Sorted(maps.Keys(verifiers)) {
+		if name != PostgresqlUser && verifiers[name] != "" {
+			others = append(others, quote(name)+" "+quote(verifiers[name])+"\n")
+		}
 	}
 
-	user1 := quote(PostgresqlUser) + " " + quote(password) + "\n"
+	return []byte(user1 + strings.Join(others, ""))
+}
 
-	return []byte(user1)
+// hbaFileContents returns the PgBouncer HBA file for cluster, or an empty
+// string when cluster has no authentication rules.
+// - https://www.pgbouncer.org/config.html#hba-file-format
+func hbaFileContents(cluster *v1beta1.PostgresCluster) string {
+	authn := cluster.Spec.Proxy.PGBouncer.Authentication
+	if authn == nil || len(authn.Rules) == 0 {
+		return ""
+	}
+
+	list := func(names []string) string {
+		if len(names) == 0 {
+			return "all"
+		}
+		quoted := make([]string, len(names))
+		for i := range names {
+			quoted[i] = quote(names[i])
+		}
+		return strings.Join(quoted, ",")
+	}
+
+	// The PgBouncer user must always be able to read from the admin console
+	// so that metrics can be collected.
+	lines := []string{
+		"host pgbouncer " + quote(PostgresqlUser) + " all scram-sha-256",
+	}
+	for _, rule := range authn.Rules {
+		lines = append(lines, strings.Join([]string{
+			rule.Connection, list(rule.Databases), list(rule.Users), "all", rule.Method,
+		}, " "))
+	}
+
+	return iniGeneratedWarning + strings.Join(lines, "\n") + "\n"
 }
 
 func clusterINI(ctx context.Context, cluster *v1beta1.PostgresCluster) string {
@@ -102,13 +152,6 @@ func clusterINI(ctx context.Context, cluster *v1beta1.PostgresCluster) string {
 		"auth_query": "SELECT username, password from pgbouncer.get_auth($1)",
 		"auth_user":  PostgresqlUser,
 
-		// TODO(cbandy): Use an HBA file to control authentication of PgBouncer
-		// accounts; e.g. "admin_users" below.
-		// - https://www.pgbouncer.org/config.html#hba-file-format
-		//"auth_hba_file": "",
-		//"auth_type":     "hba",
-		//"admin_users": "pgbouncer",
-
 		// Require TLS encryption on client connections.
 		"client_tls_sslmode":   "require",
 		"client_tls_cert_file": certFrontendAbsolutePath,
@@ -136,10 +179,29 @@ func clusterINI(ctx context.Context, cluster *v1beta1.PostgresCluster) string {
 		global["logfile"] = naming.PGBouncerFullLogPath
 	}
 
-	// When OTel metrics are enabled, allow pgBouncer's postgres user
-	// to run read-only console queries on pgBouncer's virtual db
+	// Use an HBA file to control authentication when there are rules.
+	// - https://www.pgbouncer.org/config.html#hba-file-format
+	if hbaFileContents(cluster) != "" {
+		global["auth_hba_file"] = hbaFileAbsolutePath
+		global["auth_type"] = "hba"
+	}
+
+	// Allow the specified users to run commands on the admin console. When
+	// OTel metrics are enabled, allow pgBouncer's postgres user to run
+	// read-only console queries on pgBouncer's virtual db.
+	var adminUsers, statsUsers []string
+	if authn := cluster.Spec.Proxy.PGBouncer.Authentication; authn != nil {
+		adminUsers = append(adminUsers, authn.AdminUsers...)
+		statsUsers = append(statsUsers, authn.StatsUsers...)
+	}
 	if collector.OpenTelemetryMetricsEnabled(ctx, cluster) {
-		global["stats_users"] = PostgresqlUser
+		statsUsers = append(statsUsers, PostgresqlUser)
+	}
+	if len(adminUsers) > 0 {
+		global["admin_users"] = strings.Join(adminUsers, ",")
+	}
+	if len(statsUsers) > 0 {
+		global["stats_users"] = strings.Join(statsUsers, ",")
 	}
 
 	// Prevent the user from bypassing the main configuration file.
@@ -163,7 +225,21 @@ func clusterINI(ctx context.Context, cluster *v1beta1.PostgresCluster) string {
 
 	// Replace the above with any specified databases.
 	if len(cluster.Spec.Proxy.PGBouncer.Config.Databases) > 0 {
-		databases = iniValueSet(cluster.Spec.Proxy.PGBouncer.Config.Databases)
+		databases = maps.Clone(iniValueSet(cluster.Spec.Proxy.PGBouncer.Config.Databases))
+	}
+
+	// Add read-only pools that connect to cluster's replica service. Names
+	// that PgBouncer would need quoted are skipped; specified databases take
+	// precedence.
+	if readOnly := cluster.Spec.Proxy.PGBouncer.ReadOnly; readOnly != nil {
+		suffix := cmp.Or(readOnly.Suffix, "_ro")
+
+		for _, name := range readOnlyDatabases(cluster) {
+			if _, exists := databases[name+suffix]; !exists && unquotedDatabase.MatchString(name) {
+				databases[name+suffix] = fmt.Sprintf("host=%s port=%d dbname=%s",
+					naming.ClusterReplicaService(cluster).Name, postgresPort, name)
+			}
+		}
 	}
 
 	users := iniValueSet(cluster.Spec.Proxy.PGBouncer.Config.Users)
@@ -183,6 +259,31 @@ func clusterINI(ctx context.Context, cluster *v1beta1.PostgresCluster) string {
 	return result
 }
 
+// unquotedDatabase matches database names that PgBouncer reads without quotes.
+// - https://www.pgbouncer.org/config.html#section-databases
+var unquotedDatabase = regexp.MustCompile(`^[-._A-Za-z0-9]+$`)
+
+// readOnlyDatabases returns the sorted names of databases in cluster that
+// should have read-only pools.
+func readOnlyDatabases(cluster *v1beta1.PostgresCluster) []string {
+	names := make(map[string]struct{})
+
+	// Users are unspecified; there is one database matching the cluster name.
+	if cluster.Spec.Users == nil {
+		names[cluster.Name] = struct{}{}
+	}
+	for _, user := range cluster.Spec.Users {
+		for _, name := range user.Databases {
+			names[name] = struct{}{}
+		}
+	}
+	for _, database := range cluster.Spec.Databases {
+		names[database.Name] = struct{}{}
+	}
+
+	return slices.Sorted(maps.Keys(names))
+}
+
 // podConfigFiles returns projections of PgBouncer's configuration files to
 // include in the configuration volume.
 func podConfigFiles(
@@ -211,6 +312,19 @@ func podConfigFiles(
 	// - https://docs.k8s.io/concepts/storage/volumes/#projected
 	projections = append(projections, config.Files...)
 
+	// Project the HBA file only when there is one so that PgBouncer does not
+	// restart when it is unused.
+	items := []corev1.KeyToPath{{
+		Key:  iniFileConfigMapKey,
+		Path: iniFileProjectionPath,
+	}}
+	if _, ok := configmap.Data[hbaFileConfigMapKey]; ok {
+		items = append(items, corev1.KeyToPath{
+			Key:  hbaFileConfigMapKey,
+			Path: hbaFileProjectionPath,
+		})
+	}
+
 	// Add our non-empty configurations last so that they take precedence.
 	projections = append(projections, []corev1.VolumeProjection{
 		{
@@ -218,10 +332,7 @@ func podConfigFiles(
 				LocalObjectReference: corev1.LocalObjectReference{
 					Name: configmap.Name,
 				},
-				Items: []corev1.KeyToPath{{
-					Key:  iniFileConfigMapKey,
-					Path: iniFileProjectionPath,
-				}},
+				Items: items,
 			},
 		},
 		{
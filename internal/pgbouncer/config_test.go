@@ -36,8 +36,48 @@ func TestAuthFileContents(t *testing.T) {
 	t.Parallel()
 
 	password := `very"random`
-	data := authFileContents(password)
+	data := authFileContents(password, nil)
 	assert.Equal(t, string(data), `"_crunchypgbouncer" "very""random"`+"\n")
+
+	data = authFileContents(password, map[string]string{
+		"zed":               "SCRAM-SHA-256$z",
+		"empty":             "",
+		"_crunchypgbouncer": "ignored",
+		"admin":             "SCRAM-SHA-256$a",
+	})
+	assert.Equal(t, string(data), strings.Join([]string{
+		`"_crunchypgbouncer" "very""random"`,
+		`"admin" "SCRAM-SHA-256$a"`,
+		`"zed" "SCRAM-SHA-256$z"`,
+	}, "\n")+"\n")
+}
+
+func TestHBAFileContents(t *testing.T) {
+	t.Parallel()
+
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Spec.Proxy = new(v1beta1.PostgresProxySpec)
+	cluster.Spec.Proxy.PGBouncer = new(v1beta1.PGBouncerPodSpec)
+	assert.Equal(t, hbaFileContents(cluster), "")
+
+	cluster.Spec.Proxy.PGBouncer.Authentication = &v1beta1.PGBouncerAuthenticationSpec{
+		AdminUsers: []string{"oncall"},
+	}
+	assert.Equal(t, hbaFileContents(cluster), "")
+
+	cluster.Spec.Proxy.PGBouncer.Authentication.Rules = []v1beta1.PGBouncerHBARule{
+		{Connection: "hostssl", Databases: []string{"pgbouncer"}, Users: []string{"oncall"}, Method: "scram-sha-256"},
+		{Connection: "hostssl", Users: []string{`we"ird`, "app"}, Method: "md5"},
+		{Connection: "host", Method: "reject"},
+	}
+	assert.Equal(t, hbaFileContents(cluster), strings.TrimSpace(`
+# Generated by postgres-operator. DO NOT EDIT.
+# Your changes will not be saved.
+host pgbouncer "_crunchypgbouncer" all scram-sha-256
+hostssl "pgbouncer" "oncall" all scram-sha-256
+hostssl all "we""ird","app" all md5
+host all all all reject
+	`)+"\n")
 }
 
 func TestClusterINI(t *testing.T) {
@@ -131,6 +171,57 @@ app = mode=rad
 		cluster.Spec.Proxy.PGBouncer.Config.Global["conffile"] = "too-far"
 		assert.Assert(t, !strings.Contains(clusterINI(ctx, cluster), "too-far"))
 	})
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Proxy.PGBouncer.Config.Databases = map[string]string{
+			"appdb":    "conn=str",
+			"other_ro": "custom",
+		}
+		cluster.Spec.Users = []v1beta1.PostgresUserSpec{
+			{Name: "app", Databases: []string{"appdb", "other", "needs quotes"}},
+		}
+		cluster.Spec.Databases = []v1beta1.PostgresDatabaseSpec{{Name: "declared"}}
+		cluster.Spec.Proxy.PGBouncer.ReadOnly = &v1beta1.PGBouncerReadOnlySpec{}
+
+		ini := clusterINI(ctx, cluster)
+		assert.Assert(t, cmp.Contains(ini, `
+[databases]
+appdb = conn=str
+appdb_ro = host=foo-baz-replicas port=9999 dbname=appdb
+declared_ro = host=foo-baz-replicas port=9999 dbname=declared
+other_ro = custom
+`))
+		assert.Assert(t, !strings.Contains(ini, "needs quotes"))
+		assert.Equal(t, len(cluster.Spec.Proxy.PGBouncer.Config.Databases), 2,
+			"expected spec to be unchanged")
+
+		cluster.Spec.Proxy.PGBouncer.ReadOnly.Suffix = ".replica"
+		assert.Assert(t, cmp.Contains(clusterINI(ctx, cluster),
+			"\nappdb.replica = host=foo-baz-replicas port=9999 dbname=appdb\n"))
+	})
+
+	t.Run("Authentication", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Proxy.PGBouncer.Authentication = &v1beta1.PGBouncerAuthenticationSpec{
+			AdminUsers: []string{"oncall"},
+			StatsUsers: []string{"viewer", "other"},
+		}
+
+		ini := clusterINI(ctx, cluster)
+		assert.Assert(t, cmp.Contains(ini, "\nadmin_users = oncall\n"))
+		assert.Assert(t, cmp.Contains(ini, "\nstats_users = viewer,other\n"))
+		assert.Assert(t, !strings.Contains(ini, "auth_type"), "expected no HBA without rules")
+
+		cluster.Spec.Proxy.PGBouncer.Authentication.Rules = []v1beta1.PGBouncerHBARule{
+			{Connection: "hostssl", Method: "scram-sha-256"},
+		}
+
+		ini = clusterINI(ctx, cluster)
+		assert.Assert(t, cmp.Contains(ini,
+			"\nauth_hba_file = /etc/pgbouncer/~postgres-operator/hba.conf\n"))
+		assert.Assert(t, cmp.Contains(ini, "\nauth_type = hba\n"))
+	})
 }
 
 func TestPodConfigFiles(t *testing.T) {
@@ -161,6 +252,22 @@ func TestPodConfigFiles(t *testing.T) {
 		`))
 	})
 
+	t.Run("HBAFile", func(t *testing.T) {
+		configmap := configmap.DeepCopy()
+		configmap.Data = map[string]string{hbaFileConfigMapKey: "anything"}
+
+		projections := podConfigFiles(config, configmap, secret)
+		assert.Assert(t, cmp.MarshalMatches(projections[1], `
+configMap:
+  items:
+  - key: pgbouncer.ini
+    path: ~postgres-operator.ini
+  - key: pgbouncer-hba.conf
+    path: ~postgres-operator/hba.conf
+  name: some-cm
+		`))
+	})
+
 	t.Run("CustomFiles", func(t *testing.T) {
 		config.Files = []corev1.VolumeProjection{
 			{Secret: &corev1.SecretProjection{
@@ -40,14 +40,22 @@ func ConfigMap(
 
 	outConfigMap.Data[emptyConfigMapKey] = ""
 	outConfigMap.Data[iniFileConfigMapKey] = clusterINI(ctx, inCluster)
+
+	if hba := hbaFileContents(inCluster); hba != "" {
+		outConfigMap.Data[hbaFileConfigMapKey] = hba
+	}
 }
 
-// Secret populates the PgBouncer Secret.
+// Secret populates the PgBouncer Secret. The inVerifiers are the SCRAM
+// verifiers of users that can access the admin console. When inFrontendCertificate
+// is nil, a certificate for clients is generated and stored in outSecret.
 func Secret(ctx context.Context,
 	inCluster *v1beta1.PostgresCluster,
 	inRoot *pki.RootCertificateAuthority,
+	inFrontendCertificate *corev1.SecretProjection,
 	inSecret *corev1.Secret,
 	inService *corev1.Service,
+	inVerifiers map[string]string,
 	outSecret *corev1.Secret,
 ) error {
 	if inCluster.Spec.Proxy == nil || inCluster.Spec.Proxy.PGBouncer == nil {
@@ -87,16 +95,23 @@ func Secret(ctx context.Context,
 	if err == nil {
 		// Store the SCRAM verifier alongside the plaintext password so that
 		// later reconciles don't generate it repeatedly.
-		outSecret.Data[authFileSecretKey] = authFileContents(password)
+		outSecret.Data[authFileSecretKey] = authFileContents(password, inVerifiers)
 		outSecret.Data[passwordSecretKey] = []byte(password)
 		outSecret.Data[verifierSecretKey] = []byte(verifier)
 	}
 
-	if inCluster.Spec.Proxy.PGBouncer.CustomTLSSecret == nil {
+	if inFrontendCertificate == nil {
 		leaf := &pki.LeafCertificate{}
 		dnsNames := naming.ServiceDNSNames(ctx, inService)
 		dnsFQDN := dnsNames[0]
 
+		// Clients of the read-only pools may connect through another Service.
+		if inCluster.Spec.Proxy.PGBouncer.ReadOnly != nil {
+			dnsNames = append(dnsNames, naming.ServiceDNSNames(ctx, &corev1.Service{
+				ObjectMeta: naming.ClusterPGBouncerReadOnly(inCluster),
+			})...)
+		}
+
 		if err == nil {
 			// Unmarshal and validate the stored leaf. These first errors can
 			// be ignored because they result in an invalid leaf which is then
@@ -109,7 +124,7 @@ func Secret(ctx context.Context,
 		}
 
 		if err == nil {
-			outSecret.Data[certFrontendAuthoritySecretKey], err = inRoot.Certificate.MarshalText()
+			outSecret.Data[certFrontendAuthoritySecretKey], err = inRoot.Bundle().MarshalText()
 		}
 		if err == nil {
 			outSecret.Data[certFrontendPrivateKeySecretKey], err = leaf.PrivateKey.MarshalText()
@@ -123,11 +138,13 @@ func Secret(ctx context.Context,
 }
 
 // Pod populates a PodSpec with the container and volumes needed to run PgBouncer.
+// When inFrontendCertificate is nil, PgBouncer uses the certificate in inSecret.
 func Pod(
 	ctx context.Context,
 	inCluster *v1beta1.PostgresCluster,
 	inConfigMap *corev1.ConfigMap,
 	inPostgreSQLCertificate *corev1.SecretProjection,
+	inFrontendCertificate *corev1.SecretProjection,
 	inSecret *corev1.Secret,
 	template *corev1.PodTemplateSpec,
 	logfile string,
@@ -144,7 +161,7 @@ func Pod(
 	configVolume.Projected = &corev1.ProjectedVolumeSource{
 		Sources: append(append([]corev1.VolumeProjection{},
 			podConfigFiles(inCluster.Spec.Proxy.PGBouncer.Config, inConfigMap, inSecret)...),
-			frontendCertificate(inCluster.Spec.Proxy.PGBouncer.CustomTLSSecret, inSecret),
+			frontendCertificate(inFrontendCertificate, inSecret),
 			backendAuthority(inPostgreSQLCertificate),
 		),
 	}
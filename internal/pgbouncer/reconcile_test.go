@@ -65,7 +65,7 @@ func TestSecret(t *testing.T) {
 	t.Run("Disabled", func(t *testing.T) {
 		// Nothing happens when PgBouncer is disabled.
 		constant := intent.DeepCopy()
-		assert.NilError(t, Secret(ctx, cluster, root, existing, service, intent))
+		assert.NilError(t, Secret(ctx, cluster, root, nil, existing, service, nil, intent))
 		assert.DeepEqual(t, constant, intent)
 	})
 
@@ -74,7 +74,7 @@ func TestSecret(t *testing.T) {
 	cluster.Default()
 
 	constant := existing.DeepCopy()
-	assert.NilError(t, Secret(ctx, cluster, root, existing, service, intent))
+	assert.NilError(t, Secret(ctx, cluster, root, nil, existing, service, nil, intent))
 	assert.DeepEqual(t, constant, existing)
 
 	// A password should be generated.
@@ -87,7 +87,7 @@ func TestSecret(t *testing.T) {
 	// Assuming the intent is written, no change when called again.
 	existing.Data = intent.Data
 	before := intent.DeepCopy()
-	assert.NilError(t, Secret(ctx, cluster, root, existing, service, intent))
+	assert.NilError(t, Secret(ctx, cluster, root, nil, existing, service, nil, intent))
 	assert.DeepEqual(t, before, intent)
 }
 
@@ -113,7 +113,7 @@ func TestSCRAMVerifier(t *testing.T) {
 	}
 
 	// Verify that a SCRAM verifier is set
-	assert.NilError(t, Secret(ctx, cluster, root, existing, service, intent))
+	assert.NilError(t, Secret(ctx, cluster, root, nil, existing, service, nil, intent))
 	assert.Assert(t, len(intent.Data["pgbouncer-verifier"]) != 0)
 
 	// Simulate the setting of a password and a verifier
@@ -122,7 +122,7 @@ func TestSCRAMVerifier(t *testing.T) {
 		"pgbouncer-verifier": []byte("SCRAM-SHA-256$4096:randomsalt:storedkey:serverkey"),
 		"pgbouncer-password": []byte("password"),
 	}
-	assert.NilError(t, Secret(ctx, cluster, root, existing, service, intent))
+	assert.NilError(t, Secret(ctx, cluster, root, nil, existing, service, nil, intent))
 	assert.Equal(t, string(intent.Data["pgbouncer-verifier"]), "SCRAM-SHA-256$4096:randomsalt:storedkey:serverkey")
 	assert.Equal(t, string(intent.Data["pgbouncer-password"]), "password")
 
@@ -131,7 +131,7 @@ func TestSCRAMVerifier(t *testing.T) {
 	existing.Data = map[string][]byte{
 		"pgbouncer-verifier": []byte("SCRAM-SHA-256$4096:randomsalt:storedkey:serverkey"),
 	}
-	assert.NilError(t, Secret(ctx, cluster, root, existing, service, intent))
+	assert.NilError(t, Secret(ctx, cluster, root, nil, existing, service, nil, intent))
 	assert.Assert(t, string(intent.Data["pgbouncer-verifier"]) != "SCRAM-SHA-256$4096:randomsalt:storedkey:serverkey")
 	assert.Assert(t, len(intent.Data["pgbouncer-password"]) != 0)
 	assert.Assert(t, len(intent.Data["pgbouncer-verifier"]) != 0)
@@ -151,11 +151,14 @@ func TestPod(t *testing.T) {
 	cluster := new(v1beta1.PostgresCluster)
 	configMap := new(corev1.ConfigMap)
 	primaryCertificate := new(corev1.SecretProjection)
+	var frontendCertificate *corev1.SecretProjection
 	secret := new(corev1.Secret)
 	template := new(corev1.PodTemplateSpec)
 	logfile := ""
 
-	call := func() { Pod(ctx, cluster, configMap, primaryCertificate, secret, template, logfile) }
+	call := func() {
+		Pod(ctx, cluster, configMap, primaryCertificate, frontendCertificate, secret, template, logfile)
+	}
 
 	t.Run("Disabled", func(t *testing.T) {
 		before := template.DeepCopy()
@@ -277,7 +280,7 @@ volumes:
 		cluster.Spec.Proxy.PGBouncer.Resources.Requests = corev1.ResourceList{
 			corev1.ResourceCPU: resource.MustParse("100m"),
 		}
-		cluster.Spec.Proxy.PGBouncer.CustomTLSSecret = &corev1.SecretProjection{
+		frontendCertificate = &corev1.SecretProjection{
 			LocalObjectReference: corev1.LocalObjectReference{Name: "tls-name"},
 			Items: []corev1.KeyToPath{
 				{Key: "k1", Path: "tls.crt"},
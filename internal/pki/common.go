@@ -5,29 +5,52 @@
 package pki
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"fmt"
 	"math/big"
 	"time"
 )
 
-// certificateSignatureAlgorithm is ECDSA with SHA-384, the recommended
-// signature algorithm with the P-256 curve.
-const certificateSignatureAlgorithm = x509.ECDSAWithSHA384
+// signatureAlgorithm returns the algorithm used to sign certificates with
+// signer. ECDSA keys use SHA-384, the recommended signature algorithm with
+// the P-256 curve. RSA keys are meant for older clients, so they use SHA-256.
+func signatureAlgorithm(signer crypto.Signer) x509.SignatureAlgorithm {
+	if _, ok := signer.Public().(*rsa.PublicKey); ok {
+		return x509.SHA256WithRSA
+	}
+	return x509.ECDSAWithSHA384
+}
 
 // currentTime returns the current local time. It is a variable so it can be
 // replaced during testing.
 var currentTime = time.Now
 
-// generateKey returns a random ECDSA key using a P-256 curve. This curve is
-// roughly equivalent to an RSA 3072-bit key but requires less bits to achieve
-// the equivalent cryptographic strength. Additionally, ECDSA is FIPS 140-2
-// compliant.
-func generateKey() (*ecdsa.PrivateKey, error) {
-	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+// generateKey returns a random key of the type and size of algorithm. ECDSA
+// and RSA are both FIPS 140-2 compliant.
+func generateKey(algorithm KeyAlgorithm) (PrivateKey, error) {
+	var key PrivateKey
+	var err error
+
+	switch algorithm {
+	case ECDSAP256:
+		key.ecdsa, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case ECDSAP384:
+		key.ecdsa, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case RSA3072:
+		key.rsa, err = rsa.GenerateKey(rand.Reader, 3072)
+	case RSA4096:
+		key.rsa, err = rsa.GenerateKey(rand.Reader, 4096)
+	default:
+		err = fmt.Errorf("unknown key algorithm %q", algorithm)
+	}
+
+	return key, err
 }
 
 // generateSerialNumber returns a random 128-bit integer.
@@ -36,11 +59,10 @@ func generateSerialNumber() (*big.Int, error) {
 }
 
 func generateLeafCertificate(
-	signer *x509.Certificate, signerPrivate *ecdsa.PrivateKey,
-	signeePublic *ecdsa.PublicKey, serialNumber *big.Int,
-	commonName string, dnsNames []string,
+	signer *x509.Certificate, signerPrivate crypto.Signer,
+	signeePublic crypto.PublicKey, serialNumber *big.Int,
+	commonName string, dnsNames []string, leafExpiration time.Duration,
 ) (*x509.Certificate, error) {
-	const leafExpiration = time.Hour * 24 * 365
 	const leafStartValid = time.Hour * -1
 
 	now := currentTime()
@@ -51,7 +73,7 @@ func generateLeafCertificate(
 		NotBefore:             now.Add(leafStartValid),
 		NotAfter:              now.Add(leafExpiration),
 		SerialNumber:          serialNumber,
-		SignatureAlgorithm:    certificateSignatureAlgorithm,
+		SignatureAlgorithm:    signatureAlgorithm(signerPrivate),
 		Subject: pkix.Name{
 			CommonName: commonName,
 		},
@@ -65,10 +87,9 @@ func generateLeafCertificate(
 }
 
 func generateRootCertificate(
-	privateKey *ecdsa.PrivateKey, serialNumber *big.Int,
+	privateKey crypto.Signer, serialNumber *big.Int, rootExpiration time.Duration,
 ) (*x509.Certificate, error) {
 	const rootCommonName = "postgres-operator-ca"
-	const rootExpiration = time.Hour * 24 * 365 * 10
 	const rootStartValid = time.Hour * -1
 
 	now := currentTime()
@@ -80,7 +101,7 @@ func generateRootCertificate(
 		NotBefore:             now.Add(rootStartValid),
 		NotAfter:              now.Add(rootExpiration),
 		SerialNumber:          serialNumber,
-		SignatureAlgorithm:    certificateSignatureAlgorithm,
+		SignatureAlgorithm:    signatureAlgorithm(privateKey),
 		Subject: pkix.Name{
 			CommonName: rootCommonName,
 		},
@@ -20,6 +20,10 @@ const (
 	// pemLabelECDSAKey is the textual encoding label for an elliptic curve private key
 	// according to RFC 5915. See https://tools.ietf.org/html/rfc5915.
 	pemLabelECDSAKey = "EC PRIVATE KEY"
+
+	// pemLabelRSAKey is the textual encoding label for an RSA private key
+	// according to RFC 8017. See https://tools.ietf.org/html/rfc8017.
+	pemLabelRSAKey = "RSA PRIVATE KEY"
 )
 
 var (
@@ -64,6 +68,12 @@ var (
 
 // MarshalText returns a PEM encoding of k that OpenSSL understands.
 func (k PrivateKey) MarshalText() ([]byte, error) {
+	if k.rsa != nil {
+		return pem.EncodeToMemory(&pem.Block{
+			Type:  pemLabelRSAKey,
+			Bytes: x509.MarshalPKCS1PrivateKey(k.rsa),
+		}), nil
+	}
 	if k.ecdsa == nil {
 		k.ecdsa = new(ecdsa.PrivateKey)
 	}
@@ -83,13 +93,65 @@ func (k PrivateKey) MarshalText() ([]byte, error) {
 func (k *PrivateKey) UnmarshalText(data []byte) error {
 	block, _ := pem.Decode(data)
 
+	if block != nil && block.Type == pemLabelRSAKey {
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err == nil {
+			*k = PrivateKey{rsa: key}
+		}
+		return err
+	}
+
 	if block == nil || block.Type != pemLabelECDSAKey {
 		return fmt.Errorf("not a PEM-encoded private key")
 	}
 
 	key, err := x509.ParseECPrivateKey(block.Bytes)
 	if err == nil {
-		k.ecdsa = key
+		*k = PrivateKey{ecdsa: key}
 	}
 	return err
 }
+
+// Bundle is a list of certificates, such as the roots trusted by a client.
+type Bundle []Certificate
+
+var (
+	_ encoding.TextMarshaler   = Bundle{}
+	_ encoding.TextMarshaler   = (*Bundle)(nil)
+	_ encoding.TextUnmarshaler = (*Bundle)(nil)
+)
+
+// MarshalText returns the PEM encoding of each certificate in b, one after
+// the other.
+func (b Bundle) MarshalText() ([]byte, error) {
+	var out []byte
+	for i := range b {
+		txt, err := b[i].MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, txt...)
+	}
+	return out, nil
+}
+
+// UnmarshalText populates b from the PEM encoding of zero or more
+// certificates. Any PEM block that is not a certificate is an error.
+func (b *Bundle) UnmarshalText(data []byte) error {
+	var certificates Bundle
+
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != pemLabelCertificate {
+			return fmt.Errorf("not a PEM-encoded certificate")
+		}
+
+		parsed, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return err
+		}
+		certificates = append(certificates, Certificate{x509: parsed})
+	}
+
+	*b = certificates
+	return nil
+}
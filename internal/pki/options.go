@@ -0,0 +1,83 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pki
+
+import (
+	"fmt"
+	"time"
+)
+
+// KeyAlgorithm identifies the type and size of a private key.
+type KeyAlgorithm string
+
+const (
+	// ECDSAP256 is an ECDSA key using the P-256 curve. This curve is roughly
+	// equivalent to an RSA 3072-bit key but requires less bits to achieve the
+	// equivalent cryptographic strength.
+	ECDSAP256 KeyAlgorithm = "ECDSA-P256"
+
+	// ECDSAP384 is an ECDSA key using the P-384 curve.
+	ECDSAP384 KeyAlgorithm = "ECDSA-P384"
+
+	// RSA3072 and RSA4096 are RSA keys for clients that do not support ECDSA.
+	RSA3072 KeyAlgorithm = "RSA-3072"
+	RSA4096 KeyAlgorithm = "RSA-4096"
+)
+
+// Options configure the keys and certificates generated by this package.
+type Options struct {
+	// KeyAlgorithm is the type of key generated for new certificates.
+	// Defaults to [ECDSAP256].
+	KeyAlgorithm KeyAlgorithm
+
+	// LeafLifetime is how long new leaf certificates are valid.
+	// Defaults to one year.
+	LeafLifetime time.Duration
+
+	// RootLifetime is how long new root certificates are valid.
+	// Defaults to ten years.
+	RootLifetime time.Duration
+}
+
+// options are the settings used by this package. They are replaced by
+// [SetOptions] and can be replaced during testing.
+var options = Options{
+	KeyAlgorithm: ECDSAP256,
+	LeafLifetime: time.Hour * 24 * 365,
+	RootLifetime: time.Hour * 24 * 365 * 10,
+}
+
+// SetOptions changes the keys and certificates generated by this package.
+// Fields of next that are zero keep their default. It is not safe to call
+// while certificates are being generated, so call it once during startup.
+func SetOptions(next Options) error {
+	if next.KeyAlgorithm == "" {
+		next.KeyAlgorithm = options.KeyAlgorithm
+	}
+	if next.LeafLifetime == 0 {
+		next.LeafLifetime = options.LeafLifetime
+	}
+	if next.RootLifetime == 0 {
+		next.RootLifetime = options.RootLifetime
+	}
+
+	switch next.KeyAlgorithm {
+	case ECDSAP256, ECDSAP384, RSA3072, RSA4096:
+	default:
+		return fmt.Errorf("unknown key algorithm %q", next.KeyAlgorithm)
+	}
+
+	// Leaf certificates are renewed during the last third of their lifetime,
+	// so they need enough time to be seen by the operator before they expire.
+	if next.LeafLifetime < time.Hour {
+		return fmt.Errorf("leaf lifetime must be at least 1h, got %v", next.LeafLifetime)
+	}
+	if next.RootLifetime <= next.LeafLifetime {
+		return fmt.Errorf("root lifetime must be longer than leaf lifetime, got %v", next.RootLifetime)
+	}
+
+	options = next
+	return nil
+}
@@ -5,7 +5,10 @@
 package pki
 
 import (
+	"crypto"
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
 	"crypto/x509"
 	"math/big"
 	"time"
@@ -17,8 +20,12 @@ const renewalRatio = 3
 // PKI Profile, RFC 5280.
 type Certificate struct{ x509 *x509.Certificate }
 
-// PrivateKey represents the private key of a Certificate.
-type PrivateKey struct{ ecdsa *ecdsa.PrivateKey }
+// PrivateKey represents the private key of a Certificate. It holds one ECDSA
+// or RSA key.
+type PrivateKey struct {
+	ecdsa *ecdsa.PrivateKey
+	rsa   *rsa.PrivateKey
+}
 
 // Equal reports whether c and other have the same value.
 func (c Certificate) Equal(other Certificate) bool {
@@ -42,6 +49,15 @@ func (c Certificate) DNSNames() []string {
 	return append([]string{}, c.x509.DNSNames...)
 }
 
+// NotAfter returns when the certificate expires, or the zero time when there
+// is no certificate.
+func (c Certificate) NotAfter() time.Time {
+	if c.x509 == nil {
+		return time.Time{}
+	}
+	return c.x509.NotAfter
+}
+
 // hasSubject checks that c has these values in its subject.
 func (c Certificate) hasSubject(commonName string, dnsNames []string) bool {
 	ok := c.x509 != nil &&
@@ -57,10 +73,45 @@ func (c Certificate) hasSubject(commonName string, dnsNames []string) bool {
 
 // Equal reports whether k and other have the same value.
 func (k PrivateKey) Equal(other PrivateKey) bool {
-	if k.ecdsa == nil || other.ecdsa == nil {
-		return k.ecdsa == other.ecdsa
+	a, b := k.signer(), other.signer()
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.(interface{ Equal(crypto.PrivateKey) bool }).Equal(b)
+}
+
+// algorithm returns the type and size of k. It returns an empty string when
+// k is zero or not one of the supported algorithms.
+func (k PrivateKey) algorithm() KeyAlgorithm {
+	switch {
+	case k.ecdsa != nil && k.ecdsa.Curve == elliptic.P256():
+		return ECDSAP256
+	case k.ecdsa != nil && k.ecdsa.Curve == elliptic.P384():
+		return ECDSAP384
+	case k.rsa != nil && k.rsa.N.BitLen() == 3072:
+		return RSA3072
+	case k.rsa != nil && k.rsa.N.BitLen() == 4096:
+		return RSA4096
+	}
+	return ""
+}
+
+// signer returns the key inside k or nil when k is zero.
+func (k PrivateKey) signer() crypto.Signer {
+	switch {
+	case k.ecdsa != nil:
+		return k.ecdsa
+	case k.rsa != nil:
+		return k.rsa
 	}
-	return k.ecdsa.Equal(other.ecdsa)
+	return nil
+}
+
+// signs checks that k is the private key of public.
+func (k PrivateKey) signs(public crypto.PublicKey) bool {
+	key := k.signer()
+	return key != nil &&
+		key.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(public)
 }
 
 // LeafCertificate is a certificate and private key pair that can be validated
@@ -75,6 +126,10 @@ type LeafCertificate struct {
 type RootCertificateAuthority struct {
 	Certificate Certificate
 	PrivateKey  PrivateKey
+
+	// Trusted are other root certificates that clients should trust while
+	// this authority is being rotated. They are not used to sign anything.
+	Trusted Bundle
 }
 
 // NewRootCertificateAuthority generates a new key and self-signed certificate
@@ -83,18 +138,25 @@ func NewRootCertificateAuthority() (*RootCertificateAuthority, error) {
 	var root RootCertificateAuthority
 	var serial *big.Int
 
-	key, err := generateKey()
+	key, err := generateKey(options.KeyAlgorithm)
 	if err == nil {
 		serial, err = generateSerialNumber()
 	}
 	if err == nil {
-		root.PrivateKey.ecdsa = key
-		root.Certificate.x509, err = generateRootCertificate(key, serial)
+		root.PrivateKey = key
+		root.Certificate.x509, err = generateRootCertificate(
+			key.signer(), serial, options.RootLifetime)
 	}
 
 	return &root, err
 }
 
+// Bundle returns the certificate of root followed by any others it trusts.
+// Clients that verify certificates signed by root should use this bundle.
+func (root *RootCertificateAuthority) Bundle() Bundle {
+	return append(Bundle{root.Certificate}, root.Trusted...)
+}
+
 // RootIsValid checks if root is valid according to this package's policies.
 func RootIsValid(root *RootCertificateAuthority) bool {
 	if root == nil || root.Certificate.x509 == nil {
@@ -124,9 +186,7 @@ func RootIsValid(root *RootCertificateAuthority) bool {
 		len(root.Certificate.x509.SubjectKeyId) > 0
 
 	// It is signed by this private key.
-	ok = ok &&
-		root.PrivateKey.ecdsa != nil &&
-		root.PrivateKey.ecdsa.PublicKey.Equal(root.Certificate.x509.PublicKey)
+	ok = ok && root.PrivateKey.signs(root.Certificate.x509.PublicKey)
 
 	return ok
 }
@@ -138,15 +198,15 @@ func (root *RootCertificateAuthority) GenerateLeafCertificate(
 	var leaf LeafCertificate
 	var serial *big.Int
 
-	key, err := generateKey()
+	key, err := generateKey(options.KeyAlgorithm)
 	if err == nil {
 		serial, err = generateSerialNumber()
 	}
 	if err == nil {
-		leaf.PrivateKey.ecdsa = key
+		leaf.PrivateKey = key
 		leaf.Certificate.x509, err = generateLeafCertificate(
-			root.Certificate.x509, root.PrivateKey.ecdsa, &key.PublicKey, serial,
-			commonName, dnsNames)
+			root.Certificate.x509, root.PrivateKey.signer(), key.signer().Public(),
+			serial, commonName, dnsNames, options.LeafLifetime)
 	}
 
 	return &leaf, err
@@ -182,9 +242,7 @@ func (root *RootCertificateAuthority) leafIsValid(leaf *LeafCertificate) bool {
 		!leaf.Certificate.x509.IsCA
 
 	// It is signed by this private key.
-	ok = ok &&
-		leaf.PrivateKey.ecdsa != nil &&
-		leaf.PrivateKey.ecdsa.PublicKey.Equal(leaf.Certificate.x509.PublicKey)
+	ok = ok && leaf.PrivateKey.signs(leaf.Certificate.x509.PublicKey)
 
 	// It is not yet past the "renewal by" time,
 	// as defined by the before and after times of the certificate's expiration
@@ -205,13 +263,15 @@ func isBeforeRenewalTime(before, after time.Time) bool {
 }
 
 // RegenerateLeafWhenNecessary returns leaf when it is valid according to this
-// package's policies, signed by root, and has commonName and dnsNames in its
-// subject. Otherwise, it returns a new key and certificate signed by root.
+// package's policies, signed by root, has commonName and dnsNames in its
+// subject, and has the configured type of key. Otherwise, it returns a new
+// key and certificate signed by root.
 func (root *RootCertificateAuthority) RegenerateLeafWhenNecessary(
 	leaf *LeafCertificate, commonName string, dnsNames []string,
 ) (*LeafCertificate, error) {
 	ok := root.leafIsValid(leaf) &&
-		leaf.Certificate.hasSubject(commonName, dnsNames)
+		leaf.Certificate.hasSubject(commonName, dnsNames) &&
+		leaf.PrivateKey.algorithm() == options.KeyAlgorithm
 
 	if ok {
 		return leaf, nil
@@ -34,6 +34,15 @@ func TestCertificateDNSNames(t *testing.T) {
 	assert.Assert(t, zero.DNSNames() == nil)
 }
 
+func TestCertificateNotAfter(t *testing.T) {
+	zero := Certificate{}
+	assert.Assert(t, zero.NotAfter().IsZero())
+
+	root, err := NewRootCertificateAuthority()
+	assert.NilError(t, err)
+	assert.Assert(t, root.Certificate.NotAfter().After(time.Now()))
+}
+
 func TestCertificateHasSubject(t *testing.T) {
 	zero := Certificate{}
 
@@ -172,7 +181,10 @@ func TestRootIsInvalid(t *testing.T) {
 		leaf, err := root.GenerateLeafCertificate("", nil)
 		assert.NilError(t, err)
 
-		assert.Assert(t, !RootIsValid((*RootCertificateAuthority)(leaf)))
+		assert.Assert(t, !RootIsValid(&RootCertificateAuthority{
+			Certificate: leaf.Certificate,
+			PrivateKey:  leaf.PrivateKey,
+		}))
 	})
 
 	t.Run("TooEarly", func(t *testing.T) {
@@ -356,7 +368,10 @@ func TestLeafIsInvalid(t *testing.T) {
 	})
 
 	t.Run("IsAuthority", func(t *testing.T) {
-		assert.Assert(t, !root.leafIsValid((*LeafCertificate)(root)))
+		assert.Assert(t, !root.leafIsValid(&LeafCertificate{
+			Certificate: root.Certificate,
+			PrivateKey:  root.PrivateKey,
+		}))
 	})
 
 	t.Run("TooEarly", func(t *testing.T) {
@@ -433,6 +448,136 @@ func TestRegenerateLeaf(t *testing.T) {
 
 	assert.Assert(t, after.Certificate.hasSubject("after", nil))
 	assert.Assert(t, !after.Certificate.Equal(before.Certificate))
+
+	t.Run("KeyAlgorithm", func(t *testing.T) {
+		original := options
+		t.Cleanup(func() { options = original })
+
+		options.KeyAlgorithm = ECDSAP384
+
+		// Leaf is regenerated when the type of key changes.
+		changed, err := root.RegenerateLeafWhenNecessary(before, "before", nil)
+		assert.NilError(t, err)
+		assert.Assert(t, !changed.Certificate.Equal(before.Certificate))
+		assert.Equal(t, changed.PrivateKey.algorithm(), ECDSAP384)
+	})
+}
+
+func TestSetOptions(t *testing.T) {
+	original := options
+	t.Cleanup(func() { options = original })
+
+	t.Run("Defaults", func(t *testing.T) {
+		assert.NilError(t, SetOptions(Options{}))
+		assert.DeepEqual(t, options, original)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		t.Cleanup(func() { options = original })
+
+		assert.NilError(t, SetOptions(Options{KeyAlgorithm: RSA3072, LeafLifetime: 24 * time.Hour}))
+		assert.Equal(t, options.KeyAlgorithm, RSA3072)
+		assert.Equal(t, options.LeafLifetime, 24*time.Hour)
+		assert.Equal(t, options.RootLifetime, original.RootLifetime)
+	})
+
+	for _, tt := range []struct {
+		test    string
+		options Options
+		message string
+	}{
+		{test: "UnknownAlgorithm", options: Options{KeyAlgorithm: "DSA"}, message: `unknown key algorithm "DSA"`},
+		{test: "ShortLeaf", options: Options{LeafLifetime: time.Minute}, message: "at least 1h"},
+		{test: "ShortRoot", options: Options{RootLifetime: 24 * time.Hour}, message: "longer than leaf"},
+	} {
+		t.Run(tt.test, func(t *testing.T) {
+			assert.ErrorContains(t, SetOptions(tt.options), tt.message)
+			assert.DeepEqual(t, options, original)
+		})
+	}
+}
+
+func TestKeyAlgorithms(t *testing.T) {
+	original := options
+	t.Cleanup(func() { options = original })
+
+	for _, tt := range []struct {
+		algorithm KeyAlgorithm
+		public    x509.PublicKeyAlgorithm
+		signature x509.SignatureAlgorithm
+	}{
+		{algorithm: ECDSAP256, public: x509.ECDSA, signature: x509.ECDSAWithSHA384},
+		{algorithm: ECDSAP384, public: x509.ECDSA, signature: x509.ECDSAWithSHA384},
+		{algorithm: RSA3072, public: x509.RSA, signature: x509.SHA256WithRSA},
+		{algorithm: RSA4096, public: x509.RSA, signature: x509.SHA256WithRSA},
+	} {
+		t.Run(string(tt.algorithm), func(t *testing.T) {
+			options.KeyAlgorithm = tt.algorithm
+
+			root, err := NewRootCertificateAuthority()
+			assert.NilError(t, err)
+			assert.Assert(t, RootIsValid(root))
+			assert.Equal(t, root.PrivateKey.algorithm(), tt.algorithm)
+			assert.Equal(t, root.Certificate.x509.PublicKeyAlgorithm, tt.public)
+			assert.Equal(t, root.Certificate.x509.SignatureAlgorithm, tt.signature)
+
+			leaf, err := root.GenerateLeafCertificate("some-cn", nil)
+			assert.NilError(t, err)
+			assert.Assert(t, root.leafIsValid(leaf))
+			assert.Equal(t, leaf.PrivateKey.algorithm(), tt.algorithm)
+			assert.Equal(t, leaf.Certificate.x509.SignatureAlgorithm, tt.signature)
+
+			txt, err := leaf.PrivateKey.MarshalText()
+			assert.NilError(t, err)
+
+			var sink PrivateKey
+			assert.NilError(t, sink.UnmarshalText(txt))
+			assert.Assert(t, sink.Equal(leaf.PrivateKey))
+		})
+	}
+
+	t.Run("Lifetimes", func(t *testing.T) {
+		options = original
+		options.LeafLifetime = 30 * 24 * time.Hour
+		options.RootLifetime = 365 * 24 * time.Hour
+
+		root, err := NewRootCertificateAuthority()
+		assert.NilError(t, err)
+
+		cert := root.Certificate.x509
+		assert.Equal(t, cert.NotAfter.Sub(cert.NotBefore), options.RootLifetime+time.Hour)
+
+		leaf, err := root.GenerateLeafCertificate("some-cn", nil)
+		assert.NilError(t, err)
+
+		cert = leaf.Certificate.x509
+		assert.Equal(t, cert.NotAfter.Sub(cert.NotBefore), options.LeafLifetime+time.Hour)
+	})
+}
+
+func TestRootCertificateAuthorityBundle(t *testing.T) {
+	root, err := NewRootCertificateAuthority()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, root.Bundle(), Bundle{root.Certificate})
+
+	other, err := NewRootCertificateAuthority()
+	assert.NilError(t, err)
+
+	root.Trusted = Bundle{other.Certificate}
+	assert.DeepEqual(t, root.Bundle(), Bundle{root.Certificate, other.Certificate})
+
+	// Leaf certificates signed by either root verify with the bundle.
+	pool := x509.NewCertPool()
+	for _, cert := range root.Bundle() {
+		pool.AddCert(cert.x509)
+	}
+	for _, authority := range []*RootCertificateAuthority{root, other} {
+		leaf, err := authority.GenerateLeafCertificate("some-cn", nil)
+		assert.NilError(t, err)
+
+		_, err = leaf.Certificate.x509.Verify(x509.VerifyOptions{Roots: pool})
+		assert.NilError(t, err)
+	}
 }
 
 func basicOpenSSLVerify(t *testing.T, openssl string, root, leaf Certificate) {
@@ -8,8 +8,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 // CreateDatabasesInPostgreSQL calls exec to create databases that do not exist
@@ -70,3 +74,173 @@ SELECT pg_catalog.format('CREATE DATABASE %I',
 
 	return err
 }
+
+// WriteDatabaseInPostgreSQL calls exec to create the database in spec when it
+// does not exist, then sets its owner, connection limit, schemas, and
+// extensions. It grants all privileges on the database to each of grantees.
+// It returns a description of each attribute that cannot change after the
+// database is created and differs from spec.
+func WriteDatabaseInPostgreSQL(
+	ctx context.Context, exec Executor, spec *v1beta1.PostgresDatabaseSpec,
+	grantees []string,
+) ([]string, error) {
+	log := logging.FromContext(ctx)
+
+	var connections string
+	if spec.ConnectionLimit != nil {
+		connections = strconv.FormatInt(int64(*spec.ConnectionLimit), 10)
+	}
+
+	if grantees == nil {
+		grantees = []string{}
+	}
+	users, err := json.Marshal(grantees)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every variable must be set, even when empty, so that psql replaces it.
+	// - https://www.postgresql.org/docs/current/app-psql.html#APP-PSQL-INTERPOLATION
+	variables := map[string]string{
+		"database":      spec.Name,
+		"encoding":      spec.Encoding,
+		"grantees":      string(users),
+		"icu_locale":    spec.ICULocale,
+		"limit":         connections,
+		"locale":        spec.Locale,
+		"owner":         spec.Owner,
+		"template":      spec.Template,
+		"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+		"QUIET":         "on", // Do not print successful statements to stdout.
+	}
+
+	// Create the database when it does not exist, then change the attributes
+	// that can change. Finally, print the attributes that cannot change.
+	// - https://www.postgresql.org/docs/current/sql-createdatabase.html
+	// - https://www.postgresql.org/docs/current/sql-alterdatabase.html
+	stdout, stderr, err := exec.Exec(ctx, strings.NewReader(strings.TrimSpace(`
+SET synchronous_commit = LOCAL;
+SET search_path TO '';
+SELECT pg_catalog.format('CREATE DATABASE %I', :'database')
+    || CASE WHEN :'template' = '' THEN '' ELSE pg_catalog.format(' TEMPLATE %I', :'template') END
+    || CASE WHEN :'encoding' = '' THEN '' ELSE pg_catalog.format(' ENCODING %L', :'encoding') END
+    || CASE WHEN :'locale' = '' THEN '' ELSE pg_catalog.format(' LOCALE %L', :'locale') END
+    || CASE WHEN :'icu_locale' = '' THEN '' ELSE pg_catalog.format(' LOCALE_PROVIDER icu ICU_LOCALE %L', :'icu_locale') END
+ WHERE NOT EXISTS (SELECT 1 FROM pg_catalog.pg_database WHERE datname = :'database')
+\gexec
+SELECT pg_catalog.format('ALTER DATABASE %I OWNER TO %I', :'database', :'owner')
+ WHERE :'owner' <> ''
+\gexec
+SELECT pg_catalog.format('ALTER DATABASE %I WITH CONNECTION LIMIT %s', :'database', :'limit')
+ WHERE :'limit' <> ''
+\gexec
+SELECT pg_catalog.format('GRANT ALL PRIVILEGES ON DATABASE %I TO %I', :'database', grantee)
+  FROM pg_catalog.json_array_elements_text(:'grantees'::pg_catalog.json) AS grantee
+\gexec
+\pset format unaligned
+\pset tuples_only on
+SELECT pg_catalog.json_build_object(
+       'encoding', pg_catalog.pg_encoding_to_char(d.encoding),
+       'database', pg_catalog.to_json(d))
+  FROM pg_catalog.pg_database d WHERE d.datname = :'database';
+`)), variables)
+
+	log.V(1).Info("wrote PostgreSQL database", "stdout", stdout, "stderr", stderr)
+
+	if err == nil && (len(spec.Schemas) > 0 || len(spec.Extensions) > 0) {
+		var schemas, extensions []byte
+		schemas, err = json.Marshal(spec.Schemas)
+		if err == nil {
+			extensions, err = json.Marshal(spec.Extensions)
+		}
+		if err == nil {
+			variables["schemas"] = string(schemas)
+			variables["extensions"] = string(extensions)
+
+			var out string
+			out, stderr, err = exec.ExecInDatabasesFromQuery(ctx,
+				`SELECT :'database'`, databaseObjectsSQL, variables)
+
+			log.V(1).Info("wrote PostgreSQL schemas and extensions",
+				"stdout", out, "stderr", stderr)
+		}
+	}
+
+	if err != nil {
+		if stderr = strings.TrimSpace(stderr); stderr != "" {
+			err = fmt.Errorf("%w: %s", err, stderr)
+		}
+		return nil, err
+	}
+
+	return databaseDrift(spec, stdout), nil
+}
+
+// databaseObjectsSQL creates and updates the schemas and extensions in the
+// "schemas" and "extensions" JSON variables of the current database.
+// - https://www.postgresql.org/docs/current/sql-createschema.html
+// - https://www.postgresql.org/docs/current/sql-createextension.html
+// - https://www.postgresql.org/docs/current/sql-alterextension.html
+const databaseObjectsSQL = `
+SET client_min_messages = WARNING;
+SET synchronous_commit = LOCAL;
+SET search_path TO '';
+SELECT pg_catalog.format('CREATE SCHEMA IF NOT EXISTS %I', s.name)
+  FROM pg_catalog.json_to_recordset(:'schemas') AS s (name text)
+\gexec
+SELECT pg_catalog.format('ALTER SCHEMA %I OWNER TO %I', s.name, s.owner)
+  FROM pg_catalog.json_to_recordset(:'schemas') AS s (name text, owner text)
+ WHERE s.owner IS NOT NULL
+\gexec
+SELECT pg_catalog.format('CREATE EXTENSION IF NOT EXISTS %I', e.name)
+    || CASE WHEN e.schema IS NULL THEN '' ELSE pg_catalog.format(' SCHEMA %I', e.schema) END
+    || CASE WHEN e.version IS NULL THEN '' ELSE pg_catalog.format(' VERSION %L', e.version) END
+  FROM pg_catalog.json_to_recordset(:'extensions') AS e (name text, version text, schema text)
+\gexec
+SELECT pg_catalog.format('ALTER EXTENSION %I UPDATE TO %L', e.name, e.version)
+  FROM pg_catalog.json_to_recordset(:'extensions') AS e (name text, version text)
+  JOIN pg_catalog.pg_extension x ON x.extname = e.name
+ WHERE e.version IS NOT NULL AND x.extversion <> e.version
+\gexec
+`
+
+// databaseDrift compares spec to the JSON that WriteDatabaseInPostgreSQL
+// prints and describes each attribute that differs.
+func databaseDrift(spec *v1beta1.PostgresDatabaseSpec, stdout string) []string {
+	var observed struct {
+		Encoding string `json:"encoding"`
+		Database struct {
+			Collate   string `json:"datcollate"`
+			CType     string `json:"datctype"`
+			ICULocale string `json:"daticulocale"` // PostgreSQL 15 and 16
+			Locale    string `json:"datlocale"`    // PostgreSQL 17 and newer
+		} `json:"database"`
+	}
+
+	// The JSON is the last line printed; ignore anything that cannot be read.
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if json.Unmarshal([]byte(lines[len(lines)-1]), &observed) != nil {
+		return nil
+	}
+
+	// PostgreSQL accepts a few spellings of each encoding, e.g. "UTF-8" and "utf8".
+	// - https://www.postgresql.org/docs/current/multibyte.html#MULTIBYTE-CHARSET-SUPPORTED
+	normalize := strings.NewReplacer("-", "", "_", "")
+
+	var drift []string
+	if spec.Encoding != "" && !strings.EqualFold(
+		normalize.Replace(spec.Encoding), normalize.Replace(observed.Encoding)) {
+		drift = append(drift, fmt.Sprintf("encoding is %q", observed.Encoding))
+	}
+	if spec.Locale != "" && observed.Database.Collate != spec.Locale {
+		drift = append(drift, fmt.Sprintf("collate is %q", observed.Database.Collate))
+	}
+	if spec.Locale != "" && observed.Database.CType != spec.Locale {
+		drift = append(drift, fmt.Sprintf("ctype is %q", observed.Database.CType))
+	}
+	if icu := observed.Database.ICULocale + observed.Database.Locale; spec.ICULocale != "" && icu != spec.ICULocale {
+		drift = append(drift, fmt.Sprintf("icu locale is %q", icu))
+	}
+
+	return drift
+}
@@ -13,7 +13,9 @@ import (
 
 	"gotest.tools/v3/assert"
 
+	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 func TestCreateDatabasesInPostgreSQL(t *testing.T) {
@@ -90,3 +92,113 @@ SELECT pg_catalog.format('CREATE DATABASE %I',
 		assert.Equal(t, calls, 1)
 	})
 }
+
+func TestWriteDatabaseInPostgreSQL(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Arguments", func(t *testing.T) {
+		expected := errors.New("pass-through")
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			assert.Assert(t, stdout != nil, "should capture stdout")
+			assert.Assert(t, stderr != nil, "should capture stderr")
+			_, _ = stderr.Write([]byte("ERROR:  role \"app\" does not exist\n"))
+			return expected
+		}
+
+		_, err := WriteDatabaseInPostgreSQL(ctx, exec, &v1beta1.PostgresDatabaseSpec{Name: "db"}, nil)
+		assert.Assert(t, errors.Is(err, expected))
+		assert.ErrorContains(t, err, `role "app" does not exist`)
+	})
+
+	t.Run("Minimal", func(t *testing.T) {
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Assert(t, cmp.Contains(string(b), `CREATE DATABASE %I`))
+			assert.Assert(t, cmp.Contains(string(b), `\gexec`))
+
+			assert.DeepEqual(t, command[:3], []string{"psql", "-Xw", "--file=-"})
+			assert.Assert(t, cmp.Contains(strings.Join(command, " "), "--set=database=some-db"))
+			assert.Assert(t, cmp.Contains(strings.Join(command, " "), "--set=owner= "))
+			assert.Assert(t, cmp.Contains(strings.Join(command, " "), "--set=grantees=[] "))
+			return nil
+		}
+
+		drift, err := WriteDatabaseInPostgreSQL(ctx, exec, &v1beta1.PostgresDatabaseSpec{
+			Name: "some-db",
+		}, nil)
+		assert.NilError(t, err)
+		assert.Assert(t, drift == nil)
+		assert.Equal(t, calls, 1, "expected no schemas nor extensions")
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+
+			switch calls {
+			case 1:
+				assert.Assert(t, cmp.Contains(strings.Join(command, " "), "--set=limit=10 "))
+				assert.Assert(t, cmp.Contains(strings.Join(command, " "), "--set=owner=app "))
+				assert.Assert(t, cmp.Contains(strings.Join(command, " "), `--set=grantees=["app","other"] `))
+				assert.Assert(t, cmp.Contains(string(b), `GRANT ALL PRIVILEGES ON DATABASE %I TO %I`))
+				_, _ = stdout.Write([]byte(`{"encoding":"UTF8","database":{"datcollate":"C","datctype":"C"}}` + "\n"))
+			case 2:
+				assert.Equal(t, command[0], "bash")
+				assert.Assert(t, cmp.Contains(string(b), `CREATE SCHEMA IF NOT EXISTS %I`))
+				assert.Assert(t, cmp.Contains(string(b), `ALTER EXTENSION %I UPDATE TO %L`))
+				assert.Assert(t, cmp.Contains(strings.Join(command, " "),
+					`--set=extensions=[{"name":"postgis","version":"3.4.2"}]`))
+				assert.Assert(t, cmp.Contains(strings.Join(command, " "),
+					`--set=schemas=[{"name":"app","owner":"app"}]`))
+				assert.Assert(t, cmp.Contains(strings.Join(command, " "), `SELECT :'database'`))
+			}
+			return nil
+		}
+
+		drift, err := WriteDatabaseInPostgreSQL(ctx, exec, &v1beta1.PostgresDatabaseSpec{
+			Name:            "db",
+			Owner:           "app",
+			Encoding:        "utf-8",
+			Locale:          "en_US.UTF-8",
+			ConnectionLimit: initialize.Int32(10),
+			Extensions:      []v1beta1.PostgresExtensionSpec{{Name: "postgis", Version: "3.4.2"}},
+			Schemas:         []v1beta1.PostgresSchemaSpec{{Name: "app", Owner: "app"}},
+		}, []string{"app", "other"})
+		assert.NilError(t, err)
+		assert.Equal(t, calls, 2)
+		assert.DeepEqual(t, drift, []string{`collate is "C"`, `ctype is "C"`})
+	})
+}
+
+func TestDatabaseDrift(t *testing.T) {
+	t.Parallel()
+
+	spec := &v1beta1.PostgresDatabaseSpec{Name: "db"}
+	assert.Assert(t, databaseDrift(spec, "") == nil)
+	assert.Assert(t, databaseDrift(spec, "not json") == nil)
+
+	spec.Encoding = "UTF8"
+	spec.ICULocale = "en-US"
+	assert.Assert(t, databaseDrift(spec,
+		`{"encoding":"UTF8","database":{"daticulocale":"en-US"}}`) == nil)
+	assert.Assert(t, databaseDrift(spec,
+		`{"encoding":"UTF8","database":{"datlocale":"en-US"}}`) == nil)
+
+	assert.DeepEqual(t, databaseDrift(spec,
+		`{"encoding":"SQL_ASCII","database":{}}`),
+		[]string{`encoding is "SQL_ASCII"`, `icu locale is ""`})
+}
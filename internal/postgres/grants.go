@@ -0,0 +1,270 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// grant is one privilege or role membership in the "grants" psql variable.
+type grant struct {
+	Username  string `json:"username"`
+	Kind      string `json:"kind"`
+	Database  string `json:"database,omitempty"`
+	Schema    string `json:"schema,omitempty"`
+	Owner     string `json:"owner,omitempty"`
+	Privilege string `json:"privilege,omitempty"`
+	Role      string `json:"role,omitempty"`
+}
+
+// grants flattens the grants of users into one list. Users without grants
+// and the "postgres" user are skipped.
+func grants(users []v1beta1.PostgresUserSpec) ([]string, []grant) {
+	var managed []string
+	result := []grant{}
+
+	for i := range users {
+		spec := users[i].Grants
+		username := users[i].Name
+
+		if spec == nil || username == "postgres" {
+			continue
+		}
+		managed = append(managed, username)
+
+		for _, role := range spec.Roles {
+			result = append(result, grant{Username: username, Kind: "ROLE", Role: role})
+		}
+		for _, database := range spec.Databases {
+			for _, privilege := range database.Privileges {
+				result = append(result, grant{Username: username, Kind: "DATABASE",
+					Database: database.Name, Privilege: strings.ToUpper(privilege)})
+			}
+			for _, schema := range database.Schemas {
+				for _, privilege := range schema.Privileges {
+					result = append(result, grant{Username: username, Kind: "SCHEMA",
+						Database: database.Name, Schema: schema.Name, Privilege: strings.ToUpper(privilege)})
+				}
+				for _, objects := range []struct {
+					kind       string
+					privileges []string
+				}{
+					{kind: "TABLES", privileges: schema.Tables},
+					{kind: "SEQUENCES", privileges: schema.Sequences},
+					{kind: "FUNCTIONS", privileges: schema.Functions},
+				} {
+					kind := objects.kind
+					for _, privilege := range objects.privileges {
+						result = append(result, grant{Username: username, Kind: kind,
+							Database: database.Name, Schema: schema.Name, Privilege: strings.ToUpper(privilege)})
+
+						for _, owner := range schema.DefaultPrivilegesFor {
+							result = append(result, grant{Username: username, Kind: "DEFAULT " + kind,
+								Database: database.Name, Schema: schema.Name, Owner: owner,
+								Privilege: strings.ToUpper(privilege)})
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return managed, result
+}
+
+// WriteUserGrantsInPostgreSQL calls exec to grant the privileges and role
+// memberships of users that have grants and to revoke any they should not
+// have. Users without grants are not changed. Privileges on databases and
+// schemas that do not exist are not granted; it returns the names of those
+// so they can be granted once they exist.
+func WriteUserGrantsInPostgreSQL(
+	ctx context.Context, exec Executor, users []v1beta1.PostgresUserSpec,
+) ([]string, error) {
+	log := logging.FromContext(ctx)
+
+	managed, desired := grants(users)
+	if len(managed) == 0 {
+		return nil, nil
+	}
+
+	usernames, err := json.Marshal(managed)
+	if err != nil {
+		return nil, err
+	}
+	list, err := json.Marshal(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]string{
+		"grants": string(list),
+		"users":  string(usernames),
+
+		"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+		"QUIET":         "on", // Do not print successful statements to stdout.
+	}
+
+	// Role memberships and database privileges are shared by all databases.
+	// Create group roles that do not exist, grant memberships and database
+	// privileges, then revoke those that are not specified.
+	// - https://www.postgresql.org/docs/current/role-membership.html
+	// - https://www.postgresql.org/docs/current/ddl-priv.html
+	stdout, stderr, err := exec.Exec(ctx, strings.NewReader(strings.TrimSpace(`
+SET client_min_messages = WARNING;
+SET synchronous_commit = LOCAL;
+SET search_path TO '';
+CREATE TEMPORARY TABLE input AS
+SELECT * FROM pg_catalog.json_to_recordset(:'grants')
+    AS g (username text, kind text, database text, privilege text, role text);
+BEGIN;
+SELECT pg_catalog.format('CREATE ROLE %I NOLOGIN', r.role)
+  FROM (SELECT DISTINCT role FROM input WHERE kind = 'ROLE') r
+ WHERE NOT EXISTS (SELECT 1 FROM pg_catalog.pg_roles WHERE rolname = r.role)
+\gexec
+SELECT pg_catalog.format('GRANT %I TO %I', role, username)
+  FROM input WHERE kind = 'ROLE'
+\gexec
+SELECT pg_catalog.format('REVOKE %I FROM %I GRANTED BY %I', r.rolname, u.rolname, g.rolname)
+  FROM pg_catalog.pg_auth_members m
+  JOIN pg_catalog.pg_roles r ON r.oid = m.roleid
+  JOIN pg_catalog.pg_roles u ON u.oid = m.member
+  JOIN pg_catalog.pg_roles g ON g.oid = m.grantor
+ WHERE u.rolname IN (SELECT pg_catalog.json_array_elements_text(:'users'))
+   AND NOT EXISTS (SELECT 1 FROM input
+       WHERE kind = 'ROLE' AND username = u.rolname AND role = r.rolname)
+\gexec
+SELECT pg_catalog.format('GRANT %s ON DATABASE %I TO %I', privilege, database, username)
+  FROM input WHERE kind = 'DATABASE'
+   AND EXISTS (SELECT 1 FROM pg_catalog.pg_database WHERE datname = input.database)
+\gexec
+SELECT pg_catalog.format('REVOKE %s ON DATABASE %I FROM %I', a.privilege_type, d.datname, u.rolname)
+  FROM pg_catalog.pg_database d
+  CROSS JOIN LATERAL pg_catalog.aclexplode(d.datacl) a
+  JOIN pg_catalog.pg_roles u ON u.oid = a.grantee
+ WHERE u.rolname IN (SELECT pg_catalog.json_array_elements_text(:'users'))
+   AND u.oid <> d.datdba
+   AND NOT EXISTS (SELECT 1 FROM input WHERE kind = 'DATABASE'
+       AND username = u.rolname AND database = d.datname AND privilege = a.privilege_type)
+\gexec
+COMMIT;
+\pset format unaligned
+\pset tuples_only on
+SELECT DISTINCT pg_catalog.format('database %I', database)
+  FROM input WHERE database IS NOT NULL
+   AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_database WHERE datname = input.database);
+`)), variables)
+
+	log.V(1).Info("wrote PostgreSQL role grants", "stdout", stdout, "stderr", stderr)
+	missing := missingGrantTargets(stdout)
+
+	// Privileges on schemas and their objects are stored in each database.
+	if err == nil {
+		stdout, stderr, err = exec.ExecInAllDatabases(ctx, grantsInDatabaseSQL, variables)
+		missing = append(missing, missingGrantTargets(stdout)...)
+
+		log.V(1).Info("wrote PostgreSQL object grants", "stdout", stdout, "stderr", stderr)
+	}
+
+	return missing, err
+}
+
+// missingGrantTargets returns the databases and schemas that the grant scripts
+// printed, one per line, because they do not exist.
+func missingGrantTargets(stdout string) []string {
+	var result []string
+	for line := range strings.Lines(stdout) {
+		if line = strings.TrimSpace(line); line != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+// grantsInDatabaseSQL grants and revokes privileges on the schemas, tables,
+// sequences, and functions of the current database. Privileges on objects
+// owned by a user are not changed.
+// - https://www.postgresql.org/docs/current/sql-grant.html
+// - https://www.postgresql.org/docs/current/sql-revoke.html
+// - https://www.postgresql.org/docs/current/sql-alterdefaultprivileges.html
+const grantsInDatabaseSQL = `
+SET client_min_messages = WARNING;
+SET synchronous_commit = LOCAL;
+SET search_path TO '';
+CREATE TEMPORARY TABLE input AS
+SELECT * FROM pg_catalog.json_to_recordset(:'grants')
+    AS g (username text, kind text, database text, schema text, owner text, privilege text)
+ WHERE database = pg_catalog.current_database();
+CREATE TEMPORARY TABLE managed AS
+SELECT oid, rolname FROM pg_catalog.pg_roles
+ WHERE rolname IN (SELECT pg_catalog.json_array_elements_text(:'users'));
+BEGIN;
+SELECT pg_catalog.format('REVOKE %s ON SCHEMA %I FROM %I', a.privilege_type, n.nspname, u.rolname)
+  FROM pg_catalog.pg_namespace n
+  CROSS JOIN LATERAL pg_catalog.aclexplode(n.nspacl) a
+  JOIN managed u ON u.oid = a.grantee
+ WHERE u.oid <> n.nspowner
+   AND NOT EXISTS (SELECT 1 FROM input WHERE kind = 'SCHEMA'
+       AND username = u.rolname AND schema = n.nspname AND privilege = a.privilege_type)
+\gexec
+SELECT pg_catalog.format('REVOKE %s ON %s %I.%I FROM %I', a.privilege_type,
+       CASE WHEN c.relkind = 'S' THEN 'SEQUENCE' ELSE 'TABLE' END,
+       n.nspname, c.relname, u.rolname)
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+  CROSS JOIN LATERAL pg_catalog.aclexplode(c.relacl) a
+  JOIN managed u ON u.oid = a.grantee
+ WHERE u.oid <> c.relowner
+   AND NOT EXISTS (SELECT 1 FROM input WHERE username = u.rolname AND schema = n.nspname
+       AND kind = CASE WHEN c.relkind = 'S' THEN 'SEQUENCES' ELSE 'TABLES' END
+       AND privilege = a.privilege_type)
+\gexec
+SELECT pg_catalog.format('REVOKE %s ON FUNCTION %s FROM %I', a.privilege_type, p.oid::pg_catalog.regprocedure, u.rolname)
+  FROM pg_catalog.pg_proc p
+  JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace
+  CROSS JOIN LATERAL pg_catalog.aclexplode(p.proacl) a
+  JOIN managed u ON u.oid = a.grantee
+ WHERE u.oid <> p.proowner AND p.prokind <> 'p'
+   AND NOT EXISTS (SELECT 1 FROM input WHERE kind = 'FUNCTIONS'
+       AND username = u.rolname AND schema = n.nspname AND privilege = a.privilege_type)
+\gexec
+SELECT pg_catalog.format('ALTER DEFAULT PRIVILEGES FOR ROLE %I IN SCHEMA %I REVOKE %s ON %s FROM %I',
+       o.rolname, n.nspname, a.privilege_type, k.kind, u.rolname)
+  FROM pg_catalog.pg_default_acl d
+  JOIN pg_catalog.pg_roles o ON o.oid = d.defaclrole
+  JOIN pg_catalog.pg_namespace n ON n.oid = d.defaclnamespace
+  JOIN (VALUES ('r', 'TABLES'), ('S', 'SEQUENCES'), ('f', 'FUNCTIONS')) k (objtype, kind)
+    ON k.objtype = d.defaclobjtype::text
+  CROSS JOIN LATERAL pg_catalog.aclexplode(d.defaclacl) a
+  JOIN managed u ON u.oid = a.grantee
+ WHERE NOT EXISTS (SELECT 1 FROM input WHERE kind = 'DEFAULT ' || k.kind
+       AND username = u.rolname AND schema = n.nspname AND owner = o.rolname
+       AND privilege = a.privilege_type)
+\gexec
+SELECT pg_catalog.format('GRANT %s ON SCHEMA %I TO %I', privilege, schema, username)
+  FROM input WHERE kind = 'SCHEMA'
+   AND EXISTS (SELECT 1 FROM pg_catalog.pg_namespace WHERE nspname = input.schema)
+\gexec
+SELECT pg_catalog.format('GRANT %s ON ALL %s IN SCHEMA %I TO %I', privilege, kind, schema, username)
+  FROM input WHERE kind IN ('TABLES', 'SEQUENCES', 'FUNCTIONS')
+   AND EXISTS (SELECT 1 FROM pg_catalog.pg_namespace WHERE nspname = input.schema)
+\gexec
+SELECT pg_catalog.format('ALTER DEFAULT PRIVILEGES FOR ROLE %I IN SCHEMA %I GRANT %s ON %s TO %I',
+       owner, schema, privilege, pg_catalog.substr(kind, 9), username)
+  FROM input WHERE kind LIKE 'DEFAULT %'
+   AND EXISTS (SELECT 1 FROM pg_catalog.pg_namespace WHERE nspname = input.schema)
+   AND EXISTS (SELECT 1 FROM pg_catalog.pg_roles WHERE rolname = input.owner)
+\gexec
+COMMIT;
+\pset format unaligned
+\pset tuples_only on
+SELECT DISTINCT pg_catalog.format('schema %I.%I', database, schema)
+  FROM input WHERE schema IS NOT NULL
+   AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_namespace WHERE nspname = input.schema);
+`
@@ -0,0 +1,170 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestGrants(t *testing.T) {
+	t.Parallel()
+
+	managed, result := grants(nil)
+	assert.Assert(t, managed == nil)
+	assert.DeepEqual(t, result, []grant{})
+
+	managed, result = grants([]v1beta1.PostgresUserSpec{
+		{Name: "no-grants"},
+		{Name: "postgres", Grants: &v1beta1.PostgresGrantsSpec{Roles: []string{"ignored"}}},
+		{Name: "app", Grants: &v1beta1.PostgresGrantsSpec{
+			Roles: []string{"readers"},
+			Databases: []v1beta1.PostgresDatabaseGrantSpec{{
+				Name:       "db1",
+				Privileges: []string{"CONNECT"},
+				Schemas: []v1beta1.PostgresSchemaGrantSpec{{
+					Name:                 "s1",
+					Privileges:           []string{"USAGE"},
+					Tables:               []string{"SELECT"},
+					Functions:            []string{"EXECUTE"},
+					DefaultPrivilegesFor: []string{"owner"},
+				}},
+			}},
+		}},
+		{Name: "empty", Grants: &v1beta1.PostgresGrantsSpec{}},
+	})
+
+	assert.DeepEqual(t, managed, []string{"app", "empty"})
+	assert.DeepEqual(t, result, []grant{
+		{Username: "app", Kind: "ROLE", Role: "readers"},
+		{Username: "app", Kind: "DATABASE", Database: "db1", Privilege: "CONNECT"},
+		{Username: "app", Kind: "SCHEMA", Database: "db1", Schema: "s1", Privilege: "USAGE"},
+		{Username: "app", Kind: "TABLES", Database: "db1", Schema: "s1", Privilege: "SELECT"},
+		{Username: "app", Kind: "DEFAULT TABLES", Database: "db1", Schema: "s1", Owner: "owner", Privilege: "SELECT"},
+		{Username: "app", Kind: "FUNCTIONS", Database: "db1", Schema: "s1", Privilege: "EXECUTE"},
+		{Username: "app", Kind: "DEFAULT FUNCTIONS", Database: "db1", Schema: "s1", Owner: "owner", Privilege: "EXECUTE"},
+	})
+}
+
+func TestWriteUserGrantsInPostgreSQL(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("NoGrants", func(t *testing.T) {
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			t.Fatal("expected no calls")
+			return nil
+		}
+
+		missing, err := WriteUserGrantsInPostgreSQL(ctx, exec, nil)
+		assert.NilError(t, err)
+		assert.Assert(t, missing == nil)
+
+		missing, err = WriteUserGrantsInPostgreSQL(ctx, exec, []v1beta1.PostgresUserSpec{
+			{Name: "app", Databases: []string{"db1"}},
+		})
+		assert.NilError(t, err)
+		assert.Assert(t, missing == nil)
+	})
+
+	t.Run("Grants", func(t *testing.T) {
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+
+			args := strings.Join(command, " ")
+			assert.Assert(t, cmp.Contains(args, `--set=users=["app"]`))
+			assert.Assert(t, cmp.Contains(args,
+				`--set=grants=[{"username":"app","kind":"ROLE","role":"readers"}]`))
+
+			switch calls {
+			case 1:
+				assert.Equal(t, command[0], "psql")
+				assert.Assert(t, cmp.Contains(string(b), `CREATE ROLE %I NOLOGIN`))
+				assert.Assert(t, cmp.Contains(string(b), `REVOKE %s ON DATABASE %I FROM %I`))
+			case 2:
+				assert.Equal(t, command[0], "bash")
+				assert.Assert(t, cmp.Contains(string(b), `REVOKE %s ON SCHEMA %I FROM %I`))
+				assert.Assert(t, cmp.Contains(string(b), `GRANT %s ON ALL %s IN SCHEMA %I TO %I`))
+			}
+			return nil
+		}
+
+		missing, err := WriteUserGrantsInPostgreSQL(ctx, exec, []v1beta1.PostgresUserSpec{
+			{Name: "app", Grants: &v1beta1.PostgresGrantsSpec{Roles: []string{"readers"}}},
+		})
+		assert.NilError(t, err)
+		assert.Assert(t, missing == nil)
+		assert.Equal(t, calls, 2)
+	})
+
+	t.Run("MissingTargets", func(t *testing.T) {
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+
+			// Each script prints the targets that do not exist, one per line.
+			switch calls {
+			case 1:
+				assert.Assert(t, cmp.Contains(string(b), `pg_catalog.format('database %I', database)`))
+				_, _ = stdout.Write([]byte("database \"new db\"\n"))
+			case 2:
+				assert.Assert(t, cmp.Contains(string(b), `pg_catalog.format('schema %I.%I', database, schema)`))
+				_, _ = stdout.Write([]byte("schema db1.s1\nschema db1.s2\n"))
+			}
+			return nil
+		}
+
+		missing, err := WriteUserGrantsInPostgreSQL(ctx, exec, []v1beta1.PostgresUserSpec{
+			{Name: "app", Grants: &v1beta1.PostgresGrantsSpec{
+				Databases: []v1beta1.PostgresDatabaseGrantSpec{
+					{Name: "new db", Privileges: []string{"CONNECT"}},
+					{Name: "db1", Schemas: []v1beta1.PostgresSchemaGrantSpec{
+						{Name: "s1", Privileges: []string{"USAGE"}},
+						{Name: "s2", Privileges: []string{"USAGE"}},
+					}},
+				},
+			}},
+		})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, missing, []string{`database "new db"`, "schema db1.s1", "schema db1.s2"})
+	})
+
+	t.Run("StopsOnError", func(t *testing.T) {
+		expected := errors.New("boom")
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			calls++
+			return expected
+		}
+
+		_, err := WriteUserGrantsInPostgreSQL(ctx, exec, []v1beta1.PostgresUserSpec{
+			{Name: "app", Grants: &v1beta1.PostgresGrantsSpec{}},
+		})
+		assert.Equal(t, expected, err)
+		assert.Equal(t, calls, 1)
+	})
+}
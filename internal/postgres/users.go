@@ -59,7 +59,8 @@ func sanitizeAlterRoleOptions(options string) string {
 // WriteUsersInPostgreSQL calls exec to create users that do not exist in
 // PostgreSQL. Once they exist, it updates their options and passwords and
 // grants them access to their specified databases. The databases must already
-// exist.
+// exist. Users with grants are not granted access to their databases; see
+// [WriteUserGrantsInPostgreSQL].
 func WriteUsersInPostgreSQL(
 	ctx context.Context, cluster *v1beta1.PostgresCluster, exec Executor,
 	users []v1beta1.PostgresUserSpec, verifiers map[string]string,
@@ -94,6 +95,11 @@ CREATE TEMPORARY TABLE input (id serial, data json);
 		databases := spec.Databases
 		options := sanitizeAlterRoleOptions(spec.Options)
 
+		// Users with grants get exactly those privileges and nothing more.
+		if spec.Grants != nil {
+			databases = nil
+		}
+
 		// The "postgres" user must always be a superuser that can login to
 		// the "postgres" database.
 		if spec.Name == "postgres" {
@@ -180,6 +186,197 @@ SELECT pg_catalog.format('GRANT ALL PRIVILEGES ON DATABASE %I TO %I',
 	return err
 }
 
+// PreviousPasswordUser returns the name of the login role that accepts the
+// previous password of user during its grace period. User names cannot
+// contain underscores, so this cannot be the name of another user.
+func PreviousPasswordUser(user string) string { return user + "_previous" }
+
+// WritePreviousPasswordsInPostgreSQL calls exec to let the previous passwords
+// of users login during their grace periods. Each previous password belongs to
+// a separate login role that becomes the user when it connects. The roles of
+// users that have no previous password are dropped.
+func WritePreviousPasswordsInPostgreSQL(
+	ctx context.Context, exec Executor,
+	usernames []string, verifiers map[string]string,
+) error {
+	log := logging.FromContext(ctx)
+
+	type previous struct {
+		Role     string `json:"role"`
+		Username string `json:"username"`
+		Verifier string `json:"verifier,omitempty"`
+	}
+	input := make([]previous, 0, len(usernames))
+	for _, username := range usernames {
+		// PostgreSQL truncates longer names, so roles with them cannot exist.
+		// - https://www.postgresql.org/docs/current/sql-syntax-lexical.html#SQL-SYNTAX-IDENTIFIERS
+		if role := PreviousPasswordUser(username); len(role) <= 63 {
+			input = append(input, previous{
+				Role:     role,
+				Username: username,
+				Verifier: verifiers[username],
+			})
+		}
+	}
+	if len(input) == 0 {
+		return nil
+	}
+	users, _ := json.Marshal(input)
+
+	stdout, stderr, err := exec.Exec(ctx, strings.NewReader(strings.Join([]string{
+		// Do not wait for changes to be replicated. [Since PostgreSQL v9.1]
+		// - https://www.postgresql.org/docs/current/runtime-config-wal.html
+		`SET synchronous_commit = LOCAL;`,
+
+		// Prevent unexpected dereferences by emptying "search_path".
+		// - https://www.postgresql.org/docs/current/runtime-config-client.html#GUC-SEARCH-PATH
+		`SET search_path TO '';`,
+
+		`CREATE TEMPORARY TABLE input AS`,
+		`SELECT * FROM pg_catalog.json_to_recordset(:'users')`,
+		`    AS r (role text, username text, verifier text);`,
+
+		`BEGIN;`,
+
+		// Drop the roles of passwords that no longer work. Sessions that are
+		// already established stay connected.
+		// - https://www.postgresql.org/docs/current/sql-droprole.html
+		`SELECT pg_catalog.format('DROP ROLE %I', input.role)`,
+		`  FROM input JOIN pg_catalog.pg_roles ON rolname = input.role`,
+		` WHERE input.verifier IS NULL`,
+		`\gexec`,
+
+		// Create the roles of previous passwords as members of their users.
+		// Setting "role" makes each session act as the user, so privileges
+		// and ownership of new objects are the same as the user's.
+		// - https://www.postgresql.org/docs/current/sql-createrole.html
+		// - https://www.postgresql.org/docs/current/sql-set-role.html
+		`SELECT pg_catalog.format('CREATE ROLE %I IN ROLE %I', input.role, input.username)`,
+		`  FROM input`,
+		` WHERE input.verifier IS NOT NULL`,
+		`   AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_roles WHERE rolname = input.role)`,
+		`\gexec`,
+
+		`SELECT pg_catalog.format('ALTER ROLE %I WITH LOGIN PASSWORD %L', input.role, input.verifier),`,
+		`       pg_catalog.format('ALTER ROLE %I SET role TO %I', input.role, input.username)`,
+		`  FROM input`,
+		` WHERE input.verifier IS NOT NULL`,
+		`\gexec`,
+
+		`COMMIT;`,
+	}, "\n")),
+		map[string]string{
+			"users": string(users),
+
+			"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+			"QUIET":         "on", // Do not print successful statements to stdout.
+		})
+
+	log.V(1).Info("wrote previous PostgreSQL passwords", "stdout", stdout, "stderr", stderr)
+
+	return err
+}
+
+// RemoveUsersInPostgreSQL calls exec to revoke the access of users that were
+// removed from the cluster spec according to policy. Users that do not exist
+// are ignored.
+func RemoveUsersInPostgreSQL(
+	ctx context.Context, exec Executor,
+	policy *v1beta1.PostgresUserRemovalSpec, usernames []string,
+) error {
+	log := logging.FromContext(ctx)
+
+	if policy == nil || len(usernames) == 0 ||
+		policy.Policy == v1beta1.PostgresUserRemovalRetain {
+		return nil
+	}
+
+	users, _ := json.Marshal(usernames)
+
+	// Prevent the users from logging in and end any sessions they have.
+	// - https://www.postgresql.org/docs/current/sql-alterrole.html
+	// - https://www.postgresql.org/docs/current/functions-admin.html#FUNCTIONS-ADMIN-SIGNAL
+	stdout, stderr, err := exec.Exec(ctx, strings.NewReader(strings.Join([]string{
+		// Do not wait for changes to be replicated. [Since PostgreSQL v9.1]
+		// - https://www.postgresql.org/docs/current/runtime-config-wal.html
+		`SET synchronous_commit = LOCAL;`,
+
+		// Prevent unexpected dereferences by emptying "search_path".
+		// - https://www.postgresql.org/docs/current/runtime-config-client.html#GUC-SEARCH-PATH
+		`SET search_path TO '';`,
+
+		`SELECT pg_catalog.format('ALTER ROLE %I WITH NOLOGIN', rolname)`,
+		`  FROM pg_catalog.pg_roles`,
+		` WHERE rolname IN (SELECT pg_catalog.json_array_elements_text(:'users'))`,
+		` ORDER BY rolname`,
+		`\gexec`,
+
+		`SELECT pg_catalog.pg_terminate_backend(pid)`,
+		`  FROM pg_catalog.pg_stat_activity`,
+		` WHERE usename IN (SELECT pg_catalog.json_array_elements_text(:'users'));`,
+	}, "\n")),
+		map[string]string{
+			"users": string(users),
+
+			"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+			"QUIET":         "on", // Do not print successful statements to stdout.
+		})
+
+	log.V(1).Info("disabled PostgreSQL users", "stdout", stdout, "stderr", stderr)
+
+	if err != nil || policy.Policy != v1beta1.PostgresUserRemovalDrop {
+		return err
+	}
+
+	// Objects and privileges belong to each database, so reassign and revoke
+	// them everywhere before dropping the users.
+	// - https://www.postgresql.org/docs/current/sql-reassign-owned.html
+	// - https://www.postgresql.org/docs/current/sql-drop-owned.html
+	// - https://www.postgresql.org/docs/current/role-removal.html
+	stdout, stderr, err = exec.ExecInAllDatabases(ctx,
+		strings.Join([]string{
+			`SET synchronous_commit = LOCAL;`,
+			`SET search_path TO '';`,
+
+			`SELECT pg_catalog.format('REASSIGN OWNED BY %I TO %I', rolname, :'owner'),`,
+			`       pg_catalog.format('DROP OWNED BY %I', rolname)`,
+			`  FROM pg_catalog.pg_roles`,
+			` WHERE rolname IN (SELECT pg_catalog.json_array_elements_text(:'users'))`,
+			` ORDER BY rolname`,
+			`\gexec`,
+		}, "\n"),
+		map[string]string{
+			"owner": policy.ReassignTo,
+			"users": string(users),
+
+			"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+			"QUIET":         "on", // Do not print successful statements to stdout.
+		})
+
+	log.V(1).Info("reassigned PostgreSQL objects", "stdout", stdout, "stderr", stderr)
+
+	if err == nil {
+		stdout, stderr, err = exec.Exec(ctx, strings.NewReader(strings.Join([]string{
+			`SET synchronous_commit = LOCAL;`,
+			`SET search_path TO '';`,
+
+			`SELECT pg_catalog.format('DROP ROLE IF EXISTS %I', value)`,
+			`  FROM pg_catalog.json_array_elements_text(:'users')`,
+			`\gexec`,
+		}, "\n")),
+			map[string]string{
+				"users": string(users),
+
+				"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+				"QUIET":         "on", // Do not print successful statements to stdout.
+			})
+
+		log.V(1).Info("dropped PostgreSQL users", "stdout", stdout, "stderr", stderr)
+	}
+
+	return err
+}
+
 // WriteUsersSchemasInPostgreSQL will create a schema for each user in each database that user has access to
 func WriteUsersSchemasInPostgreSQL(ctx context.Context, exec Executor,
 	users []v1beta1.PostgresUserSpec) error {
@@ -153,6 +153,32 @@ COMMIT;`))
 		assert.Equal(t, calls, 1)
 	})
 
+	t.Run("Grants", func(t *testing.T) {
+		cluster := new(v1beta1.PostgresCluster)
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Assert(t, cmp.Contains(string(b), `
+\copy input (data) from stdin with (format text)
+{"databases":null,"options":"","username":"user-with-grants","verifier":""}
+\.
+`))
+			return nil
+		}
+
+		assert.NilError(t, WriteUsersInPostgreSQL(ctx, cluster, exec,
+			[]v1beta1.PostgresUserSpec{
+				{
+					Name:      "user-with-grants",
+					Databases: []string{"db1"},
+					Grants:    &v1beta1.PostgresGrantsSpec{},
+				},
+			}, nil,
+		))
+	})
+
 	t.Run("PostgresSuperuser", func(t *testing.T) {
 		calls := 0
 		cluster := new(v1beta1.PostgresCluster)
@@ -235,3 +261,122 @@ func TestWriteUsersSchemasInPostgreSQL(t *testing.T) {
 	})
 
 }
+
+func TestWritePreviousPasswordsInPostgreSQL(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Empty", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, _, _ io.Writer, _ ...string,
+		) error {
+			t.Fatal("expected no calls")
+			return nil
+		}
+
+		assert.NilError(t, WritePreviousPasswordsInPostgreSQL(ctx, exec, nil, nil))
+		assert.NilError(t, WritePreviousPasswordsInPostgreSQL(ctx, exec,
+			[]string{strings.Repeat("x", 55)}, nil), "expected long names to be skipped")
+	})
+
+	t.Run("Roles", func(t *testing.T) {
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Assert(t, cmp.Contains(command,
+				`--set=users=[{"role":"u1_previous","username":"u1","verifier":"old$verifier"},`+
+					`{"role":"u2_previous","username":"u2"}]`))
+
+			assert.Assert(t, cmp.Contains(string(b), `'DROP ROLE %I', input.role`))
+			assert.Assert(t, cmp.Contains(string(b), `'CREATE ROLE %I IN ROLE %I', input.role, input.username`))
+			assert.Assert(t, cmp.Contains(string(b), `'ALTER ROLE %I WITH LOGIN PASSWORD %L', input.role, input.verifier`))
+			assert.Assert(t, cmp.Contains(string(b), `'ALTER ROLE %I SET role TO %I', input.role, input.username`))
+			return nil
+		}
+
+		assert.NilError(t, WritePreviousPasswordsInPostgreSQL(ctx, exec,
+			[]string{"u1", "u2"}, map[string]string{"u1": "old$verifier"}))
+		assert.Equal(t, calls, 1)
+	})
+}
+
+func TestRemoveUsersInPostgreSQL(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Retain", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, _, _ io.Writer, _ ...string,
+		) error {
+			t.Fatal("expected no calls")
+			return nil
+		}
+
+		assert.NilError(t, RemoveUsersInPostgreSQL(ctx, exec, nil, []string{"any"}))
+		assert.NilError(t, RemoveUsersInPostgreSQL(ctx, exec,
+			&v1beta1.PostgresUserRemovalSpec{Policy: "Drop", ReassignTo: "owner"}, nil))
+		assert.NilError(t, RemoveUsersInPostgreSQL(ctx, exec,
+			&v1beta1.PostgresUserRemovalSpec{Policy: "Retain"}, []string{"any"}))
+	})
+
+	t.Run("NoLogin", func(t *testing.T) {
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Assert(t, cmp.Contains(command, `--set=users=["u1","u2"]`))
+			assert.Assert(t, cmp.Contains(string(b), `'ALTER ROLE %I WITH NOLOGIN'`))
+			assert.Assert(t, cmp.Contains(string(b), `pg_terminate_backend`))
+			assert.Assert(t, !strings.Contains(string(b), `DROP`))
+			return nil
+		}
+
+		assert.NilError(t, RemoveUsersInPostgreSQL(ctx, exec,
+			&v1beta1.PostgresUserRemovalSpec{Policy: "NoLogin"}, []string{"u1", "u2"}))
+		assert.Equal(t, calls, 1)
+	})
+
+	t.Run("Drop", func(t *testing.T) {
+		var scripts []string
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Assert(t, cmp.Contains(command, `--set=users=["u1"]`))
+			scripts = append(scripts, string(b))
+			return nil
+		}
+
+		assert.NilError(t, RemoveUsersInPostgreSQL(ctx, exec,
+			&v1beta1.PostgresUserRemovalSpec{Policy: "Drop", ReassignTo: "app"}, []string{"u1"}))
+		assert.Equal(t, len(scripts), 3)
+
+		assert.Assert(t, cmp.Contains(scripts[0], `NOLOGIN`))
+		assert.Assert(t, cmp.Contains(scripts[1], `'REASSIGN OWNED BY %I TO %I', rolname, :'owner'`))
+		assert.Assert(t, cmp.Contains(scripts[1], `'DROP OWNED BY %I', rolname`))
+		assert.Assert(t, cmp.Contains(scripts[2], `'DROP ROLE IF EXISTS %I'`))
+	})
+
+	t.Run("DropStopsOnError", func(t *testing.T) {
+		expected := errors.New("boom")
+		calls := 0
+		exec := func(
+			_ context.Context, _ io.Reader, _, _ io.Writer, _ ...string,
+		) error {
+			calls++
+			return expected
+		}
+
+		assert.Equal(t, expected, RemoveUsersInPostgreSQL(ctx, exec,
+			&v1beta1.PostgresUserRemovalSpec{Policy: "Drop", ReassignTo: "app"}, []string{"u1"}))
+		assert.Equal(t, calls, 1)
+	})
+}
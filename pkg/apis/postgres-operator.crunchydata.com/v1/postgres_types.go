@@ -54,7 +54,7 @@ type PostgresConfigSpec struct {
 	// +kubebuilder:validation:XValidation:rule=`!has(self.hot_standby)`,message=`hot_standby is always enabled`
 	// +kubebuilder:validation:XValidation:rule=`!has(self.synchronous_standby_names)`
 	// +kubebuilder:validation:XValidation:rule=`!has(self.primary_conninfo) && !has(self.primary_slot_name)`
-	// +kubebuilder:validation:XValidation:rule=`!has(self.recovery_min_apply_delay)`,message=`delayed replication is not supported at this time`
+	// +kubebuilder:validation:XValidation:rule=`!has(self.recovery_min_apply_delay)`,message=`delayed replication is configured by "replicationDelay" on instance sets`
 	//
 	// # Logging
 	// - https://www.postgresql.org/docs/current/runtime-config-logging.html
@@ -30,6 +30,15 @@ import (
 // # pgBackRest Logging
 //
 // +kubebuilder:validation:XValidation:fieldPath=`.backups.pgbackrest.log.path`,message=`all instances need an additional volume for pgbackrest sidecar to log in "/volumes"`,rule=`self.?backups.pgbackrest.log.path.optMap(v, !v.startsWith("/volumes") || self.instances.all(i, i.?volumes.additional.hasValue() && i.volumes.additional.exists(volume, v.startsWith("/volumes/" + volume.name)))).orValue(true)`
+//
+// # Failover
+//
+// +kubebuilder:validation:XValidation:fieldPath=`.instances`,message=`at least one instance set must be able to become primary`,rule=`self.instances.exists(i, !has(i.replicationDelay) && !i.?patroni.tags.noFailover.orValue(false) && i.?patroni.tags.failoverPriority.orValue(1) > 0)`
+//
+// # Synchronous Replication
+//
+// +kubebuilder:validation:XValidation:fieldPath=`.patroni.synchronous.instanceSets`,message=`synchronous instance sets must be defined in instances`,rule=`self.?patroni.synchronous.optMap(s, !has(s.instanceSets) || self.instances.filter(i, i.name in s.instanceSets).size() == s.instanceSets.size()).orValue(true)`
+// +kubebuilder:validation:XValidation:fieldPath=`.patroni.synchronous.standbys`,message=`not enough eligible replicas for synchronous standbys`,rule=`self.?patroni.synchronous.optMap(s, s.?mode.orValue("On") == "Off" || self.instances.filter(i, !has(i.replicationDelay) && !i.?patroni.tags.noSync.orValue(false) && (!has(s.instanceSets) || i.name in s.instanceSets)).map(i, i.?replicas.orValue(1)).sum() >= s.?standbys.orValue(1) + (has(s.instanceSets) ? 0 : 1)).orValue(true)`
 type PostgresClusterSpec struct {
 	// +optional
 	Metadata *v1beta1.Metadata `json:"metadata,omitempty"`
@@ -71,12 +80,30 @@ type PostgresClusterSpec struct {
 	// +optional
 	CustomReplicationClientTLSSecret *corev1.SecretProjection `json:"customReplicationTLSSecret,omitempty"`
 
+	// A cert-manager Issuer or ClusterIssuer that signs the PostgreSQL server,
+	// replication client, and PgBouncer certificates. The operator creates
+	// cert-manager Certificates and uses their Secrets once they are issued.
+	// Custom TLS secrets take precedence. This has no effect when cert-manager
+	// is not installed.
+	// More info: https://cert-manager.io/docs/usage/certificate/
+	// +optional
+	CertificateIssuer *v1beta1.CertificateIssuerReference `json:"certificateIssuer,omitempty"`
+
 	// DatabaseInitSQL defines a ConfigMap containing custom SQL that will
 	// be run after the cluster is initialized. This ConfigMap must be in the same
 	// namespace as the cluster.
 	// +optional
 	DatabaseInitSQL *DatabaseInitSQL `json:"databaseInitSQL,omitempty"`
 
+	// Databases to create inside PostgreSQL and how to configure them. These
+	// are created before users are granted access to them. Removing a database
+	// from this list does NOT drop the database.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	Databases []v1beta1.PostgresDatabaseSpec `json:"databases,omitempty"`
+
 	// Whether or not the PostgreSQL cluster should use the defined default
 	// scheduling constraints. If the field is unset or false, the default
 	// scheduling constraints will be used in addition to any custom constraints
@@ -123,6 +150,17 @@ type PostgresClusterSpec struct {
 	// +optional
 	Instrumentation *v1beta1.InstrumentationSpec `json:"instrumentation,omitempty"`
 
+	// Periods during which PostgreSQL Operator may disrupt PostgreSQL: recreate
+	// Pods, restart PostgreSQL, roll out PgBouncer, or start a major upgrade.
+	// When this is empty, these happen as soon as they are needed. Add the
+	// "postgres-operator.crunchydata.com/ignore-maintenance-windows" annotation
+	// to disregard these windows.
+	// ---
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=atomic
+	// +optional
+	MaintenanceWindows []v1beta1.MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
 	// Whether or not the PostgreSQL cluster is being deployed to an OpenShift
 	// environment. If the field is unset, the operator will automatically
 	// detect the environment.
@@ -176,6 +214,14 @@ type PostgresClusterSpec struct {
 	// +optional
 	ReplicaService *v1beta1.ServiceSpec `json:"replicaService,omitempty"`
 
+	// Which replicas receive read-only traffic from the replica Service.
+	// +optional
+	ReplicaRouting *v1beta1.ReplicaRoutingSpec `json:"replicaRouting,omitempty"`
+
+	// How PostgreSQL Operator recreates instance Pods when their templates change.
+	// +optional
+	Rollout *v1beta1.RolloutStrategy `json:"rollout,omitempty"`
+
 	// Whether or not the PostgreSQL cluster should be stopped.
 	// When this is true, workloads are scaled to zero and CronJobs
 	// are suspended.
@@ -206,12 +252,18 @@ type PostgresClusterSpec struct {
 	// Users to create inside PostgreSQL and the databases they should access.
 	// The default creates one user that can access one database matching the
 	// PostgresCluster name. An empty list creates no users. Removing a user
-	// from this list does NOT drop the user nor revoke their access.
+	// from this list does NOT drop the user nor revoke their access unless
+	// userRemoval says otherwise.
 	// +listType=map
 	// +listMapKey=name
 	// +kubebuilder:validation:MaxItems=64
 	// +optional
 	Users []v1beta1.PostgresUserSpec `json:"users,omitempty"`
+
+	// What to do with PostgreSQL users that are removed from the users list.
+	// The default retains them.
+	// +optional
+	UserRemoval *v1beta1.PostgresUserRemovalSpec `json:"userRemoval,omitempty"`
 }
 
 // DataSource defines data sources for a new PostgresCluster.
@@ -300,6 +352,13 @@ type PostgresClusterDataSource struct {
 	// +kubebuilder:validation:Pattern=^repo[1-4]
 	RepoName string `json:"repoName"`
 
+	// The name of a PGBackRestBackup to restore. It must be in the namespace of
+	// the source PostgresCluster and describe a backup in repoName. When set,
+	// the restore uses that backup rather than the latest one, so do not also
+	// include "--set" in options.
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
 	// Command line options to include when running the pgBackRest restore command.
 	// https://pgbackrest.org/command.html#command-restore
 	// +optional
@@ -373,6 +432,12 @@ type PostgresClusterStatus struct {
 	// Identifies the databases that have been installed into PostgreSQL.
 	DatabaseRevision string `json:"databaseRevision,omitempty"`
 
+	// Current state of the databases in spec.databases.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Databases []v1beta1.PostgresDatabaseStatus `json:"databases,omitempty"`
+
 	// Current state of PostgreSQL instances.
 	// +listType=map
 	// +listMapKey=name
@@ -425,8 +490,8 @@ type PostgresClusterStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
 	// conditions represent the observations of postgrescluster's current state.
-	// Known .status.conditions.type are: "PersistentVolumeResizing",
-	// "Progressing", "ProxyAvailable"
+	// Known .status.conditions.type are: "CertificatesIssued", "InstanceSetsValid",
+	// "PendingMaintenance", "PersistentVolumeResizing", "Progressing", "ProxyAvailable"
 	// +optional
 	// +listType=map
 	// +listMapKey=type
@@ -486,6 +551,10 @@ type PostgresInstanceSetSpec struct {
 	// +required
 	DataVolumeClaimSpec v1beta1.VolumeClaimSpecWithAutoGrow `json:"dataVolumeClaimSpec"`
 
+	// Patroni settings for the PostgreSQL pods in this set.
+	// +optional
+	Patroni *v1beta1.PatroniInstanceSetSpec `json:"patroni,omitempty"`
+
 	// Priority class name for the PostgreSQL pod. Changing this value causes
 	// PostgreSQL to restart.
 	// More info: https://kubernetes.io/docs/concepts/scheduling-eviction/pod-priority-preemption/
@@ -498,6 +567,24 @@ type PostgresInstanceSetSpec struct {
 	// +kubebuilder:validation:Minimum=1
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// How long the PostgreSQL pods in this set wait before applying changes
+	// from the primary. Delayed pods never become primary, do not receive
+	// traffic from the replica Service, and are not synchronous standbys.
+	// They can be used to recover from mistakes, such as dropped tables,
+	// without a full restore. Setting this on the first instance set, or removing
+	// it from the last, causes PostgreSQL to restart.
+	// More info: https://www.postgresql.org/docs/current/runtime-config-replication.html#GUC-RECOVERY-MIN-APPLY-DELAY
+	// ---
+	// PostgreSQL stores this value in milliseconds in a 32-bit integer, about 24 days.
+	// NOTE: This rejects fractional numbers: https://github.com/kubernetes/kube-openapi/issues/523
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("1s") <= self && self <= duration("576h")`,message="must be between one second and 24 days"
+	//
+	// +optional
+	ReplicationDelay *v1beta1.Duration `json:"replicationDelay,omitempty"`
+
 	// Minimum number of pods that should be available at a time.
 	// Defaults to one when the replicas field is greater than one.
 	// +optional
@@ -507,6 +594,14 @@ type PostgresInstanceSetSpec struct {
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitzero"`
 
+	// Specification of a Service that exposes only the replicas of this set.
+	// The Service is named "<cluster>-<set>-replicas", so the combined length
+	// of this set name and the cluster name must be 53 characters or less.
+	// When omitted, replicas of this set are reachable through the replica
+	// Service of the cluster only.
+	// +optional
+	Service *v1beta1.ServiceSpec `json:"service,omitempty"`
+
 	// Configuration for instance sidecar containers
 	// +optional
 	Sidecars *InstanceSidecars `json:"sidecars,omitempty"`
@@ -604,6 +699,10 @@ type PostgresInstanceSetStatus struct {
 	// Desired Size of the pgWAL volume
 	// +optional
 	DesiredPGWALVolume map[string]string `json:"desiredPGWALVolume,omitempty"`
+
+	// The Service that exposes only the replicas of this set.
+	// +optional
+	Service *v1beta1.PostgresInstanceSetServiceStatus `json:"service,omitempty"`
 }
 
 // PostgresProxySpec is a union of the supported PostgreSQL proxies.
@@ -674,6 +773,7 @@ type PostgresUserInterfaceStatus struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:validation:XValidation:fieldPath=`.spec.instances`,message=`the cluster name and the name of an instance set with a service must be 53 characters or less together`,rule=`!has(self.spec) || self.spec.instances.all(set, !has(set.service) || size(self.metadata.name) + size(set.name) <= 53)`
 // +operator-sdk:csv:customresourcedefinitions:resources={{ConfigMap,v1},{Secret,v1},{Service,v1},{CronJob,v1beta1},{Deployment,v1},{Job,v1},{StatefulSet,v1},{PersistentVolumeClaim,v1}}
 
 // PostgresCluster is the Schema for the postgresclusters API
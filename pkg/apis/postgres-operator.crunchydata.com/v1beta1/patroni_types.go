@@ -4,7 +4,12 @@
 
 package v1beta1
 
-import "k8s.io/apimachinery/pkg/api/resource"
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 type PatroniSpec struct {
 	// Patroni dynamic configuration settings. Changes to this value will be
@@ -48,6 +53,12 @@ type PatroniSpec struct {
 	// +optional
 	Switchover *PatroniSwitchover `json:"switchover,omitempty"`
 
+	// Synchronous replication settings. These take precedence over any
+	// synchronous settings in dynamicConfiguration.
+	// More info: https://patroni.readthedocs.io/en/latest/replication_modes.html
+	// +optional
+	Synchronous *PatroniSynchronousSpec `json:"synchronous,omitempty"`
+
 	// TODO(cbandy): Add UseConfigMaps bool, default false.
 	// TODO(cbandy): Allow other DCS: etcd, raft, etc?
 	// N.B. changing this will cause downtime.
@@ -98,6 +109,100 @@ type PatroniSwitchover struct {
 	Type string `json:"type,omitempty"`
 }
 
+type PatroniSynchronousSpec struct {
+	// How Patroni replicates transactions synchronously.
+	// "Off" replicates asynchronously.
+	// "On" waits for synchronous standbys while any are available.
+	// "Strict" waits for synchronous standbys even when none are available,
+	// and writes stop until one is available again.
+	// "Quorum" waits for any "standbys" number of eligible standbys to confirm
+	// each commit. Quorum requires Patroni 4 or greater.
+	// ---
+	// +kubebuilder:validation:Enum={Off,On,Strict,Quorum}
+	// +kubebuilder:default=On
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// The number of synchronous standbys. Patroni sets "synchronous_standby_names"
+	// so that each commit waits for this many standbys.
+	// ---
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	Standbys *int32 `json:"standbys,omitempty"`
+
+	// The names of the instance sets whose instances can be synchronous standbys.
+	// When empty, every instance can be a synchronous standby.
+	// ---
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MaxLength=46
+	// +listType=set
+	// +optional
+	InstanceSets []string `json:"instanceSets,omitempty"`
+}
+
+// PatroniSynchronousSpec modes.
+const (
+	PatroniSynchronousModeOff    = "Off"
+	PatroniSynchronousModeOn     = "On"
+	PatroniSynchronousModeQuorum = "Quorum"
+	PatroniSynchronousModeStrict = "Strict"
+)
+
+// Enabled returns whether or not s turns on synchronous replication.
+func (s *PatroniSynchronousSpec) Enabled() bool {
+	return s != nil && s.Mode != PatroniSynchronousModeOff
+}
+
+// Eligible returns whether or not instances of the named instance set can be
+// synchronous standbys.
+func (s *PatroniSynchronousSpec) Eligible(instanceSet string) bool {
+	return s.Enabled() && (len(s.InstanceSets) == 0 || slices.Contains(s.InstanceSets, instanceSet))
+}
+
+// PatroniInstanceSetSpec defines Patroni settings that apply to the instances
+// of one instance set.
+type PatroniInstanceSetSpec struct {
+
+	// Tags that change how Patroni treats the instances of this set.
+	// More info: https://patroni.readthedocs.io/en/latest/yaml_configuration.html#tags
+	// +optional
+	Tags *PatroniTags `json:"tags,omitempty"`
+}
+
+type PatroniTags struct {
+
+	// Whether or not instances can become the primary.
+	// +optional
+	NoFailover bool `json:"noFailover,omitempty"`
+
+	// The priority of instances when choosing a new primary. Instances with
+	// higher values are preferred; zero prevents them from becoming primary.
+	// Requires Patroni 3.2 or greater.
+	// ---
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailoverPriority *int32 `json:"failoverPriority,omitempty"`
+
+	// Whether or not instances are excluded from the replica Service. Excluding
+	// the first instance set, or including the last, causes PostgreSQL to restart.
+	// +optional
+	NoLoadBalance bool `json:"noLoadBalance,omitempty"`
+
+	// Whether or not instances can be synchronous standbys.
+	// +optional
+	NoSync bool `json:"noSync,omitempty"`
+
+	// The name of another instance set from which instances should replicate
+	// rather than the primary. Instances replicate from the primary while
+	// that set has no running instances.
+	// ---
+	// +kubebuilder:validation:MaxLength=46
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	ReplicateFrom string `json:"replicateFrom,omitempty"`
+}
+
 // PatroniSwitchover types.
 const (
 	PatroniSwitchoverTypeFailover   = "Failover"
@@ -141,4 +246,81 @@ type PatroniStatus struct {
 	// Tracks the current timeline during switchovers
 	// +optional
 	SwitchoverTimeline *int64 `json:"switchoverTimeline,omitempty"`
+
+	// The instances that Patroni reports as synchronous standbys.
+	// ---
+	// +listType=atomic
+	// +optional
+	SynchronousStandbys []string `json:"synchronousStandbys,omitempty"`
+
+	// The instances of the cluster as reported by Patroni, by name.
+	// ---
+	// +kubebuilder:validation:MaxItems=1000
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Members []PatroniMemberStatus `json:"members,omitempty"`
+
+	// When the write-ahead log locations and lag of members were observed.
+	// They are observed at most once a minute unless something else about
+	// the members changes.
+	// +optional
+	MembersObservedTime *metav1.Time `json:"membersObservedTime,omitempty"`
+}
+
+// PatroniMemberStatus describes one instance of a PostgresCluster as reported by Patroni.
+type PatroniMemberStatus struct {
+	// The name of the instance.
+	// +required
+	Name string `json:"name"`
+
+	// The name of the instance Pod.
+	// +optional
+	Pod string `json:"pod,omitempty"`
+
+	// The role of the instance in the Patroni cluster: "primary", "replica",
+	// or "standby_leader".
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// The state of PostgreSQL in the instance, such as "running" or "starting".
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// The timeline of PostgreSQL in the instance.
+	// +optional
+	Timeline *int64 `json:"timeline,omitempty"`
+
+	// The latest location in the write-ahead log that the instance received.
+	// This is absent on the primary and when Patroni does not report it.
+	// +optional
+	ReceivedLSN string `json:"receivedLSN,omitempty"`
+
+	// The latest location in the write-ahead log that the instance replayed.
+	// On the primary, this is the latest location written.
+	// +optional
+	ReplayedLSN string `json:"replayedLSN,omitempty"`
+
+	// How far the instance is behind the primary, in bytes of WAL it has yet to replay.
+	// This is absent on the primary and when Patroni does not know.
+	// +optional
+	LagBytes *int64 `json:"lagBytes,omitempty"`
+
+	// How far the instance is behind the primary, in seconds. This is observed
+	// only while spec.replicaRouting.maxLagTime is set.
+	// +optional
+	LagSeconds *int64 `json:"lagSeconds,omitempty"`
+
+	// Whether or not the instance is too far behind the primary to receive
+	// read-only traffic. See spec.replicaRouting.
+	// +optional
+	Lagging *bool `json:"lagging,omitempty"`
+
+	// Whether or not PostgreSQL in the instance must restart to apply its parameters.
+	// +optional
+	PendingRestart *bool `json:"pendingRestart,omitempty"`
+
+	// The major version of PostgreSQL in the instance, according to its data directory.
+	// +optional
+	PostgresVersion int32 `json:"postgresVersion,omitempty"`
 }
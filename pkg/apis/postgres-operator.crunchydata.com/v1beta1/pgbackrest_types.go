@@ -46,6 +46,29 @@ type PGBackRestJobStatus struct {
 	Failed int32 `json:"failed,omitempty"`
 }
 
+// PGBackRestRestoreStatus contains information about the state of a pgBackRest
+// restore and the point PostgreSQL reached when it finished recovery.
+type PGBackRestRestoreStatus struct {
+	PGBackRestJobStatus `json:",inline"`
+
+	// The location of the last WAL record replayed during recovery.
+	// +optional
+	RecoveredLSN string `json:"recoveredLSN,omitempty"`
+
+	// The commit time of the last transaction replayed during recovery.
+	// It is represented in RFC3339 form and is in UTC.
+	// +optional
+	RecoveredTime *metav1.Time `json:"recoveredTime,omitempty"`
+
+	// The PostgreSQL timeline of the restored data after recovery.
+	// +optional
+	Timeline int64 `json:"timeline,omitempty"`
+
+	// The PostgreSQL system identifier of the restored data.
+	// +optional
+	SystemIdentifier string `json:"systemIdentifier,omitempty"`
+}
+
 type PGBackRestScheduledBackupStatus struct {
 
 	// The name of the associated pgBackRest scheduled backup CronJob
@@ -299,6 +322,92 @@ type PGBackRestBackupSchedules struct {
 	Incremental *string `json:"incremental,omitempty"`
 }
 
+// PGBackRestRetention defines how long pgBackRest keeps backups and WAL in a repository.
+// ---
+// +kubebuilder:validation:XValidation:rule=`!has(self.fullType) || has(self.full)`,message=`fullType requires full`
+// +kubebuilder:validation:XValidation:rule=`self.?archiveType.orValue("full") != "incr" || has(self.archive)`,message=`archive is required when archiveType is "incr"`
+type PGBackRestRetention struct {
+
+	// The number of full backups to keep, or the number of days to keep full backups
+	// when fullType is "time". Backups that depend on an expired full backup are
+	// expired with it.
+	// More info: https://pgbackrest.org/configuration.html#section-repository/option-repo-retention-full
+	// ---
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=9999999
+	// +optional
+	Full *int32 `json:"full,omitempty"`
+
+	// How full is measured: "count" keeps that many full backups and "time" keeps
+	// full backups for that many days. Defaults to "count".
+	// ---
+	// +kubebuilder:validation:Enum={count,time}
+	// +optional
+	FullType string `json:"fullType,omitempty"`
+
+	// The number of differential backups to keep. Incremental backups that depend
+	// on an expired differential backup are expired with it.
+	// More info: https://pgbackrest.org/configuration.html#section-repository/option-repo-retention-diff
+	// ---
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=9999999
+	// +optional
+	Differential *int32 `json:"differential,omitempty"`
+
+	// The number of backups of archiveType to keep WAL for. WAL that is needed to
+	// make any remaining backup consistent is always kept.
+	// More info: https://pgbackrest.org/configuration.html#section-repository/option-repo-retention-archive
+	// ---
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=9999999
+	// +optional
+	Archive *int32 `json:"archive,omitempty"`
+
+	// The type of backup counted by archive: "full", "diff", or "incr".
+	// Defaults to "full".
+	// ---
+	// +kubebuilder:validation:Enum={full,diff,incr}
+	// +optional
+	ArchiveType string `json:"archiveType,omitempty"`
+
+	// Defines the Cron schedule for expiring backups and WAL according to this
+	// retention. Defaults to "@daily".
+	// Follows the standard Cron schedule syntax:
+	// https://k8s.io/docs/concepts/workloads/controllers/cron-jobs/#cron-schedule-syntax
+	// ---
+	// +kubebuilder:validation:MinLength=6
+	// +optional
+	ExpireSchedule *string `json:"expireSchedule,omitempty"`
+}
+
+// PGBackRestVerify defines how often pgBackRest verifies a repository.
+type PGBackRestVerify struct {
+
+	// Defines the Cron schedule for verifying the repository.
+	// Follows the standard Cron schedule syntax:
+	// https://k8s.io/docs/concepts/workloads/controllers/cron-jobs/#cron-schedule-syntax
+	// ---
+	// +kubebuilder:validation:MinLength=6
+	// +required
+	Schedule string `json:"schedule"`
+
+	// When set, the latest backup is also restored into a temporary volume after
+	// it is verified. The volume is deleted when the restore finishes.
+	// +optional
+	RestoreTest *PGBackRestRestoreTest `json:"restoreTest,omitempty"`
+}
+
+// PGBackRestRestoreTest defines a throwaway restore of the latest backup.
+type PGBackRestRestoreTest struct {
+
+	// Defines a PersistentVolumeClaim for the temporary volume. It must be large
+	// enough to hold the restored PostgreSQL data directory.
+	// More info: https://kubernetes.io/docs/concepts/storage/ephemeral-volumes/#generic-ephemeral-volumes
+	// ---
+	// +required
+	VolumeClaimSpec VolumeClaimSpec `json:"volumeClaimSpec"`
+}
+
 // PGBackRestStatus defines the status of pgBackRest within a PostgresCluster
 type PGBackRestStatus struct {
 
@@ -320,9 +429,10 @@ type PGBackRestStatus struct {
 	// +listMapKey=name
 	Repos []RepoStatus `json:"repos,omitempty"`
 
-	// Status information for in-place restores
+	// Status information for restores, both in-place and when initializing
+	// a cluster from a data source
 	// +optional
-	Restore *PGBackRestJobStatus `json:"restore,omitempty"`
+	Restore *PGBackRestRestoreStatus `json:"restore,omitempty"`
 }
 
 // PGBackRestRepo represents a pgBackRest repository.  Only one of its members may be specified.
@@ -342,6 +452,19 @@ type PGBackRestRepo struct {
 	// +optional
 	BackupSchedules *PGBackRestBackupSchedules `json:"schedules,omitempty"`
 
+	// Defines how long pgBackRest keeps backups and WAL in the repository. When set,
+	// pgBackRest also expires backups on a schedule so that retention applies even
+	// when no new backups are taken. Options in "global" take precedence.
+	// More info: https://pgbackrest.org/user-guide.html#retention
+	// +optional
+	Retention *PGBackRestRetention `json:"retention,omitempty"`
+
+	// Defines a schedule for verifying the backups and WAL in the repository and,
+	// optionally, for restoring the latest backup into a temporary volume.
+	// More info: https://pgbackrest.org/command.html#command-verify
+	// +optional
+	Verify *PGBackRestVerify `json:"verify,omitempty"`
+
 	// Represents a pgBackRest repository that is created using Azure storage
 	// +optional
 	Azure *RepoAzure `json:"azure,omitempty"`
@@ -443,6 +566,124 @@ type RepoStatus struct {
 	// Desired Size of the repo volume
 	// +optional
 	DesiredRepoVolume string `json:"desiredRepoVolume,omitempty"`
+
+	// Status information for the most recent verification of the repository
+	// +optional
+	Verify *RepoVerifyStatus `json:"verify,omitempty"`
+
+	// A summary of the backups and WAL in the repository as reported by pgBackRest
+	// +optional
+	Catalog *RepoCatalog `json:"catalog,omitempty"`
+}
+
+// RepoCatalog summarizes the backups and WAL in a pgBackRest repository. Together,
+// they determine the points in time to which the repository can restore.
+// More info: https://pgbackrest.org/command.html#command-info
+type RepoCatalog struct {
+
+	// When the catalog was read from the repository
+	// +optional
+	UpdateTime *metav1.Time `json:"updateTime,omitempty"`
+
+	// When the operator last tried to read the repository
+	// +optional
+	AttemptTime *metav1.Time `json:"attemptTime,omitempty"`
+
+	// Why the repository could not be read at attemptTime. This is absent
+	// when it was read.
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// The number of times in a row the repository could not be read. Each one
+	// doubles the time until the next attempt, up to five minutes.
+	// +optional
+	Failures int32 `json:"failures,omitempty"`
+
+	// The number of backups in the repository
+	// +optional
+	Backups int32 `json:"backups"`
+
+	// The most recent full backup in the repository
+	// +optional
+	LatestFull *RepoBackup `json:"latestFull,omitempty"`
+
+	// The most recent differential backup in the repository
+	// +optional
+	LatestDifferential *RepoBackup `json:"latestDifferential,omitempty"`
+
+	// The most recent incremental backup in the repository
+	// +optional
+	LatestIncremental *RepoBackup `json:"latestIncremental,omitempty"`
+
+	// The name of the oldest WAL file in the archive of the current database
+	// +optional
+	WALMin string `json:"walMin,omitempty"`
+
+	// The name of the newest WAL file in the archive of the current database
+	// +optional
+	WALMax string `json:"walMax,omitempty"`
+}
+
+// RepoBackup describes one backup in a pgBackRest repository.
+type RepoBackup struct {
+
+	// The label that identifies the backup
+	// +required
+	Label string `json:"label"`
+
+	// When the backup started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// When the backup finished
+	// +optional
+	StopTime *metav1.Time `json:"stopTime,omitempty"`
+
+	// The WAL location at which the backup started
+	// +optional
+	StartLSN string `json:"startLSN,omitempty"`
+
+	// The WAL location at which the backup finished. PostgreSQL can be restored
+	// to this location or any later location that is in the archive.
+	// +optional
+	StopLSN string `json:"stopLSN,omitempty"`
+
+	// The size, in bytes, of the database restored from the backup
+	// +optional
+	DatabaseSize int64 `json:"databaseSize,omitempty"`
+
+	// The size, in bytes, of the backup in the repository
+	// +optional
+	RepoSize int64 `json:"repoSize,omitempty"`
+}
+
+// RepoVerifyStatus describes the most recent verification of a pgBackRest repository.
+type RepoVerifyStatus struct {
+
+	// The label of the latest backup that pgBackRest found valid
+	// +optional
+	LastVerifiedBackup string `json:"lastVerifiedBackup,omitempty"`
+
+	// Represents the time the verification Job was acknowledged by the Job controller
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Represents the time the verification Job finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// How long the verification, including any restore test, took
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Whether or not the verification and any restore test succeeded
+	// +optional
+	Succeeded bool `json:"succeeded"`
+
+	// Problems reported by the verification or the restore test
+	// +listType=atomic
+	// +optional
+	Errors []string `json:"errors,omitempty"`
 }
 
 // PGBackRestDataSource defines a pgBackRest configuration specifically for restoring from cloud-based data source
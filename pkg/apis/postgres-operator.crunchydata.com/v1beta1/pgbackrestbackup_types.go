@@ -0,0 +1,74 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PGBackRestBackupSpec describes one backup in a pgBackRest repository.
+type PGBackRestBackupSpec struct {
+
+	// The name of the PostgresCluster that took the backup
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	PostgresClusterName string `json:"postgresClusterName"`
+
+	// The name of the pgBackRest repository that holds the backup
+	// ---
+	// +kubebuilder:validation:Pattern=^repo[1-4]
+	// +required
+	RepoName string `json:"repoName"`
+
+	// The type of the backup: "full", "diff", or "incr"
+	// ---
+	// +kubebuilder:validation:Enum={full,diff,incr}
+	// +required
+	Type string `json:"type"`
+
+	// The label of the backup this one depends on, if any
+	// +optional
+	Prior string `json:"prior,omitempty"`
+
+	RepoBackup `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.postgresClusterName`
+//+kubebuilder:printcolumn:name="Repo",type=string,JSONPath=`.spec.repoName`
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+//+kubebuilder:printcolumn:name="Label",type=string,JSONPath=`.spec.label`
+//+kubebuilder:printcolumn:name="Stopped",type=date,JSONPath=`.spec.stopTime`
+//+versionName=v1beta1
+
+// PGBackRestBackup is a backup in a pgBackRest repository of a PostgresCluster.
+// PostgreSQL Operator creates and deletes these to match the output of
+// "pgbackrest info". They can be referenced by name when restoring.
+type PGBackRestBackup struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// PostgreSQL Operator keeps this in sync with the repository.
+	// ---
+	// +kubebuilder:validation:XValidation:rule=`self == oldSelf`,message=`PGBackRestBackup is read-only`
+	// +optional
+	Spec PGBackRestBackupSpec `json:"spec,omitzero"`
+}
+
+//+kubebuilder:object:root=true
+
+// PGBackRestBackupList contains a list of PGBackRestBackup
+type PGBackRestBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []PGBackRestBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PGBackRestBackup{}, &PGBackRestBackupList{})
+}
@@ -0,0 +1,102 @@
+// Copyright 2021 - 2026 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PGBackRestJobSpec describes a pgBackRest command to run once against a
+// repository of a PostgresCluster.
+type PGBackRestJobSpec struct {
+
+	// The name of the PostgresCluster in this namespace to run the command against
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	PostgresClusterName string `json:"postgresClusterName"`
+
+	// The name of the pgBackRest repository to run the command against
+	// ---
+	// +kubebuilder:validation:Pattern=^repo[1-4]
+	// +required
+	RepoName string `json:"repoName"`
+
+	// The pgBackRest command to run: "backup", "expire", "verify", or "stanza-upgrade"
+	// ---
+	// +kubebuilder:validation:Enum={backup,expire,verify,stanza-upgrade}
+	// +required
+	Operation string `json:"operation"`
+
+	// Command line options to include when running the pgBackRest command.
+	// The repository and stanza are set by the operator.
+	// https://pgbackrest.org/command.html
+	// ---
+	// +kubebuilder:validation:MaxItems=50
+	// +listType=atomic
+	// +optional
+	Options []string `json:"options,omitempty"`
+}
+
+// PGBackRestJobObjectStatus describes the progress of a PGBackRestJob.
+type PGBackRestJobObjectStatus struct {
+
+	// conditions represent the observations of a PGBackRestJob's current state.
+	// ---
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The Kubernetes Job that runs the command. Its ID is the name of the Job.
+	// +optional
+	Job *PGBackRestJobStatus `json:"job,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.postgresClusterName`
+//+kubebuilder:printcolumn:name="Repo",type=string,JSONPath=`.spec.repoName`
+//+kubebuilder:printcolumn:name="Operation",type=string,JSONPath=`.spec.operation`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="PGBackRestJobSuccessful")].reason`
+//+kubebuilder:printcolumn:name="Completed",type=date,JSONPath=`.status.job.completionTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+//+versionName=v1beta1
+
+// PGBackRestJob runs a pgBackRest command, such as an ad-hoc backup, once.
+// PostgresClusters run their PGBackRestJobs one at a time, oldest first, and
+// record the outcome in each. Create a new PGBackRestJob to run the command again.
+// Its name must be 52 characters or less so that its Job, "<name>-pgbackrest",
+// has a valid name.
+// ---
+// +kubebuilder:validation:XValidation:rule=`size(self.metadata.name) <= 52`,message=`the name of a PGBackRestJob must be 52 characters or less`
+type PGBackRestJob struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// The command cannot change after it is created.
+	// ---
+	// +kubebuilder:validation:XValidation:rule=`self == oldSelf`,message=`PGBackRestJob spec is immutable`
+	// +required
+	Spec PGBackRestJobSpec `json:"spec"`
+
+	// +optional
+	Status PGBackRestJobObjectStatus `json:"status,omitzero"`
+}
+
+//+kubebuilder:object:root=true
+
+// PGBackRestJobList contains a list of PGBackRestJob
+type PGBackRestJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []PGBackRestJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PGBackRestJob{}, &PGBackRestJobList{})
+}
@@ -62,6 +62,11 @@ type PGBouncerPodSpec struct {
 	// +optional
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
 
+	// Authentication settings for clients of PgBouncer, including access to
+	// the PgBouncer admin console.
+	// +optional
+	Authentication *PGBouncerAuthenticationSpec `json:"authentication,omitempty"`
+
 	// Configuration settings for the PgBouncer process. Changes to any of these
 	// values will be automatically reloaded without validation. Be careful, as
 	// you may put PgBouncer into an unusable state.
@@ -102,6 +107,13 @@ type PGBouncerPodSpec struct {
 	// +optional
 	PriorityClassName *string `json:"priorityClassName,omitempty"`
 
+	// Connection pools that read from PostgreSQL replicas. When specified,
+	// every database in spec.users and spec.databases gets a second pool with
+	// a suffix in its name that connects to the replica Service. These pools
+	// are also exposed by their own Service.
+	// +optional
+	ReadOnly *PGBouncerReadOnlySpec `json:"readOnly,omitempty"`
+
 	// Number of desired PgBouncer pods.
 	// +optional
 	// +kubebuilder:default=1
@@ -142,6 +154,89 @@ type PGBouncerPodSpec struct {
 	Volumes *PGBouncerVolumesSpec `json:"volumes,omitempty"`
 }
 
+type PGBouncerAuthenticationSpec struct {
+	// Users from spec.users that can run any command on the PgBouncer admin
+	// console, the "pgbouncer" database. Their passwords are copied into the
+	// PgBouncer authentication file.
+	// More info: https://www.pgbouncer.org/usage.html#admin-console
+	// ---
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	AdminUsers []PostgresIdentifier `json:"adminUsers,omitempty"`
+
+	// Users from spec.users that can run read-only commands, such as
+	// SHOW POOLS, on the PgBouncer admin console. Their passwords are copied
+	// into the PgBouncer authentication file.
+	// More info: https://www.pgbouncer.org/usage.html#admin-console
+	// ---
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	StatsUsers []PostgresIdentifier `json:"statsUsers,omitempty"`
+
+	// Client authentication rules in the order PgBouncer checks them. When
+	// specified, PgBouncer uses an HBA file and rejects connections that
+	// match no rule.
+	// More info: https://www.pgbouncer.org/config.html#hba-file-format
+	// ---
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	Rules []PGBouncerHBARule `json:"rules,omitempty"`
+}
+
+type PGBouncerHBARule struct {
+	// The connection transport this rule matches. Typical values are:
+	//  1. "host" for network connections that may or may not be encrypted.
+	//  2. "hostssl" for network connections encrypted using TLS.
+	// ---
+	// +kubebuilder:validation:Enum={host,hostssl,hostnossl}
+	// +required
+	Connection string `json:"connection"`
+
+	// Which databases this rule matches. When omitted or empty, this rule
+	// matches all databases. The admin console is the "pgbouncer" database.
+	// ---
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=atomic
+	// +optional
+	Databases []PostgresIdentifier `json:"databases,omitempty"`
+
+	// The authentication method to use when a connection matches this rule.
+	// The special value "reject" refuses connections that match this rule.
+	// More info: https://www.pgbouncer.org/config.html#auth_type
+	// ---
+	// +kubebuilder:validation:Enum={cert,md5,password,reject,scram-sha-256}
+	// +required
+	Method string `json:"method"`
+
+	// Which user names this rule matches. When omitted or empty, this rule
+	// matches all users.
+	// ---
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=atomic
+	// +optional
+	Users []PostgresIdentifier `json:"users,omitempty"`
+}
+
+type PGBouncerReadOnlySpec struct {
+	// The suffix added to database names to form the names of read-only
+	// pools. For example, clients connect to "app_ro" to read from replicas
+	// of the "app" database. Defaults to "_ro".
+	// ---
+	// +kubebuilder:default=_ro
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=10
+	// +kubebuilder:validation:Pattern=`^[-._A-Za-z0-9]+$`
+	// +optional
+	Suffix string `json:"suffix,omitempty"`
+
+	// Specification of the service that exposes the read-only pools.
+	// +optional
+	Service *ServiceSpec `json:"service,omitempty"`
+}
+
 // PGBouncerVolumesSpec defines the configuration for pgBouncer additional volumes
 type PGBouncerVolumesSpec struct {
 	// Additional pre-existing volumes to add to the pod.
@@ -65,6 +65,18 @@ type PGUpgradeSpec struct {
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 
+	// Whether to stop after checking that the cluster can be upgraded. The
+	// check runs "pg_upgrade --check" against the data of the primary once the
+	// cluster is shut down, and the upgrade does not start until it passes.
+	// When this is set, the check also runs while the cluster is online using
+	// clones of the primary's volumes. That requires a storage class that can
+	// clone volumes and enough space for the clones, and the clones of separate
+	// volumes are not taken at the same instant. The check fails when it does
+	// not finish in 30 minutes.
+	// More info: https://www.postgresql.org/docs/current/pgupgrade.html
+	// +optional
+	CheckOnly bool `json:"checkOnly,omitempty"`
+
 	PGUpgradeSettings `json:",inline"`
 }
 
@@ -118,12 +130,40 @@ type PGUpgradeStatus struct {
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// The report of the most recent "pg_upgrade --check".
+	// +optional
+	Check *PGUpgradeCheckStatus `json:"check,omitempty"`
+
 	// observedGeneration represents the .metadata.generation on which the status was based.
 	// +optional
 	// +kubebuilder:validation:Minimum=0
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// PGUpgradeCheckStatus is the report of "pg_upgrade --check".
+type PGUpgradeCheckStatus struct {
+	// The checks that pg_upgrade reported as not passing, in the order they ran.
+	// ---
+	// +listType=atomic
+	// +optional
+	Failures []PGUpgradeCheckFailure `json:"failures,omitempty"`
+}
+
+// PGUpgradeCheckFailure is one check of "pg_upgrade --check" that did not pass.
+type PGUpgradeCheckFailure struct {
+	// The description of the check, such as "Checking for reg* data types in user tables".
+	// +required
+	Check string `json:"check"`
+
+	// The result pg_upgrade reported for the check, such as "fatal".
+	// +required
+	Result string `json:"result"`
+
+	// The explanation pg_upgrade printed after the check.
+	// +optional
+	Details string `json:"details,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:storageversion
@@ -6,6 +6,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -71,7 +72,7 @@ type PostgresConfigSpec struct {
 	// +kubebuilder:validation:XValidation:rule=`!has(self.hot_standby)`,message=`hot_standby is always enabled`
 	// +kubebuilder:validation:XValidation:rule=`!has(self.synchronous_standby_names)`
 	// +kubebuilder:validation:XValidation:rule=`!has(self.primary_conninfo) && !has(self.primary_slot_name)`
-	// +kubebuilder:validation:XValidation:rule=`!has(self.recovery_min_apply_delay)`,message=`delayed replication is not supported at this time`
+	// +kubebuilder:validation:XValidation:rule=`!has(self.recovery_min_apply_delay)`,message=`delayed replication is configured by "replicationDelay" on instance sets`
 	//
 	// # Logging
 	// - https://www.postgresql.org/docs/current/runtime-config-logging.html
@@ -85,6 +86,175 @@ type PostgresConfigSpec struct {
 	Parameters map[string]intstr.IntOrString `json:"parameters,omitempty"`
 }
 
+type PostgresDatabaseSpec struct {
+	// The name of this PostgreSQL database.
+	// ---
+	// +required
+	Name PostgresIdentifier `json:"name"`
+
+	// The role that owns this database. The role must exist, either in the
+	// users list or in PostgreSQL itself.
+	// ---
+	// +optional
+	Owner PostgresIdentifier `json:"owner,omitempty"`
+
+	// The character set encoding of this database. This cannot change after
+	// the database is created; a difference is reported in status.
+	// More info: https://www.postgresql.org/docs/current/multibyte.html
+	// ---
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:Pattern=`^[-_A-Za-z0-9]+$`
+	// +optional
+	Encoding string `json:"encoding,omitempty"`
+
+	// The collation and character classification of this database. This
+	// cannot change after the database is created; a difference is reported
+	// in status.
+	// More info: https://www.postgresql.org/docs/current/locale.html
+	// ---
+	// +kubebuilder:validation:MaxLength=100
+	// +kubebuilder:validation:Pattern=`^[-.@_A-Za-z0-9]+$`
+	// +optional
+	Locale string `json:"locale,omitempty"`
+
+	// The ICU locale of this database. When set, the database uses the ICU
+	// locale provider; this requires PostgreSQL 15 or newer. This cannot
+	// change after the database is created; a difference is reported in status.
+	// More info: https://www.postgresql.org/docs/current/collation.html#COLLATION-MANAGING-STANDARD-ICU
+	// ---
+	// +kubebuilder:validation:MaxLength=100
+	// +kubebuilder:validation:Pattern=`^[-.@_=;A-Za-z0-9]+$`
+	// +optional
+	ICULocale string `json:"icuLocale,omitempty"`
+
+	// The database from which to copy this database when it is created.
+	// Defaults to "template1". Use "template0" when the encoding or locale
+	// differs from that of "template1".
+	// ---
+	// +optional
+	Template PostgresIdentifier `json:"template,omitempty"`
+
+	// How many concurrent connections can be made to this database. The
+	// value -1 means no limit.
+	// ---
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	ConnectionLimit *int32 `json:"connectionLimit,omitempty"`
+
+	// Extensions to install in this database. Removing an extension from
+	// this list does NOT drop the extension.
+	// ---
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	Extensions []PostgresExtensionSpec `json:"extensions,omitempty"`
+
+	// Schemas to create in this database. Removing a schema from this list
+	// does NOT drop the schema.
+	// ---
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	Schemas []PostgresSchemaSpec `json:"schemas,omitempty"`
+}
+
+type PostgresDatabaseStatus struct {
+	// The name of the database in spec.databases.
+	// +required
+	Name string `json:"name"`
+
+	// Identifies the configuration that has been applied to this database.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// The last time this database was compared to its spec.
+	// +optional
+	CheckTime *metav1.Time `json:"checkTime,omitempty"`
+
+	// conditions represent the observations of this database's current state.
+	// Known .status.conditions.type is: "Ready"
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// PostgresDatabaseStatus condition types and reasons.
+const (
+	PostgresDatabaseReady = "Ready"
+
+	PostgresDatabaseReasonApplied = "Applied"
+	PostgresDatabaseReasonDrifted = "Drifted"
+	PostgresDatabaseReasonFailed  = "Failed"
+)
+
+type PostgresExtensionSpec struct {
+	// The name of this PostgreSQL extension.
+	// ---
+	// +required
+	Name PostgresIdentifier `json:"name"`
+
+	// The version of this extension. The extension is updated when this
+	// differs from the installed version. Defaults to the version that
+	// PostgreSQL considers the default when the extension is installed.
+	// ---
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^[-._A-Za-z0-9]+$`
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// The schema in which to install this extension. This has no effect on an
+	// extension that is already installed.
+	// ---
+	// +optional
+	Schema PostgresIdentifier `json:"schema,omitempty"`
+}
+
+type PostgresDatabaseGrantSpec struct {
+	// The name of the database in which to grant privileges.
+	// ---
+	// +required
+	Name PostgresIdentifier `json:"name"`
+
+	// Privileges on the database itself.
+	// More info: https://www.postgresql.org/docs/current/ddl-priv.html
+	// ---
+	// +listType=set
+	// +kubebuilder:validation:items:Enum={CONNECT,CREATE,TEMPORARY}
+	// +optional
+	Privileges []string `json:"privileges,omitempty"`
+
+	// Privileges on schemas in this database and on the objects in them.
+	// ---
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	Schemas []PostgresSchemaGrantSpec `json:"schemas,omitempty"`
+}
+
+type PostgresGrantsSpec struct {
+	// Roles of which this user is a member. Roles that do not exist are
+	// created without the LOGIN option so they can act as groups.
+	// More info: https://www.postgresql.org/docs/current/role-membership.html
+	// ---
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	Roles []PostgresIdentifier `json:"roles,omitempty"`
+
+	// Privileges in each database. Privileges in databases that are missing
+	// from here are revoked.
+	// ---
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	Databases []PostgresDatabaseGrantSpec `json:"databases,omitempty"`
+}
+
 // ---
 type PostgresHBARule struct {
 	// The connection transport this rule matches. Typical values are:
@@ -171,14 +341,20 @@ type PostgresIdentifier = string
 
 type PostgresPasswordSpec struct {
 	// Type of password to generate. Defaults to ASCII. Valid options are ASCII
-	// and AlphaNumeric.
+	// and AlphaNumeric. This has no effect when secretRef is set.
 	// "ASCII" passwords contain letters, numbers, and symbols from the US-ASCII character set.
 	// "AlphaNumeric" passwords contain letters and numbers from the US-ASCII character set.
 	// ---
-	// +kubebuilder:default=ASCII
 	// +kubebuilder:validation:Enum={ASCII,AlphaNumeric}
-	// +required
-	Type string `json:"type"`
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// A Secret key that holds the password of this user. When set, no password
+	// is generated and changes to that Secret are applied to PostgreSQL. The
+	// Secret must be in the same namespace as the cluster.
+	// ---
+	// +optional
+	SecretRef *SecretKeyRef `json:"secretRef,omitempty"`
 }
 
 // PostgresPasswordSpec types.
@@ -187,6 +363,97 @@ const (
 	PostgresPasswordTypeASCII        = "ASCII"
 )
 
+// ---
+// +kubebuilder:validation:XValidation:rule=`self.policy != "Drop" || has(self.reassignTo)`,message=`"reassignTo" is required to drop users`
+type PostgresUserRemovalSpec struct {
+	// What happens to a PostgreSQL user when it is removed from the users list.
+	// "Retain" leaves the user and its access in place.
+	// "NoLogin" prevents the user from logging in and ends its current sessions.
+	// "Drop" reassigns objects owned by the user, revokes its privileges, and drops it.
+	// In every case, the Secret of the user is deleted once this is done.
+	// The "postgres" user is never changed.
+	// ---
+	// +kubebuilder:default=Retain
+	// +kubebuilder:validation:Enum={Retain,NoLogin,Drop}
+	// +required
+	Policy string `json:"policy"`
+
+	// The existing role that takes ownership of objects owned by dropped users.
+	// Required when policy is "Drop".
+	// More info: https://www.postgresql.org/docs/current/sql-reassign-owned.html
+	// ---
+	// +optional
+	ReassignTo PostgresIdentifier `json:"reassignTo,omitempty"`
+}
+
+// PostgresUserRemovalSpec policies.
+const (
+	PostgresUserRemovalDrop    = "Drop"
+	PostgresUserRemovalNoLogin = "NoLogin"
+	PostgresUserRemovalRetain  = "Retain"
+)
+
+type PostgresSchemaGrantSpec struct {
+	// The name of the schema in which to grant privileges.
+	// ---
+	// +required
+	Name PostgresIdentifier `json:"name"`
+
+	// Privileges on the schema itself.
+	// ---
+	// +listType=set
+	// +kubebuilder:validation:items:Enum={USAGE,CREATE}
+	// +optional
+	Privileges []string `json:"privileges,omitempty"`
+
+	// Privileges on every table, view, and foreign table in the schema.
+	// ---
+	// +listType=set
+	// +kubebuilder:validation:items:Enum={SELECT,INSERT,UPDATE,DELETE,TRUNCATE,REFERENCES,TRIGGER}
+	// +optional
+	Tables []string `json:"tables,omitempty"`
+
+	// Privileges on every sequence in the schema.
+	// ---
+	// +listType=set
+	// +kubebuilder:validation:items:Enum={USAGE,SELECT,UPDATE}
+	// +optional
+	Sequences []string `json:"sequences,omitempty"`
+
+	// Privileges on every function in the schema.
+	// ---
+	// +listType=set
+	// +kubebuilder:validation:items:Enum={EXECUTE}
+	// +optional
+	Functions []string `json:"functions,omitempty"`
+
+	// Roles that create objects in the schema. The table, sequence, and
+	// function privileges above also apply to objects these roles create
+	// in the future.
+	// More info: https://www.postgresql.org/docs/current/sql-alterdefaultprivileges.html
+	// ---
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	DefaultPrivilegesFor []PostgresIdentifier `json:"defaultPrivilegesFor,omitempty"`
+}
+
+type PostgresSchemaSpec struct {
+	// The name of this PostgreSQL schema.
+	// ---
+	// +required
+	Name PostgresIdentifier `json:"name"`
+
+	// The role that owns this schema. The role must exist, either in the
+	// users list or in PostgreSQL itself.
+	// ---
+	// +optional
+	Owner PostgresIdentifier `json:"owner,omitempty"`
+}
+
+// ---
+// +kubebuilder:validation:XValidation:rule=`!has(self.rotation) || !self.?password.secretRef.hasValue()`,message=`cannot rotate a password from "secretRef"`
+// +kubebuilder:validation:XValidation:rule=`!self.?rotation.gracePeriod.hasValue() || size(self.name) <= 54`,message=`the name of a user with a password grace period must be 54 characters or less`
 type PostgresUserSpec struct {
 	// The name of this PostgreSQL user. The value may contain only lowercase
 	// letters, numbers, and hyphen so that it fits into Kubernetes metadata.
@@ -225,4 +492,56 @@ type PostgresUserSpec struct {
 	// ---
 	// +optional
 	Password *PostgresPasswordSpec `json:"password,omitempty"`
+
+	// When to generate a new password for this user. Annotate the Secret of
+	// this user with "postgres-operator.crunchydata.com/rotate-password" to
+	// generate a new password right away; change the value of that annotation
+	// to do it again.
+	// ---
+	// +optional
+	Rotation *PostgresPasswordRotationSpec `json:"rotation,omitempty"`
+
+	// Privileges and role memberships of this user. When set, this user is
+	// NOT granted all privileges on its databases, and any privilege or
+	// membership that is missing from here is revoked. This field is ignored
+	// for the "postgres" user.
+	// ---
+	// +optional
+	Grants *PostgresGrantsSpec `json:"grants,omitempty"`
+}
+
+type PostgresPasswordRotationSpec struct {
+	// How often to generate a new password. An RFC 3339 duration or a number
+	// and unit: `12 hr`, `3d`, `4 weeks`, etc. When omitted, a new password is
+	// generated only when the Secret is annotated.
+	// ---
+	// Kubernetes ensures the value is in the "duration" format, but go ahead
+	// and loosely validate the format to show some acceptable units.
+	// NOTE: This rejects fractional numbers: https://github.com/kubernetes/kube-openapi/issues/523
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(h|hr|d|w|wk)|(hour|day|week)s?))+$`
+	//
+	// `controller-gen` needs to know "Type=string" to allow a "Pattern".
+	// +kubebuilder:validation:Type=string
+	//
+	// Set a max length to keep rule costs low.
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("1h") <= self && self <= duration("8760h")`,message="must be at least one hour"
+	//
+	// +optional
+	Interval *Duration `json:"interval,omitempty"`
+
+	// How long the previous password continues to work after a new password
+	// is generated. During this time, the Secret holds the previous password
+	// in "previous-password" and PostgreSQL accepts it for the login role in
+	// "previous-user", which acts as this user. Sessions that are established
+	// during this time stay connected after it ends.
+	// ---
+	// NOTE: This rejects fractional numbers: https://github.com/kubernetes/kube-openapi/issues/523
+	// +kubebuilder:validation:Pattern=`^((PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+|0)$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("0") <= self && self <= duration("720h")`,message="must be at most 30 days"
+	//
+	// +optional
+	GracePeriod *Duration `json:"gracePeriod,omitempty"`
 }
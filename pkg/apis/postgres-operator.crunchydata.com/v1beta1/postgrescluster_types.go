@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -59,12 +60,30 @@ type PostgresClusterSpec struct {
 	// +optional
 	CustomReplicationClientTLSSecret *corev1.SecretProjection `json:"customReplicationTLSSecret,omitempty"`
 
+	// A cert-manager Issuer or ClusterIssuer that signs the PostgreSQL server,
+	// replication client, and PgBouncer certificates. The operator creates
+	// cert-manager Certificates and uses their Secrets once they are issued.
+	// Custom TLS secrets take precedence. This has no effect when cert-manager
+	// is not installed.
+	// More info: https://cert-manager.io/docs/usage/certificate/
+	// +optional
+	CertificateIssuer *CertificateIssuerReference `json:"certificateIssuer,omitempty"`
+
 	// DatabaseInitSQL defines a ConfigMap containing custom SQL that will
 	// be run after the cluster is initialized. This ConfigMap must be in the same
 	// namespace as the cluster.
 	// +optional
 	DatabaseInitSQL *DatabaseInitSQL `json:"databaseInitSQL,omitempty"`
 
+	// Databases to create inside PostgreSQL and how to configure them. These
+	// are created before users are granted access to them. Removing a database
+	// from this list does NOT drop the database.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	Databases []PostgresDatabaseSpec `json:"databases,omitempty"`
+
 	// Whether or not the PostgreSQL cluster should use the defined default
 	// scheduling constraints. If the field is unset or false, the default
 	// scheduling constraints will be used in addition to any custom constraints
@@ -112,6 +131,17 @@ type PostgresClusterSpec struct {
 	// +optional
 	Instrumentation *InstrumentationSpec `json:"instrumentation,omitempty"`
 
+	// Periods during which PostgreSQL Operator may disrupt PostgreSQL: recreate
+	// Pods, restart PostgreSQL, roll out PgBouncer, or start a major upgrade.
+	// When this is empty, these happen as soon as they are needed. Add the
+	// "postgres-operator.crunchydata.com/ignore-maintenance-windows" annotation
+	// to disregard these windows.
+	// ---
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=atomic
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
 	// Whether or not the PostgreSQL cluster is being deployed to an OpenShift
 	// environment. If the field is unset, the operator will automatically
 	// detect the environment.
@@ -164,6 +194,14 @@ type PostgresClusterSpec struct {
 	// +optional
 	ReplicaService *ServiceSpec `json:"replicaService,omitempty"`
 
+	// Which replicas receive read-only traffic from the replica Service.
+	// +optional
+	ReplicaRouting *ReplicaRoutingSpec `json:"replicaRouting,omitempty"`
+
+	// How PostgreSQL Operator recreates instance Pods when their templates change.
+	// +optional
+	Rollout *RolloutStrategy `json:"rollout,omitempty"`
+
 	// Whether or not the PostgreSQL cluster should be stopped.
 	// When this is true, workloads are scaled to zero and CronJobs
 	// are suspended.
@@ -194,12 +232,18 @@ type PostgresClusterSpec struct {
 	// Users to create inside PostgreSQL and the databases they should access.
 	// The default creates one user that can access one database matching the
 	// PostgresCluster name. An empty list creates no users. Removing a user
-	// from this list does NOT drop the user nor revoke their access.
+	// from this list does NOT drop the user nor revoke their access unless
+	// userRemoval says otherwise.
 	// +listType=map
 	// +listMapKey=name
 	// +kubebuilder:validation:MaxItems=64
 	// +optional
 	Users []PostgresUserSpec `json:"users,omitempty"`
+
+	// What to do with PostgreSQL users that are removed from the users list.
+	// The default retains them.
+	// +optional
+	UserRemoval *PostgresUserRemovalSpec `json:"userRemoval,omitempty"`
 }
 
 // DataSource defines data sources for a new PostgresCluster.
@@ -288,6 +332,13 @@ type PostgresClusterDataSource struct {
 	// +kubebuilder:validation:Pattern=^repo[1-4]
 	RepoName string `json:"repoName"`
 
+	// The name of a PGBackRestBackup to restore. It must be in the namespace of
+	// the source PostgresCluster and describe a backup in repoName. When set,
+	// the restore uses that backup rather than the latest one, so do not also
+	// include "--set" in options.
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
 	// Command line options to include when running the pgBackRest restore command.
 	// https://pgbackrest.org/command.html#command-restore
 	// +optional
@@ -361,6 +412,12 @@ type PostgresClusterStatus struct {
 	// Identifies the databases that have been installed into PostgreSQL.
 	DatabaseRevision string `json:"databaseRevision,omitempty"`
 
+	// Current state of the databases in spec.databases.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Databases []PostgresDatabaseStatus `json:"databases,omitempty"`
+
 	// Current state of PostgreSQL instances.
 	// +listType=map
 	// +listMapKey=name
@@ -413,8 +470,8 @@ type PostgresClusterStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
 	// conditions represent the observations of postgrescluster's current state.
-	// Known .status.conditions.type are: "PersistentVolumeResizing",
-	// "Progressing", "ProxyAvailable"
+	// Known .status.conditions.type are: "CertificatesIssued", "InstanceSetsValid",
+	// "PendingMaintenance", "PersistentVolumeResizing", "Progressing", "ProxyAvailable"
 	// +optional
 	// +listType=map
 	// +listMapKey=type
@@ -424,12 +481,115 @@ type PostgresClusterStatus struct {
 
 // PostgresClusterStatus condition types.
 const (
+	CertificatesIssued          = "CertificatesIssued"
+	InstanceSetsValid           = "InstanceSetsValid"
+	PendingMaintenance          = "PendingMaintenance"
 	PersistentVolumeResizing    = "PersistentVolumeResizing"
 	PersistentVolumeResizeError = "PersistentVolumeResizeError"
 	PostgresClusterProgressing  = "Progressing"
 	ProxyAvailable              = "ProxyAvailable"
 )
 
+// RolloutStrategy describes how PostgreSQL Operator recreates instance Pods
+// when their templates change. Instances are recreated one at a time by
+// default, and the primary instance is always recreated last.
+type RolloutStrategy struct {
+	// The order in which instance sets roll out, by name. Instance sets that
+	// are not listed roll out after these.
+	// ---
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=set
+	// +optional
+	InstanceSetOrder []string `json:"instanceSetOrder,omitempty"`
+
+	// The number of instances that can be unavailable during a rollout.
+	// Defaults to 1.
+	// ---
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxUnavailable *int32 `json:"maxUnavailable,omitempty"`
+
+	// How far a replica can be behind the primary, in bytes of WAL it has yet
+	// to replay, and still count as available during a rollout. By default,
+	// replication lag is not considered.
+	// +optional
+	MaxReplicaLag *resource.Quantity `json:"maxReplicaLag,omitempty"`
+
+	// The number of seconds an instance must be ready before it counts as
+	// available during a rollout. Defaults to 0.
+	// ---
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+
+	// Whether or not to stop recreating instance Pods. Changes still go to
+	// their StatefulSets and roll out when this is false.
+	// +optional
+	Paused *bool `json:"paused,omitempty"`
+}
+
+// ReplicaRoutingSpec describes which replicas receive read-only traffic from
+// the replica Service and the read-only pools of PgBouncer.
+type ReplicaRoutingSpec struct {
+	// How far a replica can be behind the primary, in bytes of WAL, and still
+	// receive read-only traffic. Lag is the distance between the latest WAL
+	// location of the primary and the latest location replayed by each replica,
+	// as reported by the Patroni API. Replicas beyond this limit or with unknown
+	// lag receive no traffic while this is set. By default, replication lag in
+	// bytes is not considered.
+	// +optional
+	MaxLag *resource.Quantity `json:"maxLag,omitempty"`
+
+	// How far a replica can be behind the primary, in time, and still receive
+	// read-only traffic. Lag is the time since the last transaction replayed by
+	// each replica committed on the primary, or zero when a replica has replayed
+	// everything the primary has written. Replicas beyond this limit or with
+	// unknown lag receive no traffic while this is set. By default, replication
+	// lag in time is not considered.
+	// ---
+	// NOTE: This rejects fractional numbers: https://github.com/kubernetes/kube-openapi/issues/523
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("1s") <= self`,message="must be at least one second"
+	//
+	// +optional
+	MaxLagTime *Duration `json:"maxLagTime,omitempty"`
+}
+
+// MaintenanceWindow is a recurring period during which PostgreSQL Operator
+// may disrupt a PostgresCluster.
+type MaintenanceWindow struct {
+	// When the window opens, in Cron format: "minute hour day-of-month month day-of-week".
+	// Each field accepts "*", numbers, ranges, lists, and steps. Months and days
+	// of the week also accept names, e.g. "JAN" or "MON-FRI". The macros "@yearly",
+	// "@annually", "@monthly", "@weekly", "@daily", "@midnight", and "@hourly"
+	// are also accepted.
+	// https://k8s.io/docs/concepts/workloads/controllers/cron-jobs/#schedule-syntax
+	// ---
+	// +kubebuilder:validation:MaxLength=100
+	// +kubebuilder:validation:Pattern=`^(@(?i:yearly|annually|monthly|weekly|daily|midnight|hourly)|\S+( +\S+){4})$`
+	// +required
+	Schedule string `json:"schedule"`
+
+	// How long the window stays open after it opens
+	// ---
+	// NOTE: This rejects fractional numbers: https://github.com/kubernetes/kube-openapi/issues/523
+	// +kubebuilder:validation:Pattern=`^((PT)?( *[0-9]+ *(?i:(m|h|hr|d)|(min|hour|day)s?))+)$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("1m") <= self && self <= duration("168h")`,message="must be between one minute and one week"
+	// +required
+	Duration Duration `json:"duration"`
+
+	// The IANA time zone of the schedule, e.g. "America/New_York". Defaults to UTC.
+	// https://www.iana.org/time-zones
+	// ---
+	// +kubebuilder:validation:MaxLength=64
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
 type PostgresInstanceSetSpec struct {
 	// +optional
 	Metadata *Metadata `json:"metadata,omitempty"`
@@ -474,6 +634,10 @@ type PostgresInstanceSetSpec struct {
 	// +required
 	DataVolumeClaimSpec VolumeClaimSpecWithAutoGrow `json:"dataVolumeClaimSpec"`
 
+	// Patroni settings for the PostgreSQL pods in this set.
+	// +optional
+	Patroni *PatroniInstanceSetSpec `json:"patroni,omitempty"`
+
 	// Priority class name for the PostgreSQL pod. Changing this value causes
 	// PostgreSQL to restart.
 	// More info: https://kubernetes.io/docs/concepts/scheduling-eviction/pod-priority-preemption/
@@ -486,6 +650,24 @@ type PostgresInstanceSetSpec struct {
 	// +kubebuilder:validation:Minimum=1
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// How long the PostgreSQL pods in this set wait before applying changes
+	// from the primary. Delayed pods never become primary, do not receive
+	// traffic from the replica Service, and are not synchronous standbys.
+	// They can be used to recover from mistakes, such as dropped tables,
+	// without a full restore. Setting this on the first instance set, or removing
+	// it from the last, causes PostgreSQL to restart.
+	// More info: https://www.postgresql.org/docs/current/runtime-config-replication.html#GUC-RECOVERY-MIN-APPLY-DELAY
+	// ---
+	// PostgreSQL stores this value in milliseconds in a 32-bit integer, about 24 days.
+	// NOTE: This rejects fractional numbers: https://github.com/kubernetes/kube-openapi/issues/523
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("1s") <= self && self <= duration("576h")`,message="must be between one second and 24 days"
+	//
+	// +optional
+	ReplicationDelay *Duration `json:"replicationDelay,omitempty"`
+
 	// Minimum number of pods that should be available at a time.
 	// Defaults to one when the replicas field is greater than one.
 	// +optional
@@ -495,6 +677,14 @@ type PostgresInstanceSetSpec struct {
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitzero"`
 
+	// Specification of a Service that exposes only the replicas of this set.
+	// The Service is named "<cluster>-<set>-replicas", so the combined length
+	// of this set name and the cluster name must be 53 characters or less.
+	// When omitted, replicas of this set are reachable through the replica
+	// Service of the cluster only.
+	// +optional
+	Service *ServiceSpec `json:"service,omitempty"`
+
 	// Configuration for instance sidecar containers
 	// +optional
 	Sidecars *InstanceSidecars `json:"sidecars,omitempty"`
@@ -608,6 +798,22 @@ type PostgresInstanceSetStatus struct {
 	// Desired Size of the pgWAL volume
 	// +optional
 	DesiredPGWALVolume map[string]string `json:"desiredPGWALVolume,omitempty"`
+
+	// The Service that exposes only the replicas of this set.
+	// +optional
+	Service *PostgresInstanceSetServiceStatus `json:"service,omitempty"`
+}
+
+type PostgresInstanceSetServiceStatus struct {
+	// Name of the Service.
+	Name string `json:"name"`
+
+	// Network addresses, as "host:port", at which replicas of this set accept
+	// connections. These are the DNS name of the Service in Kubernetes followed
+	// by any addresses of its load balancer.
+	// +listType=atomic
+	// +optional
+	Endpoints []string `json:"endpoints,omitempty"`
 }
 
 // PostgresProxySpec is a union of the supported PostgreSQL proxies.
@@ -680,6 +886,7 @@ type PostgresUserInterfaceStatus struct {
 //+kubebuilder:subresource:status
 //+kubebuilder:storageversion
 //+versionName=v1beta1
+// +kubebuilder:validation:XValidation:fieldPath=`.spec.instances`,message=`the cluster name and the name of an instance set with a service must be 53 characters or less together`,rule=`!has(self.spec) || self.spec.instances.all(set, !has(set.service) || size(self.metadata.name) + size(set.name) <= 53)`
 // +operator-sdk:csv:customresourcedefinitions:resources={{ConfigMap,v1},{Secret,v1},{Service,v1},{CronJob,v1beta1},{Deployment,v1},{Job,v1},{StatefulSet,v1},{PersistentVolumeClaim,v1}}
 
 // PostgresCluster is the Schema for the postgresclusters API
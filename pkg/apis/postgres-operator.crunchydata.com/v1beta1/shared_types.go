@@ -273,6 +273,24 @@ type ServiceSpec struct {
 	ExternalTrafficPolicy *corev1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
 }
 
+// CertificateIssuerReference identifies a cert-manager Issuer or ClusterIssuer.
+type CertificateIssuerReference struct {
+	// Name of the issuer
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+
+	// Kind of the issuer, such as Issuer or ClusterIssuer
+	// +kubebuilder:default=Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// API group of the issuer. Set this to use an external issuer.
+	// +kubebuilder:default=cert-manager.io
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
 // Sidecar defines the configuration of a sidecar container
 type Sidecar struct {
 	// Resource requirements for a sidecar container